@@ -0,0 +1,365 @@
+package hl7
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// An AckCode is the acknowledgment code of MSA-1.
+type AckCode string
+
+const (
+	ApplicationAccept AckCode = "AA"
+	ApplicationError  AckCode = "AE"
+	ApplicationReject AckCode = "AR"
+	CommitAccept      AckCode = "CA"
+	CommitError       AckCode = "CE"
+	CommitReject      AckCode = "CR"
+)
+
+// Accepted reports whether c is AA or CA.
+func (c AckCode) Accepted() bool {
+	return c == ApplicationAccept || c == CommitAccept
+}
+
+// ACKOptions configures NewACK. The zero value acknowledges a message
+// without reporting any error.
+type ACKOptions struct {
+	// Err holds the problems to report in ERR segments, such as the error
+	// Unmarshal returned for the message. Each error of an ErrorList is
+	// reported on its own, with the segment and field it was found at.
+	Err error
+
+	// Text is written to MSA-3. It defaults to the text of Err.
+	Text string
+
+	// ControlId is the MSH-10 of the acknowledgment. If empty, one is made
+	// up from the current time and a counter.
+	ControlId string
+
+	// Time is the MSH-7 of the acknowledgment. It defaults to the current
+	// time.
+	Time time.Time
+}
+
+// NewACK returns an acknowledgment of the message original: an ACK whose
+// MSH sends from the application and facility original was sent to, back
+// to the ones that sent it, and whose MSA-2 is the MSH-10 of original. It
+// is written in the delimiters and character set of original.
+//
+// original asks for original mode by leaving MSH-15 and MSH-16 empty, in
+// which case the acknowledgment has code AA, AE or AR; a commit code, CA,
+// CE or CR, is turned into its application form. Otherwise it asks for
+// enhanced mode, and code is kept as given: a commit code for the commit
+// acknowledgment, whose conditions MSH-15 gives, or an application code
+// for the application acknowledgment, whose conditions MSH-16 gives. NewACK
+// returns nil, and no error, if the conditions rule out an acknowledgment
+// with code: never (NE), only on errors (ER) or only on success (SU). An
+// empty condition, like AL, asks for one always.
+//
+// Only the header of original needs to be readable, so NewACK can
+// acknowledge messages that failed to decode.
+func NewACK(original []byte, code AckCode, opts ACKOptions) ([]byte, error) {
+	original = bytes.TrimLeft(original, " \t\r\n")
+	m, err := ParseMessage(original)
+	if err != nil {
+		// what could not be read may lie past the header
+		if i := bytes.IndexAny(original, "\r\n"); i >= 0 {
+			m, err = ParseMessage(original[:i])
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	msh := m.Segments[0]
+
+	if msh.Field(15).IsEmpty() && msh.Field(16).IsEmpty() {
+		code = originalCode(code)
+	} else {
+		// MSH-15 governs commit acknowledgments, MSH-16 application ones
+		cond := msh.Field(16)
+		if strings.HasPrefix(string(code), "C") {
+			cond = msh.Field(15)
+		}
+		if !ackWanted(cond.String(), code.Accepted()) {
+			return nil, nil
+		}
+	}
+
+	t := opts.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	id := opts.ControlId
+	if id == "" {
+		id = t.Format("20060102150405") + strconv.FormatUint(ackSeq.Add(1), 10)
+	}
+	text := opts.Text
+	if text == "" && opts.Err != nil {
+		text = opts.Err.Error()
+	}
+
+	ack := ackMessage{
+		MSH: ackMSH{
+			FieldSeparator:       string(m.Delimiters.Field),
+			EncodingCharacters:   string(msh.Field(2).Bytes()),
+			SendingApplication:   rawValue(msh.Field(5).Bytes()),
+			SendingFacility:      rawValue(msh.Field(6).Bytes()),
+			ReceivingApplication: rawValue(msh.Field(3).Bytes()),
+			ReceivingFacility:    rawValue(msh.Field(4).Bytes()),
+			DateTime:             t,
+			MessageType:          ackType{Type: "ACK", TriggerEvent: rawValue(msh.Field(9).Component(2).Bytes())},
+			ControlId:            id,
+			ProcessingId:         rawValue(msh.Field(11).Bytes()),
+			VersionId:            rawValue(msh.Field(12).Bytes()),
+			CharacterSet:         rawValue(msh.Field(18).Bytes()),
+		},
+		MSA: ackMSA{
+			Code:      code,
+			ControlId: rawValue(msh.Field(10).Bytes()),
+			Text:      text,
+		},
+	}
+
+	errs := flattenErrors(opts.Err)
+	if len(errs) > 0 && versionAtLeast(msh.Field(12).Component(1).String(), 2, 5) {
+		// ERR-1 gave way to ERR-2 to ERR-8 in v2.5: one segment per error
+		for _, err := range errs {
+			loc, cond := errorLocation(m, err)
+			ack.ERR = append(ack.ERR, ackERR{
+				Point: errorPoint{
+					Segment:      loc.Segment,
+					Sequence:     loc.Ordinal,
+					Field:        loc.Field,
+					Repetition:   loc.Repetition,
+					Component:    loc.Component,
+					SubComponent: loc.SubComponent,
+				},
+				Code:        cond,
+				Severity:    "E",
+				UserMessage: err.Error(),
+			})
+		}
+	} else if len(errs) > 0 {
+		var eld []errorLocationAndCode
+		for _, err := range errs {
+			loc, cond := errorLocation(m, err)
+			eld = append(eld, errorLocationAndCode{
+				Segment:  loc.Segment,
+				Sequence: loc.Ordinal,
+				Field:    loc.Field,
+				Code:     cond,
+			})
+		}
+		ack.ERR = []ackERR{{Location: eld}}
+	}
+
+	return Marshal(&ack)
+}
+
+// ackSeq numbers the acknowledgments NewACK makes up control IDs for.
+var ackSeq atomic.Uint64
+
+// originalCode returns code in the form for original mode: AA, AE or AR.
+func originalCode(code AckCode) AckCode {
+	if len(code) != 2 || code[0] != 'C' {
+		return code
+	}
+
+	return "A" + code[1:]
+}
+
+// ackWanted reports whether the acknowledgment condition cond, of MSH-15
+// or MSH-16, asks for an acknowledgment of a message that was accepted or
+// not.
+func ackWanted(cond string, accepted bool) bool {
+	switch cond {
+	case "NE":
+		return false
+	case "ER":
+		return !accepted
+	case "SU":
+		return accepted
+	}
+
+	return true
+}
+
+// versionAtLeast reports whether the HL7 version v, such as "2.5.1", is
+// major.minor or later.
+func versionAtLeast(v string, major, minor int) bool {
+	s, rest, _ := strings.Cut(strings.TrimSpace(v), ".")
+	vMajor, err := strconv.Atoi(s)
+	if err != nil {
+		return false
+	}
+	s, _, _ = strings.Cut(rest, ".")
+	vMinor, _ := strconv.Atoi(s)
+
+	return vMajor > major || (vMajor == major && vMinor >= minor)
+}
+
+// flattenErrors returns the errors that err, if it is a list, joins, or
+// err itself.
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	if list, ok := err.(interface{ Unwrap() []error }); ok {
+		var errs []error
+		for _, err := range list.Unwrap() {
+			errs = append(errs, flattenErrors(err)...)
+		}
+		return errs
+	}
+
+	return []error{err}
+}
+
+// Message error condition codes, from HL7 table 0357.
+const (
+	errSegmentSequence = "100"
+	errDataType        = "102"
+	errInternal        = "207"
+)
+
+var errorConditions = map[string]string{
+	errSegmentSequence: "Segment sequence error",
+	errDataType:        "Data type error",
+	errInternal:        "Application internal error",
+}
+
+// errorLocation returns where in m the problem err was found and its
+// error condition.
+func errorLocation(m *Message, err error) (Location, errorCode) {
+	var (
+		loc  Location
+		cond = errInternal
+	)
+
+	var (
+		typeErr     *UnmarshalTypeError
+		unmarshaler *UnmarshalerError
+		length      *FieldLengthError
+		missing     *MissingRequiredSegmentError
+		unexpected  *UnexpectedSegmentError
+		syntax      *SyntaxError
+	)
+	switch {
+	case errors.As(err, &typeErr):
+		loc, cond = typeErr.Location, errDataType
+	case errors.As(err, &unmarshaler):
+		loc, cond = unmarshaler.Location, errDataType
+	case errors.As(err, &length):
+		loc, cond = length.Location, errDataType
+	case errors.As(err, &missing):
+		loc, cond = Location{Segment: missing.Segment}, errSegmentSequence
+	case errors.As(err, &unexpected):
+		loc, cond = m.locate(unexpected.Offset), errSegmentSequence
+	case errors.As(err, &syntax):
+		loc, cond = m.locate(syntax.Offset), errSegmentSequence
+	}
+
+	return loc, errorCode{Identifier: cond, Text: errorConditions[cond], CodingSystem: "HL70357"}
+}
+
+// locate returns the location of the segment of m that holds the byte at
+// offset.
+func (m *Message) locate(offset int) Location {
+	i := -1
+	for j, seg := range m.Segments {
+		if seg.Offset > offset {
+			break
+		}
+		i = j
+	}
+	if i < 0 {
+		return Location{}
+	}
+
+	loc := Location{Segment: m.Segments[i].Name, Offset: m.Segments[i].Offset}
+	for _, seg := range m.Segments[:i+1] {
+		if seg.Name == loc.Segment {
+			loc.Ordinal++
+		}
+	}
+
+	return loc
+}
+
+// A rawValue is text copied from a message into another written in the
+// same delimiters, so it is already escaped.
+type rawValue []byte
+
+func (r rawValue) MarshalHL7(Delimiters) ([]byte, error) {
+	return r, nil
+}
+
+type ackMessage struct {
+	MSH ackMSH
+	MSA ackMSA
+	ERR []ackERR
+}
+
+type ackMSH struct {
+	FieldSeparator       string
+	EncodingCharacters   string
+	SendingApplication   rawValue
+	SendingFacility      rawValue
+	ReceivingApplication rawValue
+	ReceivingFacility    rawValue
+	DateTime             time.Time
+	Security             string
+	MessageType          ackType
+	ControlId            string
+	ProcessingId         rawValue
+	VersionId            rawValue
+	CharacterSet         rawValue `hl7:"18"`
+}
+
+type ackType struct {
+	Type         string
+	TriggerEvent rawValue
+}
+
+type ackMSA struct {
+	Code      AckCode
+	ControlId rawValue
+	Text      string
+}
+
+type ackERR struct {
+	Location    []errorLocationAndCode // ERR-1, before v2.5
+	Point       errorPoint             `hl7:"2"`
+	Code        errorCode              `hl7:"3"`
+	Severity    string                 `hl7:"4"`
+	UserMessage string                 `hl7:"8"`
+}
+
+// errorLocationAndCode is the ELD data type of ERR-1.
+type errorLocationAndCode struct {
+	Segment  string
+	Sequence int
+	Field    int
+	Code     errorCode
+}
+
+// errorPoint is the ERL data type of ERR-2.
+type errorPoint struct {
+	Segment      string
+	Sequence     int
+	Field        int
+	Repetition   int
+	Component    int
+	SubComponent int
+}
+
+type errorCode struct {
+	Identifier   string
+	Text         string
+	CodingSystem string
+}
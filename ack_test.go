@@ -0,0 +1,168 @@
+package hl7
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/s-hammon/hl7/proto/standards/v23"
+	v23 "github.com/s-hammon/hl7/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+const ackInput = "MSH|^~\\&|Lab|LabFac|Rcv|RcvFac|20250101||ORU^R01|42|P|2.3\rPID|1||V1\r"
+
+var ackTime = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func TestNewACK(t *testing.T) {
+	b, err := NewACK([]byte(ackInput), ApplicationAccept, ACKOptions{ControlId: "A42", Time: ackTime})
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|Rcv|RcvFac|Lab|LabFac|20250102030405||ACK^R01|A42|P|2.3\r"+
+		"MSA|AA|42\r", string(b))
+
+	v, err := Parse(b)
+	require.NoError(t, err)
+	ack, ok := v.(*v23.ACK)
+	require.True(t, ok, "%T", v)
+	require.Equal(t, "AA", ack.MSA.AcknowledgementCode)
+	require.Equal(t, "42", ack.MSA.ControlId)
+	require.Equal(t, "Lab", ack.MSH.ReceivingApplication)
+
+	// a control ID and time are made up if not given
+	b, err = NewACK([]byte(strings.ReplaceAll(ackInput, "\r", "\n")), ApplicationAccept, ACKOptions{})
+	require.NoError(t, err)
+	msg, err := ParseMessage(b)
+	require.NoError(t, err)
+	msh := msg.Segments[0]
+	require.NotEmpty(t, msh.Field(10).String())
+	ts, err := msh.Field(7).Time()
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now(), ts, time.Minute)
+
+	// only the header has to be readable
+	b, err = NewACK([]byte(ackInput+"hello\r"), ApplicationReject, ACKOptions{ControlId: "A42", Time: ackTime})
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|Rcv|RcvFac|Lab|LabFac|20250102030405||ACK^R01|A42|P|2.3\r"+
+		"MSA|AR|42\r", string(b))
+
+	_, err = NewACK([]byte("PID|1\r"), ApplicationAccept, ACKOptions{})
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+}
+
+func TestNewACK_Errors(t *testing.T) {
+	errs := ErrorList{
+		&UnmarshalTypeError{Value: "string", Type: reflect.TypeFor[int](), Location: Location{Segment: "PID", Ordinal: 1, Field: 7}},
+		&MissingRequiredSegmentError{Segment: "PV1", Group: "ADT_A01"},
+		errors.New("database unavailable"),
+	}
+
+	b, err := NewACK([]byte(ackInput), ApplicationError, ACKOptions{Err: errs, Text: "rejected", ControlId: "A42", Time: ackTime})
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|Rcv|RcvFac|Lab|LabFac|20250102030405||ACK^R01|A42|P|2.3\r"+
+		"MSA|AE|42|rejected\r"+
+		"ERR|PID^1^7^102&Data type error&HL70357~PV1^^^100&Segment sequence error&HL70357~^^^207&Application internal error&HL70357\r",
+		string(b))
+
+	var ack v23.ACK
+	require.NoError(t, Unmarshal(b, &ack))
+	require.Len(t, ack.ERR.ErrorCodeAndLocation, 3)
+	require.Equal(t, v23.CM_ELD{
+		SegmentId:            "PID",
+		Sequence:             "1",
+		FieldPosition:        "7",
+		CodeIdentifyingError: v23.CE{Identifier: "102", Text: "Data type error", CodingSystem: "HL70357"},
+	}, ack.ERR.ErrorCodeAndLocation[0])
+
+	var pbAck pb.ACK
+	require.NoError(t, Unmarshal(b, &pbAck))
+	require.Equal(t, "AE", pbAck.MSA.AcknowledgementCode)
+	require.Len(t, pbAck.ERR.ErrorCodeAndLocation, 3)
+	require.Equal(t, "102", pbAck.ERR.ErrorCodeAndLocation[0].CodeIdentifyingError.Identifier)
+
+	// from v2.5, each error has an ERR segment of its own
+	in := strings.Replace(ackInput, "|P|2.3", "|P|2.5.1", 1)
+	b, err = NewACK([]byte(in), ApplicationReject, ACKOptions{Err: errs[:1], ControlId: "A42", Time: ackTime})
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|Rcv|RcvFac|Lab|LabFac|20250102030405||ACK^R01|A42|P|2.5.1\r"+
+		"MSA|AR|42|"+errs[0].Error()+"\r"+
+		"ERR||PID^1^7|102^Data type error^HL70357|E||||"+errs[0].Error()+"\r",
+		string(b))
+}
+
+func TestNewACK_DecodeError(t *testing.T) {
+	in := "MSH|^~\\&|Lab|LabFac|Rcv|RcvFac|20250101||ADT^A08|7|P|2.3\r" +
+		"PID|1||V1\r" +
+		"NK1|1\r" +
+		"NK1|x\r"
+
+	var m struct {
+		MSH v23.MSH
+		PID struct{ SetId int }
+		NK1 []struct{ SetId int }
+	}
+	err := UnmarshalOptions{AllErrors: true}.Unmarshal([]byte(in), &m)
+	require.Error(t, err)
+
+	b, err := NewACK([]byte(in), ApplicationError, ACKOptions{Err: err, Text: "bad NK1", ControlId: "A7", Time: ackTime})
+	require.NoError(t, err)
+	require.Contains(t, string(b), "\rERR|NK1^2^1^102&Data type error&HL70357\r")
+}
+
+func TestNewACK_Enhanced(t *testing.T) {
+	tests := []struct {
+		accept, app string
+		code        AckCode
+		want        string // MSA-1, or empty for no acknowledgment
+	}{
+		// commit acknowledgments, by MSH-15
+		{"AL", "", CommitAccept, "CA"},
+		{"AL", "NE", CommitReject, "CR"},
+		{"NE", "AL", CommitAccept, ""},
+		{"ER", "", CommitAccept, ""},
+		{"ER", "", CommitError, "CE"},
+		{"SU", "", CommitAccept, "CA"},
+		{"SU", "", CommitReject, ""},
+		// application acknowledgments, by MSH-16
+		{"", "AL", ApplicationError, "AE"},
+		{"NE", "AL", ApplicationAccept, "AA"},
+		{"AL", "NE", ApplicationAccept, ""},
+		{"AL", "ER", ApplicationAccept, ""},
+		{"AL", "ER", ApplicationReject, "AR"},
+		{"AL", "SU", ApplicationAccept, "AA"},
+		{"AL", "SU", ApplicationError, ""},
+		{"AL", "", ApplicationAccept, "AA"},
+	}
+
+	for _, tt := range tests {
+		in := strings.Replace(ackInput, "|P|2.3", "|P|2.3|||"+tt.accept+"|"+tt.app, 1)
+		b, err := NewACK([]byte(in), tt.code, ACKOptions{})
+		require.NoError(t, err)
+		if tt.want == "" {
+			require.Nil(t, b, "%+v", tt)
+			continue
+		}
+		require.Contains(t, string(b), "\rMSA|"+tt.want+"|42\r", "%+v", tt)
+	}
+
+	// a commit code is given in original mode as its application form
+	b, err := NewACK([]byte(ackInput), CommitAccept, ACKOptions{})
+	require.NoError(t, err)
+	require.Contains(t, string(b), "\rMSA|AA|42\r")
+}
+
+func TestNewACK_Delimiters(t *testing.T) {
+	in := "MSH#*~\\&#Lab*1.2.3*ISO#LabFac#Rcv#RcvFac#20250101##ORU*R01#4\\F\\2#P#2.3\r"
+
+	b, err := NewACK([]byte(in), ApplicationError, ACKOptions{Text: "bad * value", ControlId: "A42", Time: ackTime})
+	require.NoError(t, err)
+	require.Equal(t, "MSH#*~\\&#Rcv#RcvFac#Lab*1.2.3*ISO#LabFac#20250102030405##ACK*R01#A42#P#2.3\r"+
+		"MSA#AE#4\\F\\2#bad \\S\\ value\r", string(b))
+
+	msg, err := ParseMessage(b)
+	require.NoError(t, err)
+	msa, _ := msg.Segment("MSA")
+	require.Equal(t, "4#2", msa.Field(2).String())
+}
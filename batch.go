@@ -0,0 +1,212 @@
+package hl7
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// A File is an HL7 batch file: batches of messages, optionally enclosed in
+// an FHS file header and an FTS file trailer.
+type File struct {
+	Header  *BatchHeader // FHS; nil for a file without one
+	Batches []Batch
+	Trailer *BatchTrailer // FTS
+}
+
+// A Batch is a run of messages, normally enclosed in a BHS batch header
+// and a BTS batch trailer. Each message is the text of one message, with
+// its segments terminated by CR, ready for Unmarshal.
+type Batch struct {
+	Header   *BatchHeader // BHS; nil for a batch without one
+	Messages [][]byte
+	Trailer  *BatchTrailer // BTS
+}
+
+// A BatchHeader is an FHS file header or a BHS batch header, which have
+// the same fields.
+type BatchHeader struct {
+	FieldSeparator       string
+	EncodingCharacters   string
+	SendingApplication   string
+	SendingFacility      string
+	ReceivingApplication string
+	ReceivingFacility    string
+	CreationDateTime     string
+	Security             string
+	NameId               string
+	Comment              string
+	ControlId            string
+	ReferenceControlId   string
+}
+
+// A BatchTrailer is an FTS file trailer or a BTS batch trailer. Count is
+// FTS-1, the number of batches in the file, or BTS-1, the number of
+// messages in the batch. Totals is BTS-3, which FTS does not have.
+type BatchTrailer struct {
+	Count   Nullable[int]
+	Comment string
+	Totals  []string
+}
+
+// ParseFile splits the batch file data into its batches and messages.
+// Segments may be terminated by CR, LF or CRLF. FHS and FTS may be left out
+// of a file of one batch, and BHS and BTS of a batch that is the only one
+// in its file, down to a file of bare messages.
+//
+// ParseFile checks BTS-1 and FTS-1, where they are given, against the
+// messages and batches found, returning a *BatchCountError along with the
+// File if one does not match.
+func ParseFile(data []byte) (*File, error) {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	data = normalizeTerminators(make([]byte, 0, len(data)+1), data)
+
+	d := decodeState{file: true}
+	if d.load(data); d.savedError != nil {
+		return nil, d.savedError
+	}
+	d.scanNext()
+	if err := d.scanSegments(); err != nil {
+		return nil, err
+	}
+
+	var (
+		f        = new(File)
+		open     = false // whether the last batch may take more messages
+		closed   = false // whether FTS has been read
+		msgStart = -1
+		countErr error
+	)
+	endMessage := func(end int) {
+		if msgStart >= 0 {
+			b := &f.Batches[len(f.Batches)-1]
+			b.Messages = append(b.Messages, data[msgStart:end])
+			msgStart = -1
+		}
+	}
+
+	for pos, seg := range d.segments {
+		if closed {
+			return nil, &SyntaxError{msg: fmt.Sprintf("segment %s after FTS", seg.name), Offset: seg.offset}
+		}
+
+		switch seg.name {
+		case "FHS":
+			if pos > 0 {
+				return nil, &SyntaxError{msg: "FHS after the start of the file", Offset: seg.offset}
+			}
+			f.Header = new(BatchHeader)
+			d.assignSegment(reflect.ValueOf(f.Header).Elem(), pos)
+		case "BHS":
+			endMessage(seg.offset)
+			f.Batches = append(f.Batches, Batch{Header: new(BatchHeader)})
+			d.assignSegment(reflect.ValueOf(f.Batches[len(f.Batches)-1].Header).Elem(), pos)
+			open = true
+		case "MSH":
+			endMessage(seg.offset)
+			if !open {
+				f.Batches = append(f.Batches, Batch{})
+				open = true
+			}
+			msgStart = seg.offset
+		case "BTS":
+			endMessage(seg.offset)
+			if !open {
+				f.Batches = append(f.Batches, Batch{})
+			}
+			b := &f.Batches[len(f.Batches)-1]
+			b.Trailer = new(BatchTrailer)
+			d.assignSegment(reflect.ValueOf(b.Trailer).Elem(), pos)
+			if c := b.Trailer.Count; countErr == nil && c.IsPresent() && c.Value != len(b.Messages) {
+				countErr = &BatchCountError{Segment: "BTS", Count: c.Value, Actual: len(b.Messages), Offset: seg.offset}
+			}
+			open = false
+		case "FTS":
+			endMessage(seg.offset)
+			f.Trailer = new(BatchTrailer)
+			d.assignSegment(reflect.ValueOf(f.Trailer).Elem(), pos)
+			if c := f.Trailer.Count; countErr == nil && c.IsPresent() && c.Value != len(f.Batches) {
+				countErr = &BatchCountError{Segment: "FTS", Count: c.Value, Actual: len(f.Batches), Offset: seg.offset}
+			}
+			open, closed = false, true
+		default:
+			if msgStart < 0 {
+				return nil, &SyntaxError{msg: fmt.Sprintf("expecting MSH, got %q", seg.name), Offset: seg.offset}
+			}
+		}
+	}
+	endMessage(len(data))
+
+	if err := d.err(); err != nil {
+		return nil, err
+	}
+
+	return f, countErr
+}
+
+// Messages returns an iterator over the messages of every batch in f, in
+// file order.
+func (f *File) Messages() iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for _, b := range f.Batches {
+			for _, msg := range b.Messages {
+				if !yield(msg) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Marshal returns the text of f. Every batch is written with a BHS and a
+// BTS, and the file with an FHS and an FTS if it has either. BTS-1 and
+// FTS-1 are set to the number of messages and batches written. Each
+// header and the trailers after it are written in the delimiters the
+// header declares, or the default ones.
+func (f *File) Marshal() ([]byte, error) {
+	e := newEncodeState()
+
+	header := func(name string, h *BatchHeader) error {
+		if h == nil {
+			h = new(BatchHeader)
+		}
+		return e.segment(name, reflect.ValueOf(h).Elem())
+	}
+	trailer := func(name string, t *BatchTrailer, count int) error {
+		var tr BatchTrailer
+		if t != nil {
+			tr = *t
+		}
+		tr.Count = Nullable[int]{Value: count, Presence: Present}
+		return e.segment(name, reflect.ValueOf(&tr).Elem())
+	}
+
+	file := f.Header != nil || f.Trailer != nil
+	if file {
+		if err := header("FHS", f.Header); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, b := range f.Batches {
+		if err := header("BHS", b.Header); err != nil {
+			return nil, err
+		}
+		for _, msg := range b.Messages {
+			e.Write(bytes.TrimRight(msg, "\r\n"))
+			e.WriteByte('\r')
+		}
+		if err := trailer("BTS", b.Trailer, len(b.Messages)); err != nil {
+			return nil, err
+		}
+	}
+
+	if file {
+		if err := trailer("FTS", f.Trailer, len(f.Batches)); err != nil {
+			return nil, err
+		}
+	}
+
+	return bytes.Clone(e.Bytes()), nil
+}
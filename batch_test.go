@@ -0,0 +1,158 @@
+package hl7
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	v23 "github.com/s-hammon/hl7/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+const batchFile = "FHS|^~\\&|Lab|LabFac|||20250101||results.hl7|nightly run|F1\n" +
+	"BHS|^~\\&|Lab|LabFac|||20250101||ORU|first batch|B1\n" +
+	"MSH|^~\\&|Lab|LabFac|||||ORU^R01|1|P|2.3\n" +
+	"PID|1||V1\n" +
+	"MSH|^~\\&|Lab|LabFac|||||ORU^R01|2|P|2.3\n" +
+	"PID|1||V2\n" +
+	"BTS|2|end of first|10~20\n" +
+	"BHS|^~\\&|Lab|LabFac|||20250101||ORU||B2\n" +
+	"MSH|^~\\&|Lab|LabFac|||||ORU^R01|3|P|2.3\n" +
+	"PID|1||V3\n" +
+	"BTS|1\n" +
+	"FTS|2|done\n"
+
+func TestParseFile(t *testing.T) {
+	f, err := ParseFile([]byte(batchFile))
+	require.NoError(t, err)
+
+	require.Equal(t, &BatchHeader{
+		FieldSeparator:     "|",
+		EncodingCharacters: "^~\\&",
+		SendingApplication: "Lab",
+		SendingFacility:    "LabFac",
+		CreationDateTime:   "20250101",
+		NameId:             "results.hl7",
+		Comment:            "nightly run",
+		ControlId:          "F1",
+	}, f.Header)
+	require.Equal(t, &BatchTrailer{Count: Nullable[int]{Value: 2, Presence: Present}, Comment: "done"}, f.Trailer)
+
+	require.Len(t, f.Batches, 2)
+	require.Equal(t, "B1", f.Batches[0].Header.ControlId)
+	require.Equal(t, "B2", f.Batches[1].Header.ControlId)
+	require.Equal(t, []string{"10", "20"}, f.Batches[0].Trailer.Totals)
+	require.Len(t, f.Batches[0].Messages, 2)
+	require.Len(t, f.Batches[1].Messages, 1)
+
+	var ids []string
+	for msg := range f.Messages() {
+		var m v23.ORU_R01
+		require.NoError(t, UnmarshalOptions{Strict: true}.Unmarshal(msg, &m))
+		ids = append(ids, m.MSH.ControlId+"/"+m.Results[0].PID.InternalPatientId[0].Id)
+	}
+	require.Equal(t, []string{"1/V1", "2/V2", "3/V3"}, ids)
+}
+
+func TestParseFile_Bare(t *testing.T) {
+	in := "MSH|^~\\&|Lab|LabFac|||||ORU^R01|1|P|2.3\rPID|1||V1\r" +
+		"MSH|^~\\&|Lab|LabFac|||||ORU^R01|2|P|2.3\rPID|1||V2\r" +
+		"BTS\r"
+
+	f, err := ParseFile([]byte(in))
+	require.NoError(t, err)
+	require.Nil(t, f.Header)
+	require.Len(t, f.Batches, 1)
+	require.Nil(t, f.Batches[0].Header)
+	require.Equal(t, &BatchTrailer{}, f.Batches[0].Trailer)
+	require.Equal(t, "MSH|^~\\&|Lab|LabFac|||||ORU^R01|2|P|2.3\rPID|1||V2\r", string(f.Batches[0].Messages[1]))
+}
+
+func TestParseFile_Counts(t *testing.T) {
+	in := strings.Replace(batchFile, "BTS|1\n", "BTS|3\n", 1)
+	f, err := ParseFile([]byte(in))
+	var countErr *BatchCountError
+	require.ErrorAs(t, err, &countErr)
+	require.Equal(t, BatchCountError{Segment: "BTS", Count: 3, Actual: 1, Offset: strings.Index(in, "BTS|3")}, *countErr)
+	require.NotNil(t, f)
+	require.Len(t, f.Batches, 2)
+
+	in = strings.Replace(batchFile, "FTS|2", "FTS|1", 1)
+	_, err = ParseFile([]byte(in))
+	require.ErrorAs(t, err, &countErr)
+	require.Equal(t, fmt.Sprintf("hl7: FTS-1 counts 1 batches, found 2 (offset %d)", strings.Index(in, "FTS")), err.Error())
+}
+
+func TestParseFile_Errors(t *testing.T) {
+	tests := []string{
+		"",
+		"PID|1||V1\r",
+		"BHS|^~\\&\rPID|1||V1\r",
+		"BHS|^~\\&\rFHS|^~\\&\r",
+		"FHS|^~\\&\rFTS|0\rMSH|^~\\&|Lab\r",
+	}
+
+	for _, in := range tests {
+		_, err := ParseFile([]byte(in))
+		var syntaxErr *SyntaxError
+		require.ErrorAs(t, err, &syntaxErr, in)
+	}
+}
+
+func TestUnmarshal_Batch(t *testing.T) {
+	cr := func(s string) []byte { return []byte(strings.ReplaceAll(s, "\n", "\r")) }
+	tests := []struct {
+		in   []byte
+		want string
+	}{
+		{cr(batchFile), `expecting "MSH", got "FHS"`},
+		{cr(batchFile[strings.Index(batchFile, "BHS"):]), `expecting "MSH", got "BHS"`},
+		{cr(batchFile[strings.Index(batchFile, "MSH"):strings.Index(batchFile, "BTS")]), "more than one MSH segment"},
+	}
+
+	for _, tt := range tests {
+		var m v23.ORU_R01
+		require.ErrorContains(t, Unmarshal(tt.in, &m), tt.want)
+		var segs map[string]any
+		require.ErrorContains(t, Unmarshal(tt.in, &segs), tt.want)
+	}
+}
+
+func TestFile_Marshal(t *testing.T) {
+	f, err := ParseFile([]byte(batchFile))
+	require.NoError(t, err)
+
+	b, err := f.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, strings.ReplaceAll(batchFile, "\n", "\r"), string(b))
+
+	// counts follow the messages written; envelopes are added as needed
+	f.Batches[0].Messages = f.Batches[0].Messages[:1]
+	f.Batches[1].Header = nil
+	f.Header, f.Trailer = nil, nil
+	b, err = f.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, "BHS|^~\\&|Lab|LabFac|||20250101||ORU|first batch|B1\r"+
+		"MSH|^~\\&|Lab|LabFac|||||ORU^R01|1|P|2.3\rPID|1||V1\r"+
+		"BTS|1|end of first|10~20\r"+
+		"BHS|^~\\&\r"+
+		"MSH|^~\\&|Lab|LabFac|||||ORU^R01|3|P|2.3\rPID|1||V3\r"+
+		"BTS|1\r", string(b))
+
+	g, err := ParseFile(b)
+	require.NoError(t, err)
+	require.Len(t, g.Batches, 2)
+}
+
+func TestDecoder_Batch(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(batchFile))
+	dec.SetOptions(UnmarshalOptions{Strict: true})
+
+	var ids []string
+	for dec.More() {
+		var m v23.ORU_R01
+		require.NoError(t, dec.Decode(&m))
+		ids = append(ids, m.MSH.ControlId)
+	}
+	require.Equal(t, []string{"1", "2", "3"}, ids)
+}
@@ -0,0 +1,222 @@
+package hl7
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// A Charset converts text between a character set named in MSH-18 and
+// UTF-8.
+type Charset interface {
+	// Decode returns src, encoded in the character set, as UTF-8.
+	Decode(src []byte) ([]byte, error)
+	// Encode returns the UTF-8 text src encoded in the character set.
+	Encode(src []byte) ([]byte, error)
+}
+
+var charsets = struct {
+	sync.RWMutex
+	m map[string]Charset
+}{
+	m: map[string]Charset{
+		"ASCII":          passthrough{},
+		"UNICODE":        passthrough{},
+		"UNICODE UTF-8":  passthrough{},
+		"8859/1":         latin(nil),
+		"8859/15":        latin(latin9),
+		"UNICODE UTF-16": utf16Charset{},
+	},
+}
+
+// RegisterCharset makes cs available for messages whose MSH-18 is name,
+// replacing any Charset already registered for it. Names are compared
+// without regard to case. ASCII, 8859/1, 8859/15, UNICODE, UNICODE UTF-8
+// and UNICODE UTF-16 are built in; text in any other character set is
+// passed through unchanged.
+func RegisterCharset(name string, cs Charset) {
+	charsets.Lock()
+	defer charsets.Unlock()
+	charsets.m[strings.ToUpper(name)] = cs
+}
+
+func lookupCharset(name string) (Charset, bool) {
+	charsets.RLock()
+	defer charsets.RUnlock()
+
+	cs, ok := charsets.m[strings.ToUpper(name)]
+	return cs, ok
+}
+
+// decodeCharset returns data as UTF-8. UTF-16 input is recognized by its
+// byte order mark or by the byte pattern of its MSH; anything else is
+// decoded as the character set its MSH-18 declares. A character set with
+// no Charset registered leaves data as it is.
+func decodeCharset(data []byte) ([]byte, error) {
+	if isUTF16(data) {
+		return utf16Charset{}.Decode(data)
+	}
+
+	cs, ok := lookupCharset(charsetName(data))
+	if !ok {
+		return data, nil
+	}
+	if _, ok := cs.(utf16Charset); ok {
+		// UTF-16 input was caught above; this text was converted already
+		return data, nil
+	}
+
+	return cs.Decode(data)
+}
+
+// encodeCharset converts the UTF-8 message data to the character set its
+// MSH-18 declares.
+func encodeCharset(data []byte) ([]byte, error) {
+	cs, ok := lookupCharset(charsetName(data))
+	if !ok {
+		return data, nil
+	}
+
+	return cs.Encode(data)
+}
+
+// charsetName returns the first character set named in MSH-18 of data.
+func charsetName(data []byte) string {
+	return strings.TrimSpace(headerField(data, 18))
+}
+
+// headerField returns the first repetition of MSH-n in data, which must be
+// ASCII-compatible, with its components separated by '^' whatever the
+// message's own component delimiter. Leading whitespace and a byte order
+// mark are skipped, as in lenient mode.
+func headerField(data []byte, n int) string {
+	data = bytes.TrimLeft(bytes.TrimPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(data) < 8 || string(data[:3]) != "MSH" {
+		return ""
+	}
+
+	line := data
+	if i := bytes.IndexAny(line, "\r\n"); i >= 0 {
+		line = line[:i]
+	}
+
+	// the first member is "MSH" and the second MSH-2, so MSH-n is member n
+	i := 0
+	for f := range bytes.SplitSeq(line, data[3:4]) {
+		if i++; i == n {
+			rep, _, _ := bytes.Cut(f, data[5:6])
+			return strings.ReplaceAll(string(rep), string(data[4]), "^")
+		}
+	}
+
+	return ""
+}
+
+func isUTF16(data []byte) bool {
+	return bytes.HasPrefix(data, []byte{0xfe, 0xff}) ||
+		bytes.HasPrefix(data, []byte{0xff, 0xfe}) ||
+		bytes.HasPrefix(data, []byte("M\x00S\x00H\x00")) ||
+		bytes.HasPrefix(data, []byte("\x00M\x00S\x00H"))
+}
+
+// passthrough is a Charset for input that is already UTF-8 or ASCII.
+type passthrough struct{}
+
+func (passthrough) Decode(src []byte) ([]byte, error) { return src, nil }
+func (passthrough) Encode(src []byte) ([]byte, error) { return src, nil }
+
+// latin is an ISO 8859 Charset: Latin-1, with the code points in the map
+// replaced.
+type latin map[byte]rune
+
+// latin9 lists where ISO 8859-15 differs from ISO 8859-1.
+var latin9 = latin{
+	0xa4: '€', 0xa6: 'Š', 0xa8: 'š', 0xb4: 'Ž',
+	0xb8: 'ž', 0xbc: 'Œ', 0xbd: 'œ', 0xbe: 'Ÿ',
+}
+
+func (l latin) Decode(src []byte) ([]byte, error) {
+	out := make([]byte, 0, len(src)+len(src)/8)
+	for _, c := range src {
+		r, ok := l[c]
+		if !ok {
+			r = rune(c)
+		}
+		out = utf8.AppendRune(out, r)
+	}
+
+	return out, nil
+}
+
+func (l latin) Encode(src []byte) ([]byte, error) {
+	out := make([]byte, 0, len(src))
+	for _, r := range string(src) {
+		c, ok := l.byteFor(r)
+		if !ok {
+			return nil, fmt.Errorf("hl7: %q cannot be encoded in the message character set", r)
+		}
+		out = append(out, c)
+	}
+
+	return out, nil
+}
+
+func (l latin) byteFor(r rune) (byte, bool) {
+	for c, lr := range l {
+		if lr == r {
+			return c, true
+		}
+	}
+	if _, replaced := l[byte(r)]; r > 0xff || replaced {
+		return 0, false
+	}
+
+	return byte(r), true
+}
+
+// utf16Charset decodes UTF-16 in either byte order, taken from its byte
+// order mark and big-endian without one, and encodes big-endian with a
+// byte order mark.
+type utf16Charset struct{}
+
+func (utf16Charset) Decode(src []byte) ([]byte, error) {
+	if len(src)%2 != 0 {
+		return nil, fmt.Errorf("hl7: odd number of bytes in UTF-16 input")
+	}
+
+	bigEndian := true
+	switch {
+	case bytes.HasPrefix(src, []byte{0xfe, 0xff}):
+		src = src[2:]
+	case bytes.HasPrefix(src, []byte{0xff, 0xfe}):
+		src = src[2:]
+		bigEndian = false
+	case len(src) > 1 && src[0] != 0 && src[1] == 0:
+		bigEndian = false
+	}
+
+	units := make([]uint16, len(src)/2)
+	for i := range units {
+		hi, lo := src[2*i], src[2*i+1]
+		if !bigEndian {
+			hi, lo = lo, hi
+		}
+		units[i] = uint16(hi)<<8 | uint16(lo)
+	}
+
+	return []byte(string(utf16.Decode(units))), nil
+}
+
+func (utf16Charset) Encode(src []byte) ([]byte, error) {
+	units := utf16.Encode([]rune(string(src)))
+	out := make([]byte, 0, 2+2*len(units))
+	out = append(out, 0xfe, 0xff)
+	for _, u := range units {
+		out = append(out, byte(u>>8), byte(u))
+	}
+
+	return out, nil
+}
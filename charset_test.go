@@ -0,0 +1,191 @@
+package hl7
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	pb "github.com/s-hammon/hl7/proto/standards/v23"
+	v23 "github.com/s-hammon/hl7/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+type charsetMsg struct {
+	MSH v23.MSH
+	PID v23.PID
+}
+
+func charsetInput(charset, name string) []byte {
+	return []byte("MSH|^~\\&|App|Fac|||||ADT^A01|1|P|2.3||||||" + charset + "\r" +
+		"PID|1||V1||" + name + "\r")
+}
+
+func TestUnmarshal_Charset(t *testing.T) {
+	tests := []struct {
+		charset string
+		name    string
+		want    string
+	}{
+		{"", "DOE^JANE", "DOE"},
+		{"ASCII", "DOE^JANE", "DOE"},
+		{"UNICODE", "MÜLLER^JANE", "MÜLLER"},
+		{"UNICODE UTF-8", "MÜLLER^JANE", "MÜLLER"},
+		{"8859/1", "M\xdcLLER^JANE", "MÜLLER"},
+		{"8859/1~8859/15", "M\xdcLLER^JANE", "MÜLLER"},
+		{"8859/15", "\xa4\xbcUVRE^JANE", "€ŒUVRE"},
+	}
+
+	for _, tt := range tests {
+		var m charsetMsg
+		require.NoError(t, Unmarshal(charsetInput(tt.charset, tt.name), &m), tt.charset)
+		require.Equal(t, tt.want, m.PID.PatientName[0].FamilyName, tt.charset)
+		// MSH.CharacterSet has room for the first repetition only
+		charset, _, _ := strings.Cut(tt.charset, "~")
+		require.Equal(t, charset, m.MSH.CharacterSet)
+
+		msg, err := ParseMessage(charsetInput(tt.charset, tt.name))
+		require.NoError(t, err, tt.charset)
+		require.Equal(t, tt.want, pid(msg).Field(5).Component(1).String(), tt.charset)
+	}
+}
+
+func TestUnmarshal_CharsetUTF16(t *testing.T) {
+	in := string(charsetInput("UNICODE UTF-16", "MÜLLER^JANE"))
+	units := utf16.Encode([]rune(in))
+
+	encode := func(bom, bigEndian bool) []byte {
+		var b []byte
+		if bom {
+			b = append(b, 0xfe, 0xff)
+		}
+		for _, u := range units {
+			b = append(b, byte(u>>8), byte(u))
+		}
+		if !bigEndian {
+			for i := 0; i+1 < len(b); i += 2 {
+				b[i], b[i+1] = b[i+1], b[i]
+			}
+		}
+		return b
+	}
+
+	for _, bom := range []bool{true, false} {
+		for _, bigEndian := range []bool{true, false} {
+			var m charsetMsg
+			require.NoError(t, Unmarshal(encode(bom, bigEndian), &m))
+			require.Equal(t, "MÜLLER", m.PID.PatientName[0].FamilyName)
+			require.Equal(t, "UNICODE UTF-16", m.MSH.CharacterSet)
+		}
+	}
+
+	b, err := Marshal(&charsetMsg{
+		MSH: v23.MSH{MessageType: v23.CM_MSG{Type: "ADT", TriggerEvent: "A01"}, CharacterSet: "UNICODE UTF-16"},
+		PID: v23.PID{PatientName: []v23.XPN{{FamilyName: "MÜLLER"}}},
+	})
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(b, []byte{0xfe, 0xff, 0, 'M', 0, 'S', 0, 'H'}))
+
+	var m charsetMsg
+	require.NoError(t, Unmarshal(b, &m))
+	require.Equal(t, "MÜLLER", m.PID.PatientName[0].FamilyName)
+}
+
+func TestMarshal_Charset(t *testing.T) {
+	in := charsetInput("8859/1", "M\xdcLLER^JANE")
+
+	var m charsetMsg
+	require.NoError(t, Unmarshal(in, &m))
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, string(in), string(b))
+
+	m.PID.PatientName[0].FamilyName = "€"
+	_, err = Marshal(&m)
+	require.Error(t, err)
+
+	m.MSH.CharacterSet = "8859/15"
+	b, err = Marshal(&m)
+	require.NoError(t, err)
+	require.Contains(t, string(b), "PID|1||V1||\xa4^JANE\r")
+}
+
+func TestUnmarshal_UnknownCharset(t *testing.T) {
+	// text in a character set with no Charset registered is left as it is
+	in := charsetInput("8859/2", "\xa3UKASZ")
+	var m charsetMsg
+	require.NoError(t, Unmarshal(in, &m))
+	require.Equal(t, "\xa3UKASZ", m.PID.PatientName[0].FamilyName)
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, string(in), string(b))
+
+	msg, err := ParseMessage(in)
+	require.NoError(t, err)
+	require.Equal(t, string(in), string(msg.Bytes()))
+
+	b, err = NewACK(in, ApplicationReject, ACKOptions{ControlId: "A1"})
+	require.NoError(t, err)
+	require.Contains(t, string(b), "\rMSA|AR|1\r")
+}
+
+// cyrillic is the capital letters of ISO 8859-5, standing in for a
+// character set registered by the caller.
+type cyrillic struct{}
+
+func (cyrillic) Decode(src []byte) ([]byte, error) {
+	var out []byte
+	for _, c := range src {
+		if c >= 0xb0 && c < 0xd0 {
+			out = utf8.AppendRune(out, rune(c-0xb0)+'А')
+		} else {
+			out = append(out, c)
+		}
+	}
+	return out, nil
+}
+
+func (cyrillic) Encode(src []byte) ([]byte, error) {
+	var out []byte
+	for _, r := range string(src) {
+		if r >= 'А' && r <= 'Я' {
+			out = append(out, byte(r-'А')+0xb0)
+		} else {
+			out = append(out, byte(r))
+		}
+	}
+	return out, nil
+}
+
+func TestRegisterCharset(t *testing.T) {
+	RegisterCharset("8859/5", cyrillic{})
+
+	in := charsetInput("8859/5", "\xbf\xb5\xc2\xc0^\xb0")
+	var m charsetMsg
+	require.NoError(t, Unmarshal(in, &m))
+	require.Equal(t, "ПЕТР", m.PID.PatientName[0].FamilyName)
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, string(in), string(b))
+}
+
+func pid(m *Message) Segment {
+	seg, _ := m.Segment("PID")
+	return seg
+}
+
+func TestUnmarshal_CharsetProto(t *testing.T) {
+	in := "MSH|^~\\&|App|Fac|||||ADT^A01|1|P|2.3|||AL|NE|US|8859/1|EN\r"
+
+	// the generated MSH declares a field for each of MSH-1 to MSH-19, in order
+	var m struct{ MSH pb.MSH }
+	require.NoError(t, Unmarshal([]byte(in), &m))
+	require.Equal(t, "AL", m.MSH.AcceptAcknowledgementType)
+	require.Equal(t, "NE", m.MSH.ApplicationAcknowledgementType)
+	require.Equal(t, "US", m.MSH.CountryCode)
+	require.Equal(t, "8859/1", m.MSH.CharacterSet)
+	require.Equal(t, "EN", m.MSH.PrincipalLanguage)
+}
@@ -1,22 +1,130 @@
 package hl7
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
-	"strconv"
-	"strings"
+	"slices"
+	"sync"
+	"time"
 )
 
 func Unmarshal(data []byte, v any) error {
-	var d decodeState
+	return UnmarshalOptions{}.Unmarshal(data, v)
+}
+
+// UnmarshalOptions configures how a message is decoded. The zero value
+// decodes the same way as Unmarshal. Decoding into a *Message applies
+// Lenient, Strict and MaxFieldLength, and ignores the other options.
+type UnmarshalOptions struct {
+	// KeepFormatting leaves formatting escape sequences such as \.br\ and
+	// \H\ in decoded strings instead of rendering them as plain text.
+	KeepFormatting bool
+
+	// AllErrors reports every problem found in the message as an
+	// ErrorList instead of only the first one.
+	AllErrors bool
+
+	// TimeZone is the location of timestamps that carry no UTC offset.
+	// It defaults to UTC.
+	TimeZone *time.Location
+
+	// Strict rejects messages that the default mode lets through:
+	// segments the destination struct has no field for, which are
+	// otherwise skipped, and segment IDs that are not an upper-case
+	// letter followed by two upper-case letters or digits.
+	Strict bool
+
+	// MaxFieldLength, if positive, is the longest a field may be, in
+	// bytes of encoded text.
+	MaxFieldLength int
+
+	// AllowPartial accepts messages that lack segments tagged `required`.
+	AllowPartial bool
+
+	// Lenient accepts messages that stray from the encoding rules in ways
+	// some senders do: leading whitespace or a byte order mark, LF or CRLF
+	// segment terminators, blank lines, lines after the last segment that
+	// are not segments at all, and lower-case segment IDs.
+	Lenient bool
+}
+
+func (o UnmarshalOptions) timeZone() *time.Location {
+	if o.TimeZone == nil {
+		return time.UTC
+	}
+
+	return o.TimeZone
+}
+
+func (o UnmarshalOptions) Unmarshal(data []byte, v any) error {
+	if m, ok := v.(*Message); ok && m != nil {
+		return o.parseMessage(m, data)
+	}
+
+	d := decodeStatePool.Get().(*decodeState)
+	defer d.release()
+
+	d.opts = o
 	d.init(data)
 	if d.savedError != nil {
-		return d.savedError
+		return d.err()
 	}
 
 	return d.unmarshal(v)
 }
 
+// parseMessage parses data into m, applying the options that concern the
+// text of the message: Lenient, Strict and MaxFieldLength. The others are
+// for decoding into structs and maps, and have no effect on a Message.
+func (o UnmarshalOptions) parseMessage(m *Message, data []byte) error {
+	if !o.Lenient && !o.Strict && o.MaxFieldLength <= 0 {
+		// a Message keeps views into data rather than copies
+		if err := m.parse(data); err != nil {
+			return err
+		}
+		for _, seg := range m.Segments[1:] {
+			if seg.Name == "MSH" {
+				return errMultipleMSH(seg.Offset)
+			}
+		}
+		return nil
+	}
+
+	d := decodeStatePool.Get().(*decodeState)
+	defer d.release()
+
+	d.opts = o
+	d.init(data)
+	if d.savedError == nil {
+		d.scanMessage()
+	}
+	if err := d.err(); err != nil {
+		return err
+	}
+
+	// d.data is data itself unless it had to be converted or tidied
+	return m.parseUTF8(d.data)
+}
+
+// decodeStatePool holds the decodeStates of finished Unmarshal calls, so
+// that the buffers they grew serve the next call.
+var decodeStatePool = sync.Pool{
+	New: func() any {
+		return new(decodeState)
+	},
+}
+
+// release drops d's references to the message it decoded and returns it
+// to decodeStatePool.
+func (d *decodeState) release() {
+	d.data, d.text = nil, ""
+	clear(d.segments)
+	clear(d.unknown)
+	clear(d.errs)
+	decodeStatePool.Put(d)
+}
+
 type InvalidUnmarshalError struct {
 	Type reflect.Type
 }
@@ -32,13 +140,81 @@ func (e *InvalidUnmarshalError) Error() string {
 	return "hl7: Unmarshal(nil " + e.Type.String() + ")"
 }
 
+// Unmarshaler is the interface implemented by types that can decode their
+// own HL7 representation. raw is the undecoded ER7 text of the value,
+// escape sequences included: the whole segment (without its terminator)
+// for a segment, or the field, repetition, component or subcomponent the
+// value was matched to. delims are the delimiters declared by the message.
+// UnmarshalHL7 is not called for empty values.
+type Unmarshaler interface {
+	UnmarshalHL7(raw []byte, delims Delimiters) error
+}
+
+var unmarshalerType = reflect.TypeFor[Unmarshaler]()
+
+// A RawSegment is a segment kept as text. A []RawSegment field tagged
+// `hl7:",unknown"` in a message or group struct collects the segments
+// that no other field takes, such as Z-segments, and Marshal writes them
+// back at the position they were read from.
+type RawSegment struct {
+	Name  string
+	Data  []byte // the segment, without its terminator, in the delimiters of its message
+	Index int    // 0-based position of the segment in its message; MSH is 0
+}
+
+var rawSegmentsType = reflect.TypeFor[[]RawSegment]()
+
 type decodeState struct {
 	data       []byte
 	off        int // next read offset in data
 	prev       int // previous decoder state
 	hl7Idx     int // the current 1-based HL7 field index
 	scan       scanner
-	savedError error
+	opts       UnmarshalOptions
+	segments   []segment // every segment, in message order
+	savedError error     // the first error found
+	errs       []error   // every error found, when opts.AllErrors is set
+
+	segTypes segmentTypes // segment types registered with a Decoder
+
+	// unknown holds the `unknown` fields of the groups being decoded,
+	// innermost last.
+	unknown []reflect.Value
+
+	// text is data converted to a string once, so that the values taken
+	// from it share its memory.
+	text string
+
+	// fields holds the fields of every segment, each segment's starting
+	// with a placeholder for its name so that field n of the segment at
+	// fields[i] is fields[i+n]. marks holds the delimiters within them.
+	fields []span
+	marks  []mark
+
+	// reuse is set by a Decoder, which resets its destination before
+	// decoding into it: slices of the destination are then filled from
+	// their spare capacity rather than grown or replaced.
+	reuse bool
+
+	// file is set by ParseFile, whose input may start with FHS or BHS
+	// and holds any number of MSH segments.
+	file bool
+}
+
+// segment is a decoded segment along with its position in the message.
+type segment struct {
+	name       string
+	offset     int // byte offset of the segment name in data
+	first, end int // the segment's fields in decodeState.fields
+}
+
+// A span is a field of a segment, data[start:end], and the range of
+// decodeState.marks that falls within it. A literal span is neither split
+// nor unescaped: MSH-1 and MSH-2 are the delimiters themselves.
+type span struct {
+	start, end int
+	lo, hi     int
+	literal    bool
 }
 
 const (
@@ -50,20 +226,55 @@ const (
 	stateValue
 	stateError
 	stateEOF
+
+	// delimiters within a field, from the outermost in
+	stateRepetition
+	stateComponent
+	stateSubComponent
 )
 
 func (d *decodeState) init(data []byte) *decodeState {
-	d.data = data
 	d.off = 0
 	d.prev = stateBegin
+	d.segments = d.segments[:0]
+	d.fields = d.fields[:0]
+	d.marks = d.marks[:0]
+	d.unknown = d.unknown[:0]
+	d.savedError = nil
+	d.errs = d.errs[:0]
+
+	data, err := decodeCharset(data)
+	if err != nil {
+		d.data = nil
+		d.saveError(err)
+		return d
+	}
+	if d.opts.Lenient {
+		data = tidy(data)
+	}
+
+	return d.load(data)
+}
+
+// load sets up d to scan data, already in UTF-8, reading the delimiters
+// from its header segment.
+func (d *decodeState) load(data []byte) *decodeState {
+	d.data = data
+	d.text = string(data)
 
 	if len(d.data) < 8 {
-		d.savedError = fmt.Errorf("not enough bytes in header: expecting at least 8, got %d", len(d.data))
+		d.saveError(&SyntaxError{
+			msg:    fmt.Sprintf("not enough bytes in header: expecting at least 8, got %d", len(d.data)),
+			Offset: len(d.data),
+		})
 		return d
 	}
 
-	if string(d.data[:3]) != "MSH" {
-		d.savedError = fmt.Errorf("expecting \"MSH\", got %q", string(d.data[:3]))
+	if name := string(d.data[:3]); name != "MSH" && !(d.file && isHeaderSegment(name)) {
+		d.saveError(&SyntaxError{
+			msg:    fmt.Sprintf("expecting \"MSH\", got %q", string(d.data[:3])),
+			Offset: 0,
+		})
 		return d
 	}
 
@@ -72,6 +283,7 @@ func (d *decodeState) init(data []byte) *decodeState {
 	d.scan.repDelim = d.data[5]
 	d.scan.escDelim = d.data[6]
 	d.scan.subDelim = d.data[7]
+	d.scan.setStates()
 
 	d.off = 8
 	d.hl7Idx = 2
@@ -85,32 +297,63 @@ func (d *decodeState) unmarshal(v any) error {
 		return &InvalidUnmarshalError{reflect.TypeOf(v)}
 	}
 
+	if d.savedError != nil {
+		return d.err()
+	}
+
 	rv = rv.Elem()
 
-	var m map[string]any
-	d.scanNext()
-	if err := d.value(reflect.ValueOf(&m).Elem()); err != nil {
-		return err
+	if !d.scanMessage() {
+		return d.err()
 	}
 
 	switch rv.Kind() {
 	case reflect.Map:
+		m := d.segmentMaps()
+		d.typeSegments(m)
 		rv.Set(reflect.ValueOf(m))
 	case reflect.Struct:
-		return unmarshalStruct(rv, m)
+		if d.reuse {
+			resetValue(rv)
+		}
+		d.decodeGroup(rv, 0, nil)
 	}
 
-	return nil
+	return d.err()
 }
 
-func (d *decodeState) encodingChars() string {
-	chars := []byte{
-		d.scan.comDelim,
-		d.scan.repDelim,
-		d.scan.escDelim,
-		d.scan.subDelim,
+// scanMessage splits the message into segments and applies the checks of
+// d.opts that need no destination. It reports false if the message could
+// not be split, or holds more than one.
+func (d *decodeState) scanMessage() bool {
+	d.scanNext()
+	if err := d.scanSegments(); err != nil {
+		return false
 	}
-	return string(chars)
+	for _, seg := range d.segments[1:] {
+		if seg.name == "MSH" {
+			// a batch of messages is for ParseFile or a Decoder
+			d.saveError(errMultipleMSH(seg.offset))
+			return false
+		}
+	}
+	d.check()
+
+	return true
+}
+
+func errMultipleMSH(offset int) error {
+	return &SyntaxError{msg: "more than one MSH segment", Offset: offset}
+}
+
+// err returns the outcome of decoding: nil, the first error found, or an
+// ErrorList of all of them when opts.AllErrors is set.
+func (d *decodeState) err() error {
+	if d.opts.AllErrors && len(d.errs) > 0 {
+		return ErrorList(slices.Clone(d.errs))
+	}
+
+	return d.savedError
 }
 
 func (d *decodeState) scanNext() {
@@ -122,12 +365,19 @@ func (d *decodeState) scanNext() {
 	}
 }
 
+// scanValue scans to the end of the current field, adding the
+// repetition, component and subcomponent delimiters in it to d.marks so
+// that decoding the field need not look at its bytes again.
 func (d *decodeState) scanValue() {
 	s, data, i := &d.scan, d.data, d.off
-	for i < len(d.data) {
+	for i < len(data) {
 		current := s.state(data[i])
 		i++
-		if current != stateValue {
+		switch current {
+		case stateValue:
+		case stateRepetition, stateComponent, stateSubComponent:
+			d.marks = append(d.marks, mark{off: i - 1, state: current})
+		default:
 			d.prev = current
 			d.off = i
 			d.hl7Idx++
@@ -142,67 +392,170 @@ func (d *decodeState) readIndex() int {
 	return d.off - 1
 }
 
-func (d *decodeState) value(v reflect.Value) error {
-	t := v.Type()
+// scanSegments splits the message into d.segments, recording the bounds
+// of each field in d.fields.
+func (d *decodeState) scanSegments() error {
+	name, offset, first := d.text[:3], 0, len(d.fields)
+	d.fields = append(d.fields,
+		span{},
+		span{start: 3, end: 4, literal: true},
+		span{start: 4, end: 8, literal: true},
+	)
 
-	switch v.Kind() {
-	case reflect.Map:
-		if v.IsNil() {
-			v.Set(reflect.MakeMap(t))
+	start, lo := d.off, len(d.marks)
+	// a header with nothing after its delimiters has already ended
+	ended := d.prev == stateEndSegment
+	for {
+		if !ended {
+			d.scanValue()
+			switch d.prev {
+			case stateEOF, stateError:
+				if start < len(d.data) {
+					// the last field of a message without a final terminator
+					d.setField(name, first, d.hl7Idx+1, span{start: start, end: len(d.data), lo: lo, hi: len(d.marks)})
+				}
+				d.addSegment(name, offset, first)
+				return d.savedError
+			case stateFieldIdx:
+				d.setField(name, first, d.hl7Idx, span{start: start, end: d.readIndex(), lo: lo, hi: len(d.marks)})
+			case stateEndSegment:
+				d.setField(name, first, d.hl7Idx, span{start: start, end: d.readIndex(), lo: lo, hi: len(d.marks)})
+				ended = true
+			}
 		}
-	}
 
-	var (
-		segmentName string = "MSH"
-		fieldMap           = make(map[int]any)
-		inserted           = false
-	)
-
-	fieldMap[1] = string(d.scan.fldDelim)
-	fieldMap[2] = d.encodingChars()
+		if ended {
+			for {
+				d.addSegment(name, offset, first)
+				if d.off+3 > len(d.data) {
+					return d.savedError
+				}
 
-	start := d.off
+				offset, first = d.off, len(d.fields)
+				name = d.text[offset : offset+3]
+				d.fields = append(d.fields, span{})
+				d.scanN(3)
 
-	for {
-		d.scanValue()
-		switch d.prev {
-		case stateEOF, stateError:
-			if !inserted {
-				setSegmentValue(v, segmentName, fieldMap)
-			}
-			return d.savedError
-		case stateFieldIdx:
-			i := d.readIndex()
-			raw := string(d.data[start:i])
-			fieldMap[d.hl7Idx] = d.buildFieldValue(raw)
-		case stateEndSegment:
-			i := d.readIndex()
-			raw := string(d.data[start:i])
-			fieldMap[d.hl7Idx] = d.buildFieldValue(raw)
-
-			if !inserted {
-				setSegmentValue(v, segmentName, fieldMap)
-				inserted = true
+				d.scanNext()
+				d.hl7Idx = 0
+				if d.prev != stateEndSegment {
+					break
+				}
+				// a segment with nothing but its name
 			}
+			ended = false
 
-			if d.off+3 > len(d.data) {
-				return d.savedError
+			if isHeaderSegment(name) && d.prev == stateFieldIdx {
+				// the separator just read is field 1; field 2, which
+				// holds the other delimiters, is scanned next
+				d.setField(name, first, 1, span{start: d.off - 1, end: d.off, literal: true})
+				d.hl7Idx = 1
 			}
+		}
 
-			segmentName = string(d.data[d.off : d.off+3])
-			d.scanN(3)
+		start, lo = d.off, len(d.marks)
+	}
+}
 
-			fieldMap = make(map[int]any)
-			inserted = false
+// setField records sp as field n of the segment name whose fields start
+// at d.fields[first]. Field 2 of a header segment holds the delimiters and
+// is taken literally.
+func (d *decodeState) setField(name string, first, n int, sp span) {
+	if n == 2 && isHeaderSegment(name) {
+		sp.lo, sp.literal = sp.hi, true
+	}
+	for len(d.fields) <= first+n {
+		d.fields = append(d.fields, span{})
+	}
+	d.fields[first+n] = sp
+}
 
-			d.scanNext()
-			d.hl7Idx = 0
+// isHeaderSegment reports whether name is a segment whose first two fields
+// declare the delimiters: MSH, or the FHS and BHS headers of a batch file.
+func isHeaderSegment(name string) bool {
+	return name == "MSH" || name == "FHS" || name == "BHS"
+}
+
+func (d *decodeState) addSegment(name string, offset, first int) {
+	d.segments = append(d.segments, segment{
+		name:   name,
+		offset: offset,
+		first:  first,
+		end:    len(d.fields),
+	})
+}
+
+// check applies the segment ID and field length rules that opts asks
+// for to the segments found by value.
+func (d *decodeState) check() {
+	if !d.opts.Strict && d.opts.MaxFieldLength <= 0 {
+		return
+	}
+
+	for i, seg := range d.segments {
+		if d.opts.Strict {
+			next := seg.offset + 3
+			if !isSegmentName(seg.name) ||
+				next < len(d.data) && d.data[next] != d.scan.fldDelim && d.data[next] != '\r' {
+				d.saveError(&SyntaxError{
+					msg:    fmt.Sprintf("invalid segment ID %q", seg.name),
+					Offset: seg.offset,
+				})
+			}
 		}
 
-		start = d.off
+		if d.opts.MaxFieldLength > 0 {
+			for n := 1; n < seg.end-seg.first; n++ {
+				if isHeaderSegment(seg.name) && n <= 2 {
+					continue
+				}
+				p := path{seg: i, field: n}
+				start, end := d.bounds(p)
+				if end-start > d.opts.MaxFieldLength {
+					d.saveError(&FieldLengthError{
+						Location:  d.location(p),
+						Length:    end - start,
+						MaxLength: d.opts.MaxFieldLength,
+					})
+				}
+			}
+		}
 	}
 }
 
+// tidy corrects the deviations from the encoding rules that Lenient
+// accepts, returning a message with CR terminated, upper-case segments
+// and nothing before the first or after the last of them.
+func tidy(data []byte) []byte {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	data = bytes.TrimLeft(data, " \t\r\n")
+	if len(data) < 4 {
+		return data
+	}
+
+	fldDelim := data[3]
+	out := make([]byte, 0, len(data)+1)
+	good := 0
+	for line := range bytes.FieldsFuncSeq(data, func(r rune) bool { return r == '\r' || r == '\n' }) {
+		isSegment := len(line) >= 3 && (len(line) == 3 || line[3] == fldDelim)
+		if isSegment {
+			id := bytes.ToUpper(line[:3])
+			isSegment = isSegmentName(string(id))
+			out = append(out, id...)
+			out = append(out, line[3:]...)
+		} else {
+			out = append(out, line...)
+		}
+		out = append(out, '\r')
+		if isSegment {
+			good = len(out)
+		}
+	}
+
+	return out[:good]
+}
+
 func (d *decodeState) scanN(n int) {
 	for range n {
 		d.scanNext()
@@ -214,380 +567,789 @@ func (d *decodeState) eof() {
 	d.off = len(d.data) + 1
 }
 
-func (d *decodeState) buildFieldValue(raw string) any {
-	// repeats
-	if n := 1 + strings.Count(raw, string(d.scan.repDelim)); n > 1 {
-		out := make([]any, 0, n)
-		for p := range strings.SplitSeq(raw, string(d.scan.repDelim)) {
-			out = append(out, d.buildFieldValue(p))
-		}
+// A node is a field or a part of one, data[start:end], along with the
+// delimiters within it. state is the outermost delimiter it is split at:
+// stateRepetition for a whole field, and anything past stateSubComponent
+// for a value that is not split any further.
+type node struct {
+	start, end int
+	marks      []mark
+	state      int
+	literal    bool
+}
 
-		return out
+// field returns field n of the segment at pos, reporting whether the
+// segment has one.
+func (d *decodeState) field(pos, n int) (node, bool) {
+	seg := d.segments[pos]
+	if n < 1 || seg.first+n >= seg.end {
+		return node{}, false
 	}
-	// components
-	if n := 1 + strings.Count(raw, string(d.scan.comDelim)); n > 1 {
-		m := make(map[int]any, n)
-		i := 1
-		for p := range strings.SplitSeq(raw, string(d.scan.comDelim)) {
-			m[i] = d.buildSubComponentValue(p)
-			i++
+
+	sp := d.fields[seg.first+n]
+	return node{
+		start:   sp.start,
+		end:     sp.end,
+		marks:   d.marks[sp.lo:sp.hi],
+		state:   stateRepetition,
+		literal: sp.literal,
+	}, true
+}
+
+// level moves n down to the outermost state it has delimiters of, so
+// that a field without repetitions is split into its components and one
+// without either into its subcomponents.
+func (n node) level() node {
+	for n.state <= stateSubComponent && n.count() == 1 {
+		n.state++
+	}
+
+	return n
+}
+
+// count returns the number of parts n splits into.
+func (n node) count() int {
+	c := 1
+	for _, m := range n.marks {
+		if m.state == n.state {
+			c++
 		}
+	}
 
-		return m
+	return c
+}
+
+// part returns the i-th part of n, counting from 1, reporting whether n
+// has one. A value that is not split is its own first part.
+func (n node) part(i int) (node, bool) {
+	ps := n.parts()
+	for ; i > 1; i-- {
+		if _, ok := ps.next(); !ok {
+			return node{}, false
+		}
 	}
 
-	// subcomponents (or scaler if none exist)
-	return d.buildSubComponentValue(raw)
+	return ps.next()
 }
 
-func (d *decodeState) buildSubComponentValue(raw string) any {
-	if n := 1 + strings.Count(raw, string(d.scan.subDelim)); n > 1 {
-		m := make(map[int]any, n)
-		i := 1
-		for p := range strings.SplitSeq(raw, string(d.scan.subDelim)) {
-			m[i] = p
-			i++
+func (n node) parts() parts {
+	return parts{n: n, start: n.start}
+}
+
+// parts iterates over the parts of a node.
+type parts struct {
+	n     node
+	start int // where the next part starts
+	first int // the first of n.marks within the next part
+}
+
+func (ps *parts) next() (node, bool) {
+	n := ps.n
+	if ps.start > n.end {
+		return node{}, false
+	}
+
+	part := node{start: ps.start, end: n.end, marks: n.marks[ps.first:], state: n.state + 1}
+	for j := ps.first; j < len(n.marks); j++ {
+		if n.marks[j].state == n.state {
+			part.end, part.marks = n.marks[j].off, n.marks[ps.first:j]
+			ps.start, ps.first = part.end+1, j+1
+			return part, true
 		}
+	}
+	ps.start = n.end + 1
 
-		return m
+	return part, true
+}
+
+// nodeText returns the value of n, which must not be split any further.
+func (d *decodeState) nodeText(n node) string {
+	s := d.text[n.start:n.end]
+	if n.literal {
+		return s
 	}
 
-	return raw
+	return d.scan.unescape(s, d.opts.KeepFormatting)
 }
 
-func setSegmentValue(v reflect.Value, name string, fieldMap map[int]any) {
-	key := reflect.ValueOf(name)
-	existing := v.MapIndex(key)
+// isNull reports whether n is the explicit null.
+func (d *decodeState) isNull(n node) bool {
+	return !n.literal && len(n.marks) == 0 && d.text[n.start:n.end] == nullText
+}
 
-	if !existing.IsValid() {
-		v.SetMapIndex(key, reflect.ValueOf(fieldMap))
-		return
+// toAny returns n as a string, a map of components or subcomponents, or a
+// slice of repetitions.
+func (d *decodeState) toAny(n node) any {
+	n = n.level()
+	if n.state > stateSubComponent {
+		if d.isNull(n) {
+			return Null
+		}
+		return d.nodeText(n)
 	}
 
-	existing = existing.Elem()
+	var (
+		reps  []any
+		parts map[int]any
+	)
+	if n.state == stateRepetition {
+		reps = make([]any, 0, n.count())
+	} else {
+		parts = make(map[int]any, n.count())
+	}
 
-	switch existing.Kind() {
-	case reflect.Map:
-		slice := []map[int]any{
-			existing.Interface().(map[int]any),
-			fieldMap,
+	ps := n.parts()
+	for part, ok := ps.next(); ok; part, ok = ps.next() {
+		if reps != nil {
+			reps = append(reps, d.toAny(part))
+		} else {
+			parts[len(parts)+1] = d.toAny(part)
 		}
-		v.SetMapIndex(key, reflect.ValueOf(slice))
-	case reflect.Slice:
-		slice := existing.Interface().([]map[int]any)
-		slice = append(slice, fieldMap)
-		v.SetMapIndex(key, reflect.ValueOf(slice))
 	}
+
+	if reps != nil {
+		return reps
+	}
+	return parts
 }
 
-func unmarshalStruct(dst reflect.Value, data map[string]any) error {
-	t := dst.Type()
+// fieldMap returns the fields of the segment at pos keyed by HL7 index,
+// each as toAny returns it.
+func (d *decodeState) fieldMap(pos int) map[int]any {
+	seg := d.segments[pos]
+	m := make(map[int]any, seg.end-seg.first)
+	for n := 1; n < seg.end-seg.first; n++ {
+		f, _ := d.field(pos, n)
+		m[n] = d.toAny(f)
+	}
 
-	for i := range t.NumField() {
-		sf := t.Field(i)
-		fv := dst.Field(i)
+	return m
+}
 
-		// skip unexported
-		if !fv.CanSet() {
-			continue
+// segmentMaps returns the message as a map from segment ID to the
+// fieldMap of the segment, or a slice of them for a repeated segment.
+func (d *decodeState) segmentMaps() map[string]any {
+	m := make(map[string]any)
+	for pos, seg := range d.segments {
+		fields := d.fieldMap(pos)
+		switch existing := m[seg.name].(type) {
+		case nil:
+			m[seg.name] = fields
+		case map[int]any:
+			m[seg.name] = []map[int]any{existing, fields}
+		case []map[int]any:
+			m[seg.name] = append(existing, fields)
 		}
+	}
 
-		tag := parseTag(sf.Tag.Get("hl7"))
+	return m
+}
 
-		// group field
-		if tag.Options.Group() {
-			switch fv.Kind() {
-			case reflect.Slice, reflect.Pointer:
-				buildGroupSlice(fv, data)
-				continue
+// decodeGroup fills the message or group struct dst from d.segments,
+// starting at pos, and returns the position of the first segment it did
+// not consume. Segments are matched against the struct's fields in
+// declaration order, so a segment only lands in the group instance that it
+// follows. A segment that dst cannot take is left for the enclosing group
+// (parent reports which names it would still accept); one that no
+// enclosing group can take either is skipped.
+func (d *decodeState) decodeGroup(dst reflect.Value, pos int, parent func(string) bool) int {
+	plan := cachedGroup(dst.Type())
+	children := plan.children
+	if plan.unknown >= 0 {
+		d.unknown = append(d.unknown, dst.Field(plan.unknown))
+		defer func() { d.unknown = d.unknown[:len(d.unknown)-1] }()
+	}
+	filled := make([]bool, len(children))
+	start := pos
+	cur := 0
+	defer func() {
+		if d.opts.AllowPartial {
+			return
+		}
+		for j, c := range children {
+			if c.required && !c.group && !filled[j] {
+				offset := len(d.data)
+				if start < len(d.segments) {
+					offset = d.segments[start].offset
+				}
+				d.saveError(&MissingRequiredSegmentError{
+					Segment: c.name,
+					Group:   dst.Type().Name(),
+					Offset:  offset,
+				})
 			}
 		}
+	}()
 
-		// segment field
-		segName := tag.Name
-		if segName == "" {
-			segName = sf.Name
+	accepts := func(name string) bool {
+		if findChild(children, cur, name) >= 0 {
+			return true
 		}
+		return parent != nil && parent(name)
+	}
 
-		if segData, ok := data[segName]; ok {
-			assignSegment(fv, segData)
-			continue
-		}
+	for pos < len(d.segments) {
+		seg := d.segments[pos]
 
-		// nested struct
-		switch fv.Kind() {
-		case reflect.Struct:
-			unmarshalStruct(fv, data)
-		case reflect.Pointer:
-			if fv.Type().Elem().Kind() != reflect.Struct {
-				continue
+		j := findChild(children, cur, seg.name)
+		if j < 0 {
+			if parent != nil && parent(seg.name) {
+				return pos
 			}
-			if fv.IsNil() {
-				fv.Set(reflect.New(fv.Type().Elem()))
+			if n := len(d.unknown); n > 0 {
+				d.addUnknown(d.unknown[n-1], pos)
+			} else if d.opts.Strict {
+				d.saveError(&UnexpectedSegmentError{
+					Segment: seg.name,
+					Group:   dst.Type().Name(),
+					Offset:  seg.offset,
+				})
 			}
-			unmarshalStruct(fv.Elem(), data)
+			pos++
+			continue
+		}
+
+		c := children[j]
+		fv := dst.Field(c.index)
+		if c.group {
+			pos = d.decodeGroup(d.newGroupElem(fv), pos, accepts)
+		} else {
+			d.assignSegment(fv, pos)
+			pos++
+		}
+
+		filled[j] = true
+		cur = j
+		if !c.repeated {
+			cur++
 		}
 	}
 
-	return nil
+	return pos
 }
 
-func buildGroupSlice(dst reflect.Value, data map[string]any) {
-	var groupType reflect.Type
-	isSlice := dst.Kind() == reflect.Slice
-	if isSlice {
-		groupType = dst.Type().Elem()
-	} else {
-		groupType = dst.Type()
+// addUnknown appends the segment at pos to dst, a []RawSegment.
+func (d *decodeState) addUnknown(dst reflect.Value, pos int) {
+	seg := d.segments[pos]
+	raw := RawSegment{
+		Name:  seg.name,
+		Data:  bytes.Clone(d.data[seg.offset:d.endOfSegment(seg.offset)]),
+		Index: pos,
 	}
-	fields := groupFields(groupType)
+	dst.Set(reflect.Append(dst, reflect.ValueOf(raw)))
+}
 
-	var anchor groupField
-	for _, f := range fields {
-		if f.Required {
-			anchor = f
-			break
-		}
-	}
+// groupChild describes a field of a message or group struct that holds
+// either segments or nested groups.
+type groupChild struct {
+	index    int
+	name     string // segment ID; empty for groups
+	group    bool
+	repeated bool
+	required bool
+	typ      reflect.Type // struct type of a single segment or group
+}
 
-	if anchor.Name == "" {
-		return
+// A groupPlan is the decoding plan for a message or group struct type.
+type groupPlan struct {
+	children []groupChild
+
+	// unknown is the index of the field tagged `unknown`, or -1.
+	unknown int
+
+	// first holds the segment IDs that can begin an instance of the
+	// group: those of its segments that come before, or are, its first
+	// required member, including the ones that can begin a nested group
+	// in that range.
+	first map[string]bool
+}
+
+var groupCache sync.Map // map[reflect.Type]*groupPlan
+
+// cachedGroup returns the plan for the message or group struct type typ,
+// computing it on first use.
+func cachedGroup(typ reflect.Type) *groupPlan {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if p, ok := groupCache.Load(typ); ok {
+		return p.(*groupPlan)
 	}
 
-	segments := normalizeSegmentSlice(data[anchor.Name])
-	count := len(segments)
-	if !isSlice {
-		if count > 1 {
-			count = 1
+	p := &groupPlan{children: groupChildren(typ), unknown: -1, first: make(map[string]bool)}
+	for i := range typ.NumField() {
+		sf := typ.Field(i)
+		if sf.IsExported() && sf.Type == rawSegmentsType && parseTag(sf.Tag.Get("hl7")).Options.Unknown() {
+			p.unknown = i
+			break
 		}
 	}
-
-	sliceType := reflect.SliceOf(groupType)
-	out := reflect.MakeSlice(sliceType, count, count)
-	for i := range count {
-		var group reflect.Value
-		if groupType.Kind() == reflect.Pointer {
-			group = reflect.New(groupType.Elem())
-			for _, f := range fields {
-				assignGroupField(group.Elem().Field(f.Index), f, data, i)
+	for _, c := range p.children {
+		if c.group {
+			for name := range cachedGroup(c.typ).first {
+				p.first[name] = true
 			}
-			unmarshalStruct(group.Elem(), data)
 		} else {
-			group = reflect.New(groupType).Elem()
-			for _, f := range fields {
-				assignGroupField(group.Field(f.Index), f, data, i)
-			}
-			unmarshalStruct(group, data)
+			p.first[c.name] = true
 		}
-		out.Index(i).Set(group)
-	}
 
-	if isSlice {
-		dst.Set(out)
-	} else if count > 0 {
-		dst.Set(out.Index(0))
+		if c.required {
+			break
+		}
 	}
-}
 
-type groupField struct {
-	Name     string
-	Required bool
-	Index    int
+	actual, _ := groupCache.LoadOrStore(typ, p)
+	return actual.(*groupPlan)
 }
 
-func groupFields(typ reflect.Type) []groupField {
-	if typ.Kind() == reflect.Pointer {
-		typ = typ.Elem()
-	}
-	out := make([]groupField, 0, typ.NumField())
+func groupChildren(typ reflect.Type) []groupChild {
+	out := make([]groupChild, 0, typ.NumField())
 
 	for i := range typ.NumField() {
 		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
 		tag := parseTag(sf.Tag.Get("hl7"))
+		if tag.Options.Unknown() {
+			continue
+		}
 
-		if tag.Name == "" {
+		c := groupChild{index: i, typ: sf.Type}
+		if c.typ.Kind() == reflect.Slice {
+			c.repeated = true
+			c.typ = c.typ.Elem()
+		}
+		if c.typ.Kind() == reflect.Pointer {
+			c.typ = c.typ.Elem()
+		}
+		isAny := c.typ.Kind() == reflect.Interface
+		if c.typ.Kind() != reflect.Struct && !isAny {
 			continue
 		}
 
-		if sf.Type.Kind() == reflect.Slice {
+		name := tag.Name
+		if name == "" {
+			name = sf.Name
+		}
+
+		c.required = tag.Options.Required()
+		if !tag.Options.Group() && isSegmentName(name) {
+			c.name = name
+		} else if isAny {
+			// an interface holds a segment of a registered type, never a group
 			continue
+		} else {
+			c.group = true
 		}
 
-		out = append(out, groupField{
-			Name:     tag.Name,
-			Required: tag.Options.Required(),
-			Index:    i,
-		})
+		out = append(out, c)
 	}
 
 	return out
 }
 
-func assignGroupField(dst reflect.Value, f groupField, data map[string]any, idx int) {
-	seg, ok := data[f.Name]
-	if !ok {
-		return
-	}
-
-	segments := normalizeSegmentSlice(seg)
-	if idx >= len(segments) {
-		return
+// findChild returns the index of the first child at or after cur that can
+// take a segment called name, or -1.
+func findChild(children []groupChild, cur int, name string) int {
+	for j := cur; j < len(children); j++ {
+		c := children[j]
+		if c.group {
+			if cachedGroup(c.typ).first[name] {
+				return j
+			}
+		} else if c.name == name {
+			return j
+		}
 	}
 
-	assignSegment(dst, segments[idx])
+	return -1
 }
 
-func normalizeSegmentSlice(seg any) []map[int]any {
-	switch v := seg.(type) {
+// newGroupElem returns the struct a new group instance should be decoded
+// into: dst itself, the value it points to, or a new element appended to
+// it.
+func (d *decodeState) newGroupElem(dst reflect.Value) reflect.Value {
+	switch dst.Kind() {
 	default:
-		return nil
-	case map[int]any:
-		return []map[int]any{v}
-	case []map[int]any:
-		return v
+		return dst
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return dst.Elem()
+	case reflect.Slice:
+		return d.newGroupElem(d.appendElem(dst))
 	}
 }
 
-func assignSegment(dst reflect.Value, seg any) {
-	switch v := seg.(type) {
-	case map[int]any:
-		switch dst.Kind() {
-		default:
-			return
-		case reflect.Struct:
-			assignSegmentStruct(dst, v)
-		case reflect.Pointer:
-			if dst.IsNil() {
-				dst.Set(reflect.New(dst.Type().Elem()))
-			}
-			assignSegmentStruct(dst.Elem(), v)
-		case reflect.Slice:
-			if dst.IsNil() {
-				dst.Set(reflect.MakeSlice(dst.Type(), 0, 1))
+// appendElem adds an element to the slice dst and returns it. When
+// reusing, the element comes from the spare capacity of dst, which
+// resetValue left cleared, pointers and all.
+func (d *decodeState) appendElem(dst reflect.Value) reflect.Value {
+	n := dst.Len()
+	if d.reuse && n < dst.Cap() {
+		dst.SetLen(n + 1)
+	} else {
+		dst.Set(reflect.Append(dst, reflect.Zero(dst.Type().Elem())))
+	}
+
+	return dst.Index(n)
+}
+
+// resetValue clears v for a Decoder to decode a new message into. Slices
+// are emptied but keep their memory, and the structs their pointers point
+// to, for appendElem and assignRepetitions to reuse; other pointers, maps
+// and interfaces are set to nil.
+func resetValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if f := v.Field(i); f.CanSet() {
+				resetValue(f)
 			}
-			elemType := dst.Type().Elem()
-			var elem reflect.Value
-			if elemType.Kind() == reflect.Pointer {
-				elem = reflect.New(elemType.Elem())
-				assignSegmentStruct(elem.Elem(), v)
+		}
+	case reflect.Array:
+		for i := range v.Len() {
+			resetValue(v.Index(i))
+		}
+	case reflect.Slice:
+		for i := range v.Len() {
+			if elem := v.Index(i); elem.Kind() == reflect.Pointer && !elem.IsNil() {
+				resetValue(elem.Elem())
 			} else {
-				elem = reflect.New(elemType).Elem()
-				assignSegmentStruct(elem, v)
+				resetValue(elem)
 			}
-			dst.Set(reflect.Append(dst, elem))
 		}
-	case []map[int]any:
-		if dst.Kind() != reflect.Slice {
-			return
+		v.SetLen(0)
+	default:
+		v.SetZero()
+	}
+}
+
+// path locates the value being assigned: the index of its segment in
+// d.segments and the 1-based field, repetition, component and subcomponent
+// indexes below it.
+type path struct {
+	seg, field, rep, comp, sub int
+}
+
+// at returns the path of the n-th member of the composite at p.
+func (p path) at(n int) path {
+	switch {
+	case p.field == 0:
+		p.field = n
+	case p.comp == 0:
+		p.comp = n
+	case p.sub == 0:
+		p.sub = n
+	}
+
+	return p
+}
+
+// typeSegments replaces the maps of fields in m with values of the types
+// registered for their segments.
+func (d *decodeState) typeSegments(m map[string]any) {
+	typed := make(map[string]reflect.Value)
+	for pos, seg := range d.segments {
+		t, ok := d.segmentType(seg.name)
+		if !ok {
+			continue
 		}
 
-		n := len(v)
-		slice := reflect.MakeSlice(dst.Type(), n, n)
-		elemType := dst.Type().Elem()
+		elem := reflect.New(t).Elem()
+		d.assignSegment(elem, pos)
+		if vals, ok := typed[seg.name]; ok {
+			typed[seg.name] = reflect.Append(vals, elem)
+		} else {
+			typed[seg.name] = reflect.Append(reflect.MakeSlice(reflect.SliceOf(t), 0, 1), elem)
+		}
+	}
 
-		for i, m := range v {
-			var elem reflect.Value
-			if elemType.Kind() == reflect.Pointer {
-				elem = reflect.New(elemType.Elem())
-				assignSegmentStruct(elem.Elem(), m)
-			} else {
-				elem = reflect.New(elemType).Elem()
-				assignSegmentStruct(elem, m)
+	for name, vals := range typed {
+		if vals.Len() == 1 {
+			m[name] = vals.Index(0).Interface()
+		} else {
+			m[name] = vals.Interface()
+		}
+	}
+}
+
+func (d *decodeState) assignSegment(dst reflect.Value, pos int) {
+	p := path{seg: pos}
+
+	if d.unmarshaler(dst, p) {
+		return
+	}
+
+	switch dst.Kind() {
+	default:
+		d.saveError(&UnmarshalTypeError{Value: "segment", Type: dst.Type(), Location: d.location(p)})
+	case reflect.Interface:
+		if t, ok := d.segmentType(d.segments[pos].name); ok {
+			elem := reflect.New(t).Elem()
+			d.assignSegment(elem, pos)
+			dst.Set(elem)
+		} else if dst.NumMethod() == 0 {
+			dst.Set(reflect.ValueOf(d.fieldMap(pos)))
+		} else {
+			d.saveError(&UnmarshalTypeError{Value: "segment", Type: dst.Type(), Location: d.location(p)})
+		}
+	case reflect.Struct:
+		for _, f := range cachedCompositeFields(dst.Type()) {
+			if field, ok := d.field(pos, f.hl7Idx); ok {
+				d.assignValue(dst.Field(f.index), field, p.at(f.hl7Idx))
 			}
-			slice.Index(i).Set(elem)
 		}
+	case reflect.Pointer:
 		if dst.IsNil() {
-			dst.Set(slice)
-		} else {
-			dst.Set(reflect.AppendSlice(dst, slice))
+			dst.Set(reflect.New(dst.Type().Elem()))
 		}
+		d.assignSegment(dst.Elem(), pos)
+	case reflect.Slice:
+		d.assignSegment(d.appendElem(dst), pos)
 	}
 }
 
-func assignSegmentStruct(dst reflect.Value, fields map[int]any) {
-	t := dst.Type()
+// assignStruct stores the parts of a field or component in the fields of
+// dst, matching HL7 indexes to struct fields by position or by a numeric
+// `hl7` tag.
+func (d *decodeState) assignStruct(dst reflect.Value, src node, p path) {
+	for _, f := range cachedCompositeFields(dst.Type()) {
+		part, ok := src.part(f.hl7Idx)
+		if !ok {
+			continue
+		}
 
-	idx := 1
-	for i := range dst.NumField() {
-		sf := t.Field(i)
-		fv := dst.Field(i)
+		d.assignValue(dst.Field(f.index), part, p.at(f.hl7Idx))
+	}
+}
 
-		if !fv.CanSet() {
-			continue
+// assignValue stores src, a plain value, a composite or a list of
+// repetitions, in dst. A composite value stored in a scalar keeps only its
+// first component, as HL7 prescribes for receivers that expect a
+// primitive; a plain value stored in a struct fills its first field;
+// repetitions stored in anything but a slice keep only the first. Empty
+// values are absent ones and leave dst untouched, apart from clearing a
+// string. The explicit null, "", is kept as such in a string, clears other
+// scalars and is Null in an interface.
+func (d *decodeState) assignValue(dst reflect.Value, src node, p path) {
+	if src.start == src.end {
+		if dst.Kind() == reflect.String {
+			dst.SetString("")
 		}
+		return
+	}
 
-		var hl7Idx int
-		if tag := sf.Tag.Get("hl7"); tag != "" {
-			n, err := strconv.Atoi(tag)
-			if err != nil {
-				continue
-			}
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		d.assignValue(dst.Elem(), src, p)
+		return
+	case reflect.Interface:
+		if dst.NumMethod() == 0 {
+			dst.Set(reflect.ValueOf(d.toAny(src)))
+			return
+		}
+	}
 
-			hl7Idx = n
+	if val, _, ok := asNullable(dst); ok {
+		if d.isNull(src) {
+			val.SetZero()
+			dst.Field(1).SetUint(uint64(PresentNull))
 		} else {
-			hl7Idx = idx
-			idx++
+			d.assignValue(val, src, p)
+			dst.Field(1).SetUint(uint64(Present))
 		}
+		return
+	}
 
-		val, ok := fields[hl7Idx]
-		if !ok {
-			continue
-		}
+	if d.unmarshaler(dst, p) {
+		return
+	}
 
-		if fv.Kind() == reflect.Pointer {
-			if fv.IsNil() {
-				fv.Set(reflect.New(fv.Type().Elem()))
+	src = src.level()
+	switch {
+	case src.state > stateSubComponent:
+		switch {
+		case d.isNull(src) && dst.Kind() != reflect.String && isScalar(dst.Type()):
+			dst.SetZero()
+		case isScalar(dst.Type()):
+			if err := setScalar(dst, d.nodeText(src), d.opts.timeZone()); err != nil {
+				d.saveError(&UnmarshalTypeError{Value: "string", Type: dst.Type(), Location: d.location(p), Err: err})
 			}
-			assignValue(fv.Elem(), val)
-		} else {
-			assignValue(fv, val)
+		case dst.Kind() == reflect.Struct:
+			d.assignStruct(dst, src, p)
+		case dst.Kind() == reflect.Slice:
+			d.assignRepetitions(dst, src, p)
+		default:
+			d.saveError(&UnmarshalTypeError{Value: "string", Type: dst.Type(), Location: d.location(p)})
+		}
+	case src.state == stateRepetition:
+		if dst.Kind() != reflect.Slice {
+			p.rep = 1
+			first, _ := src.part(1)
+			d.assignValue(dst, first, p)
+			return
+		}
+		d.assignRepetitions(dst, src, p)
+	default:
+		switch {
+		case isScalar(dst.Type()):
+			first, _ := src.part(1)
+			d.assignValue(dst, first, p.at(1))
+		case dst.Kind() == reflect.Struct:
+			d.assignStruct(dst, src, p)
+		case dst.Kind() == reflect.Slice:
+			d.assignRepetitions(dst, src, p)
+		default:
+			d.saveError(&UnmarshalTypeError{Value: "composite", Type: dst.Type(), Location: d.location(p)})
 		}
 	}
 }
 
-func assignValue(dst reflect.Value, src any) {
-	switch v := src.(type) {
-	case string:
-		switch dst.Kind() {
-		case reflect.String:
-			dst.SetString(v)
-		case reflect.Struct:
-			fields := map[int]any{1: v}
-			assignSegmentStruct(dst, fields)
-		case reflect.Pointer:
-			if dst.IsNil() {
-				dst.Set(reflect.New(dst.Type().Elem()))
-			}
-			fields := map[int]any{1: v}
-			assignSegmentStruct(dst.Elem(), fields)
-		}
-	case map[int]any:
-		switch dst.Kind() {
-		case reflect.Struct:
-			assignSegmentStruct(dst, v)
-		case reflect.Pointer:
-			if dst.IsNil() {
-				dst.Set(reflect.New(dst.Type().Elem()))
-			}
-			assignSegmentStruct(dst.Elem(), v)
-		}
-	case []any:
-		if dst.Kind() == reflect.Slice {
-			n := len(v)
-			slice := reflect.MakeSlice(dst.Type(), n, n)
-			elemType := dst.Type().Elem()
-
-			for i, elem := range v {
-				var e reflect.Value
-				if elemType.Kind() == reflect.Pointer {
-					e = reflect.New(elemType.Elem())
-				} else {
-					e = reflect.New(elemType).Elem()
-				}
-				assignValue(e, elem)
-				slice.Index(i).Set(e)
-			}
-			dst.Set(slice)
+// assignRepetitions stores the repetitions of src, or src itself if it has
+// none, in the slice dst, replacing its elements.
+func (d *decodeState) assignRepetitions(dst reflect.Value, src node, p path) {
+	n := 1
+	if src.state == stateRepetition {
+		n = src.count()
+	}
+
+	slice := dst
+	if d.reuse && n <= dst.Cap() {
+		dst.SetLen(n)
+	} else {
+		slice = reflect.MakeSlice(dst.Type(), n, n)
+	}
+
+	ps := src.parts()
+	for i := range n {
+		rep := src
+		if src.state == stateRepetition {
+			rep, _ = ps.next()
 		}
+		p.rep = i + 1
+		d.assignValue(slice.Index(i), rep, p)
 	}
+
+	dst.Set(slice)
+}
+
+// unmarshaler hands the raw text at p to dst if dst implements
+// Unmarshaler, reporting whether it did.
+func (d *decodeState) unmarshaler(dst reflect.Value, p path) bool {
+	if !dst.CanAddr() || !cachedImplements(reflect.PointerTo(dst.Type()), unmarshalerType) {
+		return false
+	}
+
+	start, end := d.bounds(p)
+	u := dst.Addr().Interface().(Unmarshaler)
+	if err := u.UnmarshalHL7(d.data[start:end], d.scan.delimiters()); err != nil {
+		d.saveError(&UnmarshalerError{Type: dst.Type(), Location: d.location(p), Err: err})
+	}
+
+	return true
+}
+
+// saveError records an error found while decoding and carries on, so that
+// one bad value does not stop the rest of the message from being decoded.
+func (d *decodeState) saveError(err error) {
+	if d.savedError == nil {
+		d.savedError = err
+	}
+	if d.opts.AllErrors {
+		d.errs = append(d.errs, err)
+	}
+}
+
+// location resolves p to a Location, including the byte offset of the
+// value it refers to.
+func (d *decodeState) location(p path) Location {
+	seg := d.segments[p.seg]
+	loc := Location{
+		Segment:      seg.name,
+		Field:        p.field,
+		Repetition:   p.rep,
+		Component:    p.comp,
+		SubComponent: p.sub,
+	}
+	for _, s := range d.segments[:p.seg+1] {
+		if s.name == seg.name {
+			loc.Ordinal++
+		}
+	}
+	loc.Offset, _ = d.bounds(p)
+
+	return loc
+}
+
+// bounds returns the start and end offsets in d.data of the value at p.
+func (d *decodeState) bounds(p path) (int, int) {
+	seg := d.segments[p.seg]
+	start := seg.offset + 3
+	end := d.endOfSegment(start)
+	if p.field == 0 {
+		return seg.offset, end
+	}
+
+	// Splitting what follows the segment name on the field separator
+	// gives an empty member first, so field n is member n+1. In MSH the
+	// first separator is MSH-1 itself and MSH-2 is the second member.
+	n := p.field + 1
+	if isHeaderSegment(seg.name) {
+		if p.field == 1 {
+			return start, start + 1
+		}
+		n = p.field
+	}
+
+	start, end = d.member(start, end, d.scan.fldDelim, n)
+	if p.rep > 0 {
+		start, end = d.member(start, end, d.scan.repDelim, p.rep)
+	}
+	if p.comp > 0 {
+		start, end = d.member(start, end, d.scan.comDelim, p.comp)
+	}
+	if p.sub > 0 {
+		start, end = d.member(start, end, d.scan.subDelim, p.sub)
+	}
+
+	return start, end
+}
+
+// member returns the bounds of the n-th delim-separated member of
+// d.data[start:end].
+func (d *decodeState) member(start, end int, delim byte, n int) (int, int) {
+	i := start
+	for k := 1; k < n; k++ {
+		for i < end && d.data[i] != delim {
+			i++
+		}
+		if i >= end {
+			return end, end
+		}
+		i++
+	}
+
+	j := i
+	for j < end && d.data[j] != delim {
+		j++
+	}
+
+	return i, j
+}
+
+func (d *decodeState) endOfSegment(start int) int {
+	for i := start; i < len(d.data); i++ {
+		if d.data[i] == '\r' {
+			return i
+		}
+	}
+
+	return len(d.data)
 }
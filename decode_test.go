@@ -1,9 +1,16 @@
 package hl7
 
 import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	v23 "github.com/s-hammon/hl7/proto/standards/v23"
+	v23s "github.com/s-hammon/hl7/standards/v23"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,6 +98,43 @@ func TestDecodeState_UnmarshalMap(t *testing.T) {
 	require.Equal(t, want, m)
 }
 
+func TestDecodeState_EmptySegments(t *testing.T) {
+	// a segment with only its name, or a header with only its delimiters,
+	// ends at its terminator
+	for _, msg := range []string{
+		"MSH|^~\\&\rNTE\rPID|1||V1\r",
+		"MSH|^~\\&\rNTE\rNTE\rPID|1||V1",
+	} {
+		var m map[string]any
+		require.NoError(t, newState([]byte(msg)).unmarshal(&m), msg)
+		require.Equal(t, map[int]any{1: "1", 2: "", 3: "V1"}, m["PID"], msg)
+		require.Contains(t, m, "NTE", msg)
+	}
+}
+
+func TestDecodeState_FieldValues(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want any
+	}{
+		{"", ""},
+		{"plain", "plain"},
+		{"a^b", map[int]any{1: "a", 2: "b"}},
+		{"a&b", map[int]any{1: "a", 2: "b"}},
+		{"^b&c^", map[int]any{1: "", 2: map[int]any{1: "b", 2: "c"}, 3: ""}},
+		{"a~b^c~", []any{"a", map[int]any{1: "b", 2: "c"}, ""}},
+		{"a&b~c", []any{map[int]any{1: "a", 2: "b"}, "c"}},
+		{"x\\S\\y^\\T\\", map[int]any{1: "x^y", 2: "&"}},
+		{"\\R\\~\\E\\&z", []any{"~", map[int]any{1: "\\", 2: "z"}}},
+	}
+
+	for _, tt := range tests {
+		var m map[string]any
+		require.NoError(t, Unmarshal([]byte("MSH|^~\\&|\rZZZ|"+tt.raw+"\r"), &m), tt.raw)
+		require.Equal(t, tt.want, m["ZZZ"].(map[int]any)[1], tt.raw)
+	}
+}
+
 func TestDecodeState_UnmarshalORM(t *testing.T) {
 	msg := []byte("MSH|^~\\&|SendingApp|SendingFac|ReceivingApp|ReceivingFac|20250101000000||ORM^O01|123456|P|2.3|4232072\rPID|1||V12345||DOE^JANE^A||19700101|F|||123 MAIN ST^ANYWHERE^TX^76543^USA||(123)456-7890\rPV1||E|Acme ER^AER^^AR||||123456^Smith^John^J^^^M.D.\rORC|XO|00112233|30504059||CM||^^^20250101080000||20250101100000|^Decrad^Support^^^^System.||123456^Smith^John^J^^^M.D.|LTERRAD1^LT ER RAD1\rOBR|1|00112233|30504059|CXR^Chest 1 View|Y^N||20250101000000")
 
@@ -103,21 +147,21 @@ func TestDecodeState_UnmarshalORM(t *testing.T) {
 	require.Equal(t, "SendingFac", m.MSH.SendingFacility)
 	require.Equal(t, "ORM", m.MSH.MessageType.Type)
 	require.Equal(t, "O01", m.MSH.MessageType.TriggerEvent)
-	require.Equal(t, "V12345", m.PatientGroup.PID.InternalPatientId.Id)
+	require.Equal(t, "V12345", m.PatientGroup.PID.InternalPatientId[0].Id)
 	require.Equal(t, "XO", m.OrderGroups[0].ORC.OrderControl)
 	require.Equal(t, "30504059", m.OrderGroups[0].ORC.FillerOrderNumber)
 	require.Equal(t, "20250101080000", m.OrderGroups[0].ORC.QuantityTiming.StartDateTime)
 }
 
 func TestUnmarshal_ORM(t *testing.T) {
-	msg := []byte("MSH|^~\\&|SendingApp|SendingFac|ReceivingApp|ReceivingFac|20250101000000||ORM^O01|123456|P|2.3|4232072\rPID|1||V12345||DOE^JANE^A||19700101|F|||123 MAIN ST^ANYWHERE^TX^76543^USA||(123)456-7890\rPV1||E|Acme ER^AER^^AR||||123456^Smith^John^J^^^M.D.\rORC|XO|00112233|30504059||CM||^^^20250101080000||20250101100000|^Decrad^Support^^^^System.||123456^Smith^John^J^^^M.D.|LTERRAD1^LT ER RAD1\rOBR|1|00112233|30504059|CXR^Chest 1 View|Y^N||20250101000000\r")
+	msg := ormMsg
 
 	var (
 		m   v23.ORM_O01
 		err error
 	)
 
-	err = Unmarshal(msg, m)
+	err = Unmarshal(msg, v23.ORM_O01{})
 	require.Error(t, err)
 
 	err = Unmarshal(msg, &m)
@@ -125,7 +169,7 @@ func TestUnmarshal_ORM(t *testing.T) {
 }
 
 func TestUnmarshal_ORU(t *testing.T) {
-	msg := []byte("MSH|^~\\&|PSOne|BMCNE|STRIC|STRIC|20250404152739||ORU^R01|6767683|P|2.3|29069747\rPID|||002207830||SMITH^JINKLEHEIMER^JOHN JACOB||19840526|M|||123 MAIN STR^^ANYWHERE^TX^12345^USA||(999)999-9999|(999)999-9999\rPV1||O|Boutique Mammography Center at^BMCNE^^BMCNE^^^^^ACME MAMMOGRAPHY CENTER||||440854^DOE^JANE^^^^M.D.^^NPI&1234567890|||||||||||O||||||||||||||||||||||||||20250404000100\rORC|RE||29737914||||20250404152445^20250404152445^20250404152535\rOBR|1|12|29737914|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD |||20250404152445||||||No Known Allergies|||440854^DOE^JANE^^^^M.D.^^NPI&1234567890||V00384534|D01620528|  -  ,   -  ,   -  |STRICAH051|20250404152535|STRICAH051|MG|F||^^^20250404115000^20250404115900||||SCR|620863&Farkas&Julie&M&&&M.D.^^20250404152535\rOBX|1|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||MAMMOGRAM DIGITAL SCREENING BILATERAL W/CAD AND DBT||||||F|||20250404152535\rOBX|2|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|3|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||DATE:  4/4/2025||||||F|||20250404152535\rOBX|4|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|5|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||HISTORY:  Screening||||||F|||20250404152535\rOBX|6|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||  ||||||F|||20250404152535\rOBX|7|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||TECHNIQUE:  Bilateral full field digital screening mammography and bilateral||||||F|||20250404152535\rOBX|8|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||digital breast tomosynthesis were performed and interpreted in conjunction with||||||F|||20250404152535\rOBX|9|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||computer-aided detection. CC and MLO views were obtained of the breast(s) with||||||F|||20250404152535\rOBX|10|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||additional views as required. ||||||F|||20250404152535\rOBX|11|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|12|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||COMPARISON: Prior mammograms dating back to 11/16/2019 ||||||F|||20250404152535\rOBX|13|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|14|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||FINDINGS:||||||F|||20250404152535\rOBX|15|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|16|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||RIGHT: No suspicious mass, suspicious architectural distortion, or suspicious||||||F|||20250404152535\rOBX|17|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||microcalcifications. ||||||F|||20250404152535\rOBX|18|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|19|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||LEFT: No suspicious mass, suspicious architectural distortion, or suspicious||||||F|||20250404152535\rOBX|20|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||microcalcifications. ||||||F|||20250404152535\rOBX|21|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|22|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||OTHER: None.||||||F|||20250404152535\rOBX|23|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|24|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||IMPRESSION:||||||F|||20250404152535\rOBX|25|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|26|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||No suspicious findings. Unless otherwise indicated, continue annual screening||||||F|||20250404152535\rOBX|27|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||mammogram.||||||F|||20250404152535\rOBX|28|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|29|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||BIRADS Category 1 - Negative ||||||F|||20250404152535\rOBX|30|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|31|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||Your patient is being notified by mail of the results.||||||F|||20250404152535\rOBX|32|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|33|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||Breast Density:  The breasts are heterogeneously dense, which may obscure small||||||F|||20250404152535\rOBX|34|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||masses (Type C)||||||F|||20250404152535\rOBX|35|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|36|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||MFC:  1NC||||||F|||20250404152535\rOBX|37|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|38|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||Signed on 4/4/2025 3:25 PM by Julie M Farkas, M.D.||||||F|||20250404152535\rOBX|39|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535")
+	msg := oruMsg
 
 	var (
 		m   v23.ORU_R01
@@ -134,19 +178,59 @@ func TestUnmarshal_ORU(t *testing.T) {
 
 	err = Unmarshal(msg, &m)
 	require.NoError(t, err)
-	t.Log(m)
+	t.Log(&m)
 	require.Len(t, m.Results, 1)
 	require.Len(t, m.Results[0].Order[0].Observation, 39)
-	require.Equal(t, "MAMMOGRAM DIGITAL SCREENING BILATERAL W/CAD AND DBT", m.Results[0].Order[0].Observation[0].OBX.ObservationValue)
-	require.Equal(t, "Signed on 4/4/2025 3:25 PM by Julie M Farkas, M.D.", m.Results[0].Order[0].Observation[37].OBX.ObservationValue)
-	require.Equal(t, "", m.Results[0].Order[0].Observation[38].OBX.ObservationValue)
+	require.Equal(t, []string{"MAMMOGRAM DIGITAL SCREENING BILATERAL W/CAD AND DBT"}, m.Results[0].Order[0].Observation[0].OBX.ObservationValue)
+	require.Equal(t, []string{"Signed on 4/4/2025 3:25 PM by Julie M Farkas, M.D."}, m.Results[0].Order[0].Observation[37].OBX.ObservationValue)
+	require.Empty(t, m.Results[0].Order[0].Observation[38].OBX.ObservationValue)
 	require.Equal(t, "O", m.Results[0].Visit.PV1.PatientClass)
-	require.Equal(t, "JINKLEHEIMER", m.Results[0].PID.PatientName.GivenName)
+	require.Equal(t, "JINKLEHEIMER", m.Results[0].PID.PatientName[0].GivenName)
 	require.Equal(t, "19840526", m.Results[0].PID.Dob)
 }
 
+func TestUnmarshal_ORU_DSC(t *testing.T) {
+	msg := "MSH|^~\\&|Lab|LabFac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1\r" +
+		"ORC|NW\r" +
+		"OBR|1\r" +
+		"DSC|42\r"
+
+	var m v23s.ORU_R01
+	require.NoError(t, UnmarshalOptions{Strict: true}.Unmarshal([]byte(msg), &m))
+	require.Equal(t, "42", m.DCS.ContinuationPointer)
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, msg, string(b))
+}
+
+func TestUnmarshal_ORU_NoORC(t *testing.T) {
+	// ORC is optional in an ORU^R01 order; OBR begins the group without it
+	msg := "MSH|^~\\&|Lab|LabFac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1\r" +
+		"OBR|1||1001|CXR^Chest 1 View\r" +
+		"OBX|1|FT|CXR||first result\r" +
+		"OBR|2||1002|CT^CT Head\r" +
+		"OBX|1|FT|CT||second result\r"
+
+	for _, m := range []any{&v23s.ORU_R01{}, &v23.ORU_R01{}} {
+		require.NoError(t, UnmarshalOptions{Strict: true}.Unmarshal([]byte(msg), m))
+
+		b, err := Marshal(m)
+		require.NoError(t, err)
+		require.Equal(t, msg, string(b))
+	}
+
+	var m v23s.ORU_R01
+	require.NoError(t, Unmarshal([]byte(msg), &m))
+	require.Len(t, m.Results[0].Order, 2)
+	require.Equal(t, "1002", m.Results[0].Order[1].OBR.FillerOrderNumber)
+	require.Equal(t, []string{"second result"}, m.Results[0].Order[1].Observation[0].OBX.ObservationValue)
+}
+
 func TestUnmarshal_ORU_MultipleOrders(t *testing.T) {
-	msg := []byte("MSH|^~\\&|PSOne|METHNE||MHS|20251216002851||ORU^R01|7853152|P|2.3|4232074\rPID|||V00272475||BANANA^ANNA^BANNA||19801006|F|||123 MAIN ST^^ANYWHERE^TX^76543^USA||(123)456-7890|(098)765-4321||||V468357251\rPV1||E|NEMH ER FT 757 5009^VFT^^METHNE^^^^^NEMH ER FT 757 5009 VFT|||||||||||||||E|V468357251\rORC|RE||30507023||||20251216002425^20251216002425^20251216002644\rOBR|1|002353470|30507023|UPELNOB^US Pelvis Non-OB|||20251216002425||||||Lower abd pain, r ovarian cyst    DX:  ABD PAIN    Comments:  #V468357251|||123456^Smigh^John^A^^^P.A.||N00069961||, , |RAD-DOCTOR|20251216002644|RAD-DOCTOR|US|F||^^^20251215234700^20251215234700||||Lower abd pain, r ovarian cyst|999696&Graham&Joshua&J&&&M.D.^^20251216002644\rORC|CN||30507022||||20251216002425^20251216002425^20251216002644\rOBR|2|002353469|30507022|UPELDOP^US Doppler Pelvis|||20251216002425||||||Lower abd pain, r ovarian cyst    DX:  ABD PAIN    Comments:  #V468357251|||123456^Smigh^John^A^^^P.A.||N00069961||, , |Methodist Hospital Northeast|20251216002644|RAD-DOCTOR|US|F||^^^20251215234700^20251215234700||||Lower abd pain, r ovarian cyst|999696&Graham&Joshua&J&&&M.D.^^20251216002644\rOBX|1|FT|UPELNOB^US Pelvis Non-OB||ULTRASOUND PELVIS ||||||F|||20251216002644\rOBX|2|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|3|FT|UPELNOB^US Pelvis Non-OB||DATE:  12/15/2025||||||F|||20251216002644\rOBX|4|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|5|FT|UPELNOB^US Pelvis Non-OB||HISTORY:   Lower abd pain, r ovarian cyst    ||||||F|||20251216002644\rOBX|6|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|7|FT|UPELNOB^US Pelvis Non-OB||TECHNIQUE: Ultrasound of the pelvis performed per the routine protocol using a||||||F|||20251216002644\rOBX|8|FT|UPELNOB^US Pelvis Non-OB||transabdominal and transvaginal probe.||||||F|||20251216002644\rOBX|9|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|10|FT|UPELNOB^US Pelvis Non-OB||COMPARISON: CT dated 12/15/2025||||||F|||20251216002644\rOBX|11|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|12|FT|UPELNOB^US Pelvis Non-OB||FINDINGS:||||||F|||20251216002644\rOBX|13|FT|UPELNOB^US Pelvis Non-OB|| ||||||F|||20251216002644\rOBX|14|FT|UPELNOB^US Pelvis Non-OB||Uterus: Not seen ||||||F|||20251216002644\rOBX|15|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|16|FT|UPELNOB^US Pelvis Non-OB||Right Ovary: 4.4 x 4.6 x 3.4 cm||||||F|||20251216002644\rOBX|17|FT|UPELNOB^US Pelvis Non-OB||Cysts: 2.8 x 3.1 x 2.7 cm||||||F|||20251216002644\rOBX|18|FT|UPELNOB^US Pelvis Non-OB||Mass: None||||||F|||20251216002644\rOBX|19|FT|UPELNOB^US Pelvis Non-OB||Doppler examination: No evidence for ovarian torsion. Normal spectral Doppler||||||F|||20251216002644\rOBX|20|FT|UPELNOB^US Pelvis Non-OB||waveforms with pulsatile arterial inflow and aphasic venous outflow.||||||F|||20251216002644\rOBX|21|FT|UPELNOB^US Pelvis Non-OB|| ||||||F|||20251216002644\rOBX|22|FT|UPELNOB^US Pelvis Non-OB||Left Ovary: Not seen||||||F|||20251216002644\rOBX|23|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|24|FT|UPELNOB^US Pelvis Non-OB||Free fluid: None||||||F|||20251216002644\rOBX|25|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|26|FT|UPELNOB^US Pelvis Non-OB||IMPRESSION: ||||||F|||20251216002644\rOBX|27|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|28|FT|UPELNOB^US Pelvis Non-OB||Right ovarian cyst, corresponds to CT finding.||||||F|||20251216002644\rOBX|29|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|30|FT|UPELNOB^US Pelvis Non-OB||Signed on 12/16/2025 12:26 AM by Joshua J Graham, M.D.||||||F|||20251216002644\rOBX|31|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\r")
+	msg := oruMultipleOrdersMsg
 
 	var (
 		m   v23.ORU_R01
@@ -166,35 +250,395 @@ func TestUnmarshal_ORU_MultipleOrders(t *testing.T) {
 	require.Equal(t, "30507022", m.Results[0].Order[1].ORC.FillerOrderNumber)
 	require.Equal(t, "30507022", m.Results[0].Order[1].OBR.FillerOrderNumber)
 	require.Equal(t, "", m.Results[0].Order[1].OBR.Priority)
-	require.Len(t, m.Results[0].Order[0].Observation, 31)
-	// TODO: fix this so that we only assign the OBX segments once
+	require.Empty(t, m.Results[0].Order[0].Observation)
 	require.Len(t, m.Results[0].Order[1].Observation, 31)
+	require.Equal(t, []string{"ULTRASOUND PELVIS "}, m.Results[0].Order[1].Observation[0].OBX.ObservationValue)
+	require.Equal(t, "31", m.Results[0].Order[1].Observation[30].OBX.SetId)
 }
 
-func BenchmarkUnmarshal_ORU(b *testing.B) {
-	msg := []byte("MSH|^~\\&|PSOne|METHNE||MHS|20251216002851||ORU^R01|7853152|P|2.3|4232074\rPID|||V00272475||BANANA^ANNA^BANNA||19801006|F|||123 MAIN ST^^ANYWHERE^TX^76543^USA||(123)456-7890|(098)765-4321||||V468357251\rPV1||E|NEMH ER FT 757 5009^VFT^^METHNE^^^^^NEMH ER FT 757 5009 VFT|||||||||||||||E|V468357251\rORC|RE||30507023||||20251216002425^20251216002425^20251216002644\rOBR|1|002353470|30507023|UPELNOB^US Pelvis Non-OB|||20251216002425||||||Lower abd pain, r ovarian cyst    DX:  ABD PAIN    Comments:  #V468357251|||123456^Smigh^John^A^^^P.A.||N00069961||, , |RAD-DOCTOR|20251216002644|RAD-DOCTOR|US|F||^^^20251215234700^20251215234700||||Lower abd pain, r ovarian cyst|999696&Graham&Joshua&J&&&M.D.^^20251216002644\rORC|CN||30507022||||20251216002425^20251216002425^20251216002644\rOBR|2|002353469|30507022|UPELDOP^US Doppler Pelvis|||20251216002425||||||Lower abd pain, r ovarian cyst    DX:  ABD PAIN    Comments:  #V468357251|||123456^Smigh^John^A^^^P.A.||N00069961||, , |Methodist Hospital Northeast|20251216002644|RAD-DOCTOR|US|F||^^^20251215234700^20251215234700||||Lower abd pain, r ovarian cyst|999696&Graham&Joshua&J&&&M.D.^^20251216002644\rOBX|1|FT|UPELNOB^US Pelvis Non-OB||ULTRASOUND PELVIS ||||||F|||20251216002644\rOBX|2|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|3|FT|UPELNOB^US Pelvis Non-OB||DATE:  12/15/2025||||||F|||20251216002644\rOBX|4|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|5|FT|UPELNOB^US Pelvis Non-OB||HISTORY:   Lower abd pain, r ovarian cyst    ||||||F|||20251216002644\rOBX|6|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|7|FT|UPELNOB^US Pelvis Non-OB||TECHNIQUE: Ultrasound of the pelvis performed per the routine protocol using a||||||F|||20251216002644\rOBX|8|FT|UPELNOB^US Pelvis Non-OB||transabdominal and transvaginal probe.||||||F|||20251216002644\rOBX|9|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|10|FT|UPELNOB^US Pelvis Non-OB||COMPARISON: CT dated 12/15/2025||||||F|||20251216002644\rOBX|11|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|12|FT|UPELNOB^US Pelvis Non-OB||FINDINGS:||||||F|||20251216002644\rOBX|13|FT|UPELNOB^US Pelvis Non-OB|| ||||||F|||20251216002644\rOBX|14|FT|UPELNOB^US Pelvis Non-OB||Uterus: Not seen ||||||F|||20251216002644\rOBX|15|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|16|FT|UPELNOB^US Pelvis Non-OB||Right Ovary: 4.4 x 4.6 x 3.4 cm||||||F|||20251216002644\rOBX|17|FT|UPELNOB^US Pelvis Non-OB||Cysts: 2.8 x 3.1 x 2.7 cm||||||F|||20251216002644\rOBX|18|FT|UPELNOB^US Pelvis Non-OB||Mass: None||||||F|||20251216002644\rOBX|19|FT|UPELNOB^US Pelvis Non-OB||Doppler examination: No evidence for ovarian torsion. Normal spectral Doppler||||||F|||20251216002644\rOBX|20|FT|UPELNOB^US Pelvis Non-OB||waveforms with pulsatile arterial inflow and aphasic venous outflow.||||||F|||20251216002644\rOBX|21|FT|UPELNOB^US Pelvis Non-OB|| ||||||F|||20251216002644\rOBX|22|FT|UPELNOB^US Pelvis Non-OB||Left Ovary: Not seen||||||F|||20251216002644\rOBX|23|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|24|FT|UPELNOB^US Pelvis Non-OB||Free fluid: None||||||F|||20251216002644\rOBX|25|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|26|FT|UPELNOB^US Pelvis Non-OB||IMPRESSION: ||||||F|||20251216002644\rOBX|27|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|28|FT|UPELNOB^US Pelvis Non-OB||Right ovarian cyst, corresponds to CT finding.||||||F|||20251216002644\rOBX|29|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|30|FT|UPELNOB^US Pelvis Non-OB||Signed on 12/16/2025 12:26 AM by Joshua J Graham, M.D.||||||F|||20251216002644\rOBX|31|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\r")
+func TestUnmarshal_ORU_GroupOrder(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1||DOE^JANE\r" +
+		"NTE|1||patient note\r" +
+		"PV1||O\r" +
+		"ORC|RE||1001\r" +
+		"OBR|1||1001|CXR^Chest 1 View\r" +
+		"NTE|1||order note 1\r" +
+		"OBX|1|FT|CXR||first result\r" +
+		"NTE|1||obx note 1\r" +
+		"NTE|2||obx note 2\r" +
+		"OBX|2|FT|CXR||second result\r" +
+		"ORC|RE||1002\r" +
+		"OBR|2||1002|CT^CT Head\r" +
+		"OBX|1|FT|CT||third result\r" +
+		"PID|2||V2||DOE^JOHN\r" +
+		"ORC|RE||2001\r" +
+		"OBR|1||2001|US^Ultrasound\r" +
+		"NTE|1||order note 2\r" +
+		"OBX|1|FT|US||fourth result\r")
+
+	var m v23.ORU_R01
+	require.NoError(t, Unmarshal(msg, &m))
+	require.Len(t, m.Results, 2)
+
+	first := m.Results[0]
+	require.Equal(t, "V1", first.PID.InternalPatientId[0].Id)
+	require.Len(t, first.NTE, 1)
+	require.Equal(t, []string{"patient note"}, first.NTE[0].Comment)
+	require.Equal(t, "O", first.Visit.PV1.PatientClass)
+	require.Len(t, first.Order, 2)
+
+	order := first.Order[0]
+	require.Equal(t, "1001", order.ORC.FillerOrderNumber)
+	require.Len(t, order.NTE, 1)
+	require.Equal(t, []string{"order note 1"}, order.NTE[0].Comment)
+	require.Len(t, order.Observation, 2)
+	require.Equal(t, []string{"first result"}, order.Observation[0].OBX.ObservationValue)
+	require.Len(t, order.Observation[0].NTE, 2)
+	require.Equal(t, []string{"obx note 2"}, order.Observation[0].NTE[1].Comment)
+	require.Equal(t, []string{"second result"}, order.Observation[1].OBX.ObservationValue)
+	require.Empty(t, order.Observation[1].NTE)
+
+	order = first.Order[1]
+	require.Equal(t, "1002", order.OBR.FillerOrderNumber)
+	require.Empty(t, order.NTE)
+	require.Len(t, order.Observation, 1)
+	require.Equal(t, []string{"third result"}, order.Observation[0].OBX.ObservationValue)
+
+	second := m.Results[1]
+	require.Equal(t, "V2", second.PID.InternalPatientId[0].Id)
+	require.Empty(t, second.NTE)
+	require.Nil(t, second.Visit)
+	require.Len(t, second.Order, 1)
+	require.Equal(t, []string{"order note 2"}, second.Order[0].NTE[0].Comment)
+	require.Len(t, second.Order[0].Observation, 1)
+	require.Equal(t, []string{"fourth result"}, second.Order[0].Observation[0].OBX.ObservationValue)
+
+	out, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, string(msg), string(out))
+}
+
+func TestUnmarshal_UnknownSegmentsSkipped(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1\r" +
+		"ORC|RE||1001\r" +
+		"OBR|1||1001\r" +
+		"OBX|1|FT|CXR||first\r" +
+		"ZDS|1.2.3\r" +
+		"OBX|2|FT|CXR||second\r")
 
 	var m v23.ORU_R01
+	require.NoError(t, Unmarshal(msg, &m))
+	require.Len(t, m.Results[0].Order[0].Observation, 2)
+	require.Equal(t, []string{"second"}, m.Results[0].Order[0].Observation[1].OBX.ObservationValue)
+}
+
+func BenchmarkUnmarshal_ORU(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		msg  []byte
+	}{
+		{"Single", oruMsg},
+		{"MultipleOrders", oruMultipleOrdersMsg},
+		{"Large", oruLargeMsg()},
+	}
 
-	b.ReportAllocs()
-	b.ResetTimer()
-	for b.Loop() {
-		_ = Unmarshal(msg, &m)
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(bm.msg)))
+			for b.Loop() {
+				var m v23.ORU_R01
+				if err := Unmarshal(bm.msg, &m); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
 	}
 }
 
+// oruLargeMsg is a result with a thousand observations, each with a
+// repeated, composite value.
+func oruLargeMsg() []byte {
+	var b bytes.Buffer
+	b.Write(oruMultipleOrdersMsg)
+	for i := range 1000 {
+		fmt.Fprintf(&b, "OBX|%d|CE|UPELNOB^US Pelvis Non-OB^L||R%d^Result \\T\\ %d^L~N^Normal^L|mm&millimeter|1-10|N~A|||F|||20251216002644\r", 32+i, i, i)
+	}
+
+	return b.Bytes()
+}
+
 func TestUnmarshal_AL1(t *testing.T) {
 	msg := []byte("MSH|^~\\&|ITS|WOH|METHWO|METHWO|202512220000||ORM^O01|19738904|P|2.4\rPID|1|L1-B20250520173705489|Q267415244^^^METHWO^^METHWO||DOE^JOHN||19700601|M|||123 MAIN ST^^ANYWHERE^TX^76543||123-456-7890|||M|BAP|A26740438416\rPV1|1|I|A.ICU^A.IC07A^A^METHWO^^^^^A.ICU A.IC07A|EM|||^House^Gregory^^^^DO|^Referred^Self||ICU||||PR|||DNE7747^House^Gregory^^^^DO|I|A26740438416|08|||||||||||||||||||COCWH||ADM|||202512201650\rAL1|1|DA|F006004444^ranitidine^^From Zantac^^allergy.id|MO|Rash|20251220\rORC|NW|A000000078463A|A000000078463A||SC|N|^^^202512220504^^R||202512220000|||OJL9891^Farkas^Julie^^^^MD|MWORM1|210-690-7400|||A.ICU\rOBR|1|A000000078463A|A000000078463A|MHXRCXR1V^XR chest 1V^MWORM1|R|202512220504|202512220504||||||pneumonia|||005845^Farkas^Julie^^^^MD|210-690-7400^^PH^^^210^690-7400||Q267415244|A26740438416|Methodist Hospital Westover Hills|||XR|||1^^^202512220504^^R|UNKNOWN^MISSING^NUMBER~SELF^Referred^Self|||pneumonia|||^^^^MWORM1|||||Julie  Farkas  MD  -  210-690-7400\rOBX|1|TX|ORDERPTTYPE||I\rOBX|1|CE|MHXRCXR1V^XR chest 1V||H")
 
-	var (
-		m   v23.ORM_O01
-		err error
-	)
-
-	err = Unmarshal(msg, &m)
-	require.NoError(t, err)
-	t.Log(m)
+	var m v23.ORM_O01
+	require.NoError(t, Unmarshal(msg, &m))
 	require.Len(t, m.PatientGroup.AL1, 1)
 	require.Equal(t, "1", m.PatientGroup.AL1[0].SetId)
+	require.Equal(t, []string{"Rash"}, m.PatientGroup.AL1[0].AllergyReaction)
+
+	// OBR-28 repeats
+	copies := m.OrderGroups[0].Details.OBR.ResultCopiesTo
+	require.Len(t, copies, 2)
+	require.Equal(t, "UNKNOWN", copies[0].IdNumber)
+	require.Equal(t, "Referred", copies[1].FamilyName)
 	require.Equal(t, "ranitidine", m.PatientGroup.AL1[0].AllergyCode.Text)
 }
+
+func TestUnmarshal_FirstRepetition(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1^^^HOSP~MRN7^^^LAB||DOE^JANE~ROE^JO\r")
+
+	// fields with room for one value take the first repetition
+	var m struct {
+		MSH v23s.MSH
+		PID struct {
+			SetId              int
+			ExternalPatientId  string
+			InternalPatientId  v23s.CX
+			AlternatePatientId string
+			PatientName        *string
+		}
+	}
+	require.NoError(t, Unmarshal(msg, &m))
+	require.Equal(t, v23s.CX{Id: "V1", AssigningAuthority: "HOSP"}, m.PID.InternalPatientId)
+	require.Equal(t, "DOE", *m.PID.PatientName)
+}
+
+type hl7Time struct {
+	time.Time
+}
+
+func (t *hl7Time) UnmarshalHL7(raw []byte, _ Delimiters) error {
+	v, err := time.Parse("20060102150405", string(raw))
+	if err != nil {
+		return err
+	}
+	t.Time = v
+	return nil
+}
+
+func (t hl7Time) MarshalHL7(Delimiters) ([]byte, error) {
+	return []byte(t.Format("20060102150405")), nil
+}
+
+type displayName string
+
+func (n *displayName) UnmarshalHL7(raw []byte, delims Delimiters) error {
+	parts := bytes.Split(raw, []byte{delims.Component})
+	slices.Reverse(parts)
+	*n = displayName(bytes.TrimSpace(bytes.Join(parts, []byte(" "))))
+	return nil
+}
+
+func (n displayName) MarshalHL7(delims Delimiters) ([]byte, error) {
+	parts := strings.Fields(string(n))
+	slices.Reverse(parts)
+	return []byte(strings.Join(parts, string(delims.Component))), nil
+}
+
+type zpv struct {
+	Raw string
+}
+
+func (z *zpv) UnmarshalHL7(raw []byte, _ Delimiters) error {
+	z.Raw = string(raw)
+	return nil
+}
+
+func (z zpv) MarshalHL7(Delimiters) ([]byte, error) {
+	return []byte(z.Raw), nil
+}
+
+type customPID struct {
+	SetId string
+	Id    string      `hl7:"3"`
+	Name  displayName `hl7:"5"`
+}
+
+type customORC struct {
+	OrderControl   string
+	QuantityTiming struct {
+		Quantity string
+		Interval string
+		Duration string
+		Start    hl7Time
+		End      *hl7Time
+	} `hl7:"7"`
+}
+
+type customMsg struct {
+	MSH *v23.MSH
+	PID customPID
+	ORC customORC
+	ZPV []zpv
+}
+
+func TestUnmarshal_Unmarshaler(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App\r" +
+		"PID|1||V1||DOE^JANE\r" +
+		"ORC|XO||||||^^^20250101080000^20250101093000\r" +
+		"ZPV|1|A^B\\S\\C\r" +
+		"ZPV|2\r")
+
+	var m customMsg
+	require.NoError(t, Unmarshal(msg, &m))
+	require.Equal(t, "V1", m.PID.Id)
+	require.Equal(t, displayName("JANE DOE"), m.PID.Name)
+	require.Equal(t, time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC), m.ORC.QuantityTiming.Start.Time)
+	require.Equal(t, time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC), m.ORC.QuantityTiming.End.Time)
+	require.Equal(t, []zpv{{"ZPV|1|A^B\\S\\C"}, {"ZPV|2"}}, m.ZPV)
+
+	bad := []byte("MSH|^~\\&|App\rORC|XO||||||^^^2025-01-01\r")
+	err := Unmarshal(bad, &customMsg{})
+
+	var unmarshalerErr *UnmarshalerError
+	require.ErrorAs(t, err, &unmarshalerErr)
+	require.Equal(t, "ORC-7-4", unmarshalerErr.Location.String())
+	require.Equal(t, bytes.Index(bad, []byte("2025-01-01")), unmarshalerErr.Offset)
+
+	var parseErr *time.ParseError
+	require.ErrorAs(t, err, &parseErr)
+}
+
+var (
+	ormMsg               = []byte("MSH|^~\\&|SendingApp|SendingFac|ReceivingApp|ReceivingFac|20250101000000||ORM^O01|123456|P|2.3|4232072\rPID|1||V12345||DOE^JANE^A||19700101|F|||123 MAIN ST^ANYWHERE^TX^76543^USA||(123)456-7890\rPV1||E|Acme ER^AER^^AR||||123456^Smith^John^J^^^M.D.\rORC|XO|00112233|30504059||CM||^^^20250101080000||20250101100000|^Decrad^Support^^^^System.||123456^Smith^John^J^^^M.D.|LTERRAD1^LT ER RAD1\rOBR|1|00112233|30504059|CXR^Chest 1 View|Y^N||20250101000000\r")
+	oruMsg               = []byte("MSH|^~\\&|PSOne|BMCNE|STRIC|STRIC|20250404152739||ORU^R01|6767683|P|2.3|29069747\rPID|||002207830||SMITH^JINKLEHEIMER^JOHN JACOB||19840526|M|||123 MAIN STR^^ANYWHERE^TX^12345^USA||(999)999-9999|(999)999-9999\rPV1||O|Boutique Mammography Center at^BMCNE^^BMCNE^^^^^ACME MAMMOGRAPHY CENTER||||440854^DOE^JANE^^^^M.D.^^NPI&1234567890|||||||||||O||||||||||||||||||||||||||20250404000100\rORC|RE||29737914||||20250404152445^20250404152445^20250404152535\rOBR|1|12|29737914|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD |||20250404152445||||||No Known Allergies|||440854^DOE^JANE^^^^M.D.^^NPI&1234567890||V00384534|D01620528|  -  ,   -  ,   -  |STRICAH051|20250404152535|STRICAH051|MG|F||^^^20250404115000^20250404115900||||SCR|620863&Farkas&Julie&M&&&M.D.^^20250404152535\rOBX|1|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||MAMMOGRAM DIGITAL SCREENING BILATERAL W/CAD AND DBT||||||F|||20250404152535\rOBX|2|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|3|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||DATE:  4/4/2025||||||F|||20250404152535\rOBX|4|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|5|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||HISTORY:  Screening||||||F|||20250404152535\rOBX|6|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||  ||||||F|||20250404152535\rOBX|7|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||TECHNIQUE:  Bilateral full field digital screening mammography and bilateral||||||F|||20250404152535\rOBX|8|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||digital breast tomosynthesis were performed and interpreted in conjunction with||||||F|||20250404152535\rOBX|9|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||computer-aided detection. CC and MLO views were obtained of the breast(s) with||||||F|||20250404152535\rOBX|10|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||additional views as required. ||||||F|||20250404152535\rOBX|11|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|12|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||COMPARISON: Prior mammograms dating back to 11/16/2019 ||||||F|||20250404152535\rOBX|13|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|14|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||FINDINGS:||||||F|||20250404152535\rOBX|15|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|16|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||RIGHT: No suspicious mass, suspicious architectural distortion, or suspicious||||||F|||20250404152535\rOBX|17|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||microcalcifications. ||||||F|||20250404152535\rOBX|18|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|19|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||LEFT: No suspicious mass, suspicious architectural distortion, or suspicious||||||F|||20250404152535\rOBX|20|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||microcalcifications. ||||||F|||20250404152535\rOBX|21|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|22|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||OTHER: None.||||||F|||20250404152535\rOBX|23|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|24|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||IMPRESSION:||||||F|||20250404152535\rOBX|25|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|26|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||No suspicious findings. Unless otherwise indicated, continue annual screening||||||F|||20250404152535\rOBX|27|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||mammogram.||||||F|||20250404152535\rOBX|28|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|29|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||BIRADS Category 1 - Negative ||||||F|||20250404152535\rOBX|30|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|31|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||Your patient is being notified by mail of the results.||||||F|||20250404152535\rOBX|32|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|33|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||Breast Density:  The breasts are heterogeneously dense, which may obscure small||||||F|||20250404152535\rOBX|34|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||masses (Type C)||||||F|||20250404152535\rOBX|35|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|36|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||MFC:  1NC||||||F|||20250404152535\rOBX|37|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535\rOBX|38|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||Signed on 4/4/2025 3:25 PM by Julie M Farkas, M.D.||||||F|||20250404152535\rOBX|39|FT|MAMSTOM2^Mammogram Digital Screening Bilateral w/CAD ||||||||F|||20250404152535")
+	oruMultipleOrdersMsg = []byte("MSH|^~\\&|PSOne|METHNE||MHS|20251216002851||ORU^R01|7853152|P|2.3|4232074\rPID|||V00272475||BANANA^ANNA^BANNA||19801006|F|||123 MAIN ST^^ANYWHERE^TX^76543^USA||(123)456-7890|(098)765-4321||||V468357251\rPV1||E|NEMH ER FT 757 5009^VFT^^METHNE^^^^^NEMH ER FT 757 5009 VFT|||||||||||||||E|V468357251\rORC|RE||30507023||||20251216002425^20251216002425^20251216002644\rOBR|1|002353470|30507023|UPELNOB^US Pelvis Non-OB|||20251216002425||||||Lower abd pain, r ovarian cyst    DX:  ABD PAIN    Comments:  #V468357251|||123456^Smigh^John^A^^^P.A.||N00069961||, , |RAD-DOCTOR|20251216002644|RAD-DOCTOR|US|F||^^^20251215234700^20251215234700||||Lower abd pain, r ovarian cyst|999696&Graham&Joshua&J&&&M.D.^^20251216002644\rORC|CN||30507022||||20251216002425^20251216002425^20251216002644\rOBR|2|002353469|30507022|UPELDOP^US Doppler Pelvis|||20251216002425||||||Lower abd pain, r ovarian cyst    DX:  ABD PAIN    Comments:  #V468357251|||123456^Smigh^John^A^^^P.A.||N00069961||, , |Methodist Hospital Northeast|20251216002644|RAD-DOCTOR|US|F||^^^20251215234700^20251215234700||||Lower abd pain, r ovarian cyst|999696&Graham&Joshua&J&&&M.D.^^20251216002644\rOBX|1|FT|UPELNOB^US Pelvis Non-OB||ULTRASOUND PELVIS ||||||F|||20251216002644\rOBX|2|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|3|FT|UPELNOB^US Pelvis Non-OB||DATE:  12/15/2025||||||F|||20251216002644\rOBX|4|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|5|FT|UPELNOB^US Pelvis Non-OB||HISTORY:   Lower abd pain, r ovarian cyst    ||||||F|||20251216002644\rOBX|6|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|7|FT|UPELNOB^US Pelvis Non-OB||TECHNIQUE: Ultrasound of the pelvis performed per the routine protocol using a||||||F|||20251216002644\rOBX|8|FT|UPELNOB^US Pelvis Non-OB||transabdominal and transvaginal probe.||||||F|||20251216002644\rOBX|9|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|10|FT|UPELNOB^US Pelvis Non-OB||COMPARISON: CT dated 12/15/2025||||||F|||20251216002644\rOBX|11|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|12|FT|UPELNOB^US Pelvis Non-OB||FINDINGS:||||||F|||20251216002644\rOBX|13|FT|UPELNOB^US Pelvis Non-OB|| ||||||F|||20251216002644\rOBX|14|FT|UPELNOB^US Pelvis Non-OB||Uterus: Not seen ||||||F|||20251216002644\rOBX|15|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|16|FT|UPELNOB^US Pelvis Non-OB||Right Ovary: 4.4 x 4.6 x 3.4 cm||||||F|||20251216002644\rOBX|17|FT|UPELNOB^US Pelvis Non-OB||Cysts: 2.8 x 3.1 x 2.7 cm||||||F|||20251216002644\rOBX|18|FT|UPELNOB^US Pelvis Non-OB||Mass: None||||||F|||20251216002644\rOBX|19|FT|UPELNOB^US Pelvis Non-OB||Doppler examination: No evidence for ovarian torsion. Normal spectral Doppler||||||F|||20251216002644\rOBX|20|FT|UPELNOB^US Pelvis Non-OB||waveforms with pulsatile arterial inflow and aphasic venous outflow.||||||F|||20251216002644\rOBX|21|FT|UPELNOB^US Pelvis Non-OB|| ||||||F|||20251216002644\rOBX|22|FT|UPELNOB^US Pelvis Non-OB||Left Ovary: Not seen||||||F|||20251216002644\rOBX|23|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|24|FT|UPELNOB^US Pelvis Non-OB||Free fluid: None||||||F|||20251216002644\rOBX|25|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|26|FT|UPELNOB^US Pelvis Non-OB||IMPRESSION: ||||||F|||20251216002644\rOBX|27|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|28|FT|UPELNOB^US Pelvis Non-OB||Right ovarian cyst, corresponds to CT finding.||||||F|||20251216002644\rOBX|29|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\rOBX|30|FT|UPELNOB^US Pelvis Non-OB||Signed on 12/16/2025 12:26 AM by Joshua J Graham, M.D.||||||F|||20251216002644\rOBX|31|FT|UPELNOB^US Pelvis Non-OB||||||||F|||20251216002644\r")
+)
+
+func TestCachedGroup(t *testing.T) {
+	typ := reflect.TypeFor[v23.ORU_R01]()
+
+	p := cachedGroup(typ)
+	require.Same(t, p, cachedGroup(reflect.PointerTo(typ)))
+	require.Equal(t, map[string]bool{"PID": true}, cachedGroup(reflect.TypeFor[v23.ResultGroup]()).first)
+	require.Equal(t, map[string]bool{"ORC": true, "OBR": true}, cachedGroup(reflect.TypeFor[v23.ObsOrderGroup]()).first)
+}
+
+func TestUnmarshal_Strict(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1\r" +
+		"ZPV|custom\r" +
+		"ORC|RE||1001\r" +
+		"OBR|1||1001\r" +
+		"Obx|1|FT|CXR||first\r")
+
+	var m v23.ORU_R01
+	require.NoError(t, Unmarshal(msg, &m))
+
+	err := UnmarshalOptions{Strict: true, AllErrors: true}.Unmarshal(msg, &v23.ORU_R01{})
+	var list ErrorList
+	require.ErrorAs(t, err, &list)
+	require.Len(t, list, 3)
+
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, list[0], &syntaxErr)
+	require.Equal(t, bytes.Index(msg, []byte("Obx")), syntaxErr.Offset)
+
+	var unexpected *UnexpectedSegmentError
+	require.ErrorAs(t, list[1], &unexpected)
+	require.Equal(t, "ZPV", unexpected.Segment)
+	require.Equal(t, bytes.Index(msg, []byte("ZPV")), unexpected.Offset)
+	require.ErrorAs(t, list[2], &unexpected)
+	require.Equal(t, "Obx", unexpected.Segment)
+}
+
+func TestUnmarshal_MaxFieldLength(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1~V2^^^HOSPITAL\r")
+
+	var m map[string]any
+	require.NoError(t, UnmarshalOptions{MaxFieldLength: 16}.Unmarshal(msg, &m))
+
+	err := UnmarshalOptions{MaxFieldLength: 15}.Unmarshal(msg, &m)
+	var lengthErr *FieldLengthError
+	require.ErrorAs(t, err, &lengthErr)
+	require.Equal(t, "PID-3", lengthErr.Location.String())
+	require.Equal(t, 16, lengthErr.Length)
+	require.Equal(t, bytes.Index(msg, []byte("V1~")), lengthErr.Offset)
+}
+
+func TestUnmarshal_AllowPartial(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1\r" +
+		"ORC|RE||1001\r" +
+		"OBX|1|FT|CXR||first\r")
+
+	var missingErr *MissingRequiredSegmentError
+	require.ErrorAs(t, Unmarshal(msg, &v23.ORU_R01{}), &missingErr)
+
+	var m v23.ORU_R01
+	require.NoError(t, UnmarshalOptions{AllowPartial: true}.Unmarshal(msg, &m))
+	require.Equal(t, []string{"first"}, m.Results[0].Order[0].Observation[0].OBX.ObservationValue)
+}
+
+func TestUnmarshal_Lenient(t *testing.T) {
+	msg := []byte("\xef\xbb\xbf \r\n" +
+		"MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\n" +
+		"pid|1||V1\r\n" +
+		"\r\n" +
+		"ORC|RE||1001\n" +
+		"OBR|1||1001\n" +
+		"obx|1|FT|CXR||first\n" +
+		"\x1c\r\n\x00")
+
+	var m v23.ORU_R01
+	require.Error(t, UnmarshalOptions{Strict: true}.Unmarshal(msg, &m))
+
+	m = v23.ORU_R01{}
+	require.NoError(t, UnmarshalOptions{Lenient: true, Strict: true}.Unmarshal(msg, &m))
+	require.Equal(t, "V1", m.Results[0].PID.InternalPatientId[0].Id)
+	require.Equal(t, []string{"first"}, m.Results[0].Order[0].Observation[0].OBX.ObservationValue)
+	require.Equal(t, "2.3", m.MSH.VersionId)
+
+	require.Equal(t, "MSH|^~\\&|App\rPID|1\r", string(tidy([]byte("\n MSH|^~\\&|App\n\npid|1\r\n--\r\n"))))
+}
+
+type oruWithUnknown struct {
+	MSH     v23s.MSH
+	Results []v23s.ResultGroup `hl7:"group"`
+	Unknown []RawSegment       `hl7:",unknown"`
+}
+
+func TestUnmarshal_UnknownSegments(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"OBX|0|ST|EARLY||too soon\r" +
+		"PID|1||V1\r" +
+		"ZPI|1|custom^value\r" +
+		"ORC|RE||1001\r" +
+		"OBR|1||1001\r" +
+		"OBX|1|FT|CXR||first\r" +
+		"ZDS|1.2.3^App^Application^DICOM\r")
+
+	var m oruWithUnknown
+	require.NoError(t, UnmarshalOptions{Strict: true}.Unmarshal(msg, &m))
+	require.Equal(t, []RawSegment{
+		{Name: "OBX", Data: []byte("OBX|0|ST|EARLY||too soon"), Index: 1},
+		{Name: "ZPI", Data: []byte("ZPI|1|custom^value"), Index: 3},
+		{Name: "ZDS", Data: []byte("ZDS|1.2.3^App^Application^DICOM"), Index: 7},
+	}, m.Unknown)
+	require.Equal(t, []string{"first"}, m.Results[0].Order[0].Observation[0].OBX.ObservationValue)
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, string(msg), string(b))
+
+	// edits to the mapped segments leave the raw ones in place
+	m.Results[0].Order[0].Observation = append(m.Results[0].Order[0].Observation, v23s.ObservationGroup{
+		OBX: v23s.OBX{SetId: "2", ValueType: "FT", ObservationValue: []string{"second"}},
+	})
+	b, err = Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r"+
+		"OBX|0|ST|EARLY||too soon\r"+
+		"PID|1||V1\r"+
+		"ZPI|1|custom^value\r"+
+		"ORC|RE||1001\r"+
+		"OBR|1||1001\r"+
+		"OBX|1|FT|CXR||first\r"+
+		"ZDS|1.2.3^App^Application^DICOM\r"+
+		"OBX|2|FT|||second\r", string(b))
+}
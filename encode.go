@@ -0,0 +1,531 @@
+package hl7
+
+import (
+	"bytes"
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// Marshal returns the ER7 encoding of v, which must be a struct (or a
+// pointer to one) tagged the same way as the structs accepted by Unmarshal.
+// The text is encoded in the character set named in MSH-18, if any.
+func Marshal(v any) ([]byte, error) {
+	return MarshalOptions{}.Marshal(v)
+}
+
+// MarshalOptions configures how a message is encoded. The zero value
+// encodes the same way as Marshal.
+type MarshalOptions struct {
+	// KeepFormatting writes formatting escape sequences such as \.br\
+	// found in string values as-is instead of escaping their escape
+	// characters. It is the counterpart of UnmarshalOptions.KeepFormatting.
+	KeepFormatting bool
+}
+
+func (o MarshalOptions) Marshal(v any) ([]byte, error) {
+	e := newEncodeState()
+	e.opts = o
+	if err := e.marshal(v); err != nil {
+		return nil, err
+	}
+
+	out, err := encodeCharset(e.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.Clone(out), nil
+}
+
+// Marshaler is the interface implemented by types that can encode
+// themselves as HL7. MarshalHL7 returns ER7 text that is written as-is, so
+// it must already be escaped using delims: a whole segment (without its
+// terminator) for a segment, or the text of a field or component.
+type Marshaler interface {
+	MarshalHL7(delims Delimiters) ([]byte, error)
+}
+
+var marshalerType = reflect.TypeFor[Marshaler]()
+
+type InvalidMarshalError struct {
+	Type reflect.Type
+}
+
+func (e *InvalidMarshalError) Error() string {
+	if e.Type == nil {
+		return "hl7: Marshal(nil)"
+	}
+	if e.Type.Kind() == reflect.Pointer {
+		return "hl7: Marshal(nil " + e.Type.String() + ")"
+	}
+
+	return "hl7: Marshal(non-struct " + e.Type.String() + ")"
+}
+
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "hl7: unsupported type: " + e.Type.String()
+}
+
+const (
+	defaultFldDelim = '|'
+	defaultComDelim = '^'
+	defaultRepDelim = '~'
+	defaultEscDelim = '\\'
+	defaultSubDelim = '&'
+)
+
+type encodeState struct {
+	bytes.Buffer
+
+	fldDelim byte
+	comDelim byte
+	repDelim byte
+	escDelim byte
+	subDelim byte
+
+	opts MarshalOptions
+
+	nseg int          // segments written so far
+	raw  []RawSegment // raw segments not yet written, by Index
+}
+
+func newEncodeState() *encodeState {
+	return &encodeState{
+		fldDelim: defaultFldDelim,
+		comDelim: defaultComDelim,
+		repDelim: defaultRepDelim,
+		escDelim: defaultEscDelim,
+		subDelim: defaultSubDelim,
+	}
+}
+
+func (e *encodeState) marshal(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return &InvalidMarshalError{rv.Type()}
+		}
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return &InvalidMarshalError{reflect.TypeOf(v)}
+	}
+
+	if err := e.group(rv); err != nil {
+		return err
+	}
+	e.writeRaw(true)
+
+	return nil
+}
+
+// writeRaw writes the pending raw segments whose turn has come: those that
+// stood at or before the current position in the message they were read
+// from, or all of them if all is set.
+func (e *encodeState) writeRaw(all bool) {
+	for len(e.raw) > 0 && (all || e.raw[0].Index <= e.nseg) {
+		e.Write(e.raw[0].Data)
+		e.WriteByte('\r')
+		e.nseg++
+		e.raw = e.raw[1:]
+	}
+}
+
+// group writes every segment reachable from the message or group struct
+// v, in field order.
+func (e *encodeState) group(v reflect.Value) error {
+	plan := cachedGroup(v.Type())
+	if plan.unknown >= 0 {
+		e.raw = append(e.raw, v.Field(plan.unknown).Interface().([]RawSegment)...)
+		slices.SortStableFunc(e.raw, func(a, b RawSegment) int {
+			return cmp.Compare(a.Index, b.Index)
+		})
+	}
+
+	for _, c := range plan.children {
+		var err error
+		if c.group {
+			err = e.each(v.Field(c.index), e.group)
+		} else {
+			err = e.each(v.Field(c.index), func(seg reflect.Value) error {
+				e.writeRaw(false)
+				mark := e.Len()
+				if err := e.segment(c.name, seg); err != nil {
+					return err
+				}
+				if e.Len() > mark {
+					e.nseg++
+				}
+				return nil
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// each calls fn for every struct held by v, which may be a struct, a
+// pointer or interface holding one, or a slice of these. An interface may
+// also hold a segment in the map form Unmarshal gives segments of no
+// registered type. Nil pointers and interfaces are skipped.
+func (e *encodeState) each(v reflect.Value, fn func(reflect.Value) error) error {
+	switch v.Kind() {
+	default:
+		if !isComposite(v.Type()) {
+			return &UnsupportedTypeError{v.Type()}
+		}
+		return fn(v)
+	case reflect.Struct:
+		return fn(v)
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return e.each(v.Elem(), fn)
+	case reflect.Slice:
+		for i := range v.Len() {
+			if err := e.each(v.Index(i), fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (e *encodeState) segment(name string, v reflect.Value) error {
+	if m, ok := asMarshaler(v); ok {
+		start := e.Len()
+		if err := e.marshaler(m, v.Type()); err != nil {
+			return err
+		}
+		if e.Len() > start {
+			e.WriteByte('\r')
+		}
+		return nil
+	}
+
+	isHeader := isHeaderSegment(name)
+	if isHeader {
+		e.setDelimiters(v)
+	}
+
+	start := e.Len()
+	e.WriteString(name)
+	if isHeader {
+		e.WriteByte(e.fldDelim)
+		e.Write(e.encodingChars())
+	}
+	end := e.Len()
+
+	// MSH-1 is the field separator itself, so MSH-3 is the first field
+	// that needs one written in front of it.
+	n := 0
+	if isHeader {
+		n = 2
+	}
+	err := indexedFields(v, func(hl7Idx int, fv reflect.Value, opts tagOptions) error {
+		if isHeader && hl7Idx <= 2 {
+			return nil
+		}
+		for ; n < hl7Idx; n++ {
+			e.WriteByte(e.fldDelim)
+		}
+
+		mark := e.Len()
+		if err := e.field(fv, opts); err != nil {
+			return err
+		}
+		if e.Len() > mark {
+			end = e.Len()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if end == start+len(name) && !isHeader {
+		// nothing but the segment name: leave the segment out
+		e.Truncate(start)
+		return nil
+	}
+
+	e.Truncate(end)
+	e.WriteByte('\r')
+	return nil
+}
+
+// segmentFields calls fn with the 1-based HL7 index and tag options of
+// each field of the segment or composite struct v, numbered the same way
+// the decoder numbers them.
+func segmentFields(v reflect.Value, fn func(int, reflect.Value, tagOptions) error) error {
+	for _, f := range cachedCompositeFields(v.Type()) {
+		if err := fn(f.hl7Idx, v.Field(f.index), f.tag.Options); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexedFields calls fn the same way for the struct v and for a map
+// keyed by HL7 index, the form Unmarshal gives the segments and composite
+// values it stores in interfaces. The values of a map are visited in
+// index order.
+func indexedFields(v reflect.Value, fn func(int, reflect.Value, tagOptions) error) error {
+	if v.Kind() != reflect.Map {
+		return segmentFields(v, fn)
+	}
+
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return cmp.Compare(a.Int(), b.Int())
+	})
+	for _, k := range keys {
+		if err := fn(int(k.Int()), v.MapIndex(k), ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isComposite reports whether values of type t are written field by field
+// or component by component: structs, and maps keyed by HL7 index.
+func isComposite(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Map:
+		switch t.Key().Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e *encodeState) setDelimiters(msh reflect.Value) {
+	_ = indexedFields(msh, func(hl7Idx int, fv reflect.Value, _ tagOptions) error {
+		fv = indirect(fv)
+		if fv.Kind() != reflect.String {
+			return nil
+		}
+
+		s := fv.String()
+		switch {
+		case hl7Idx == 1 && len(s) == 1:
+			e.fldDelim = s[0]
+		case hl7Idx == 2 && len(s) == 4:
+			e.comDelim = s[0]
+			e.repDelim = s[1]
+			e.escDelim = s[2]
+			e.subDelim = s[3]
+		}
+
+		return nil
+	})
+}
+
+func (e *encodeState) encodingChars() []byte {
+	return []byte{e.comDelim, e.repDelim, e.escDelim, e.subDelim}
+}
+
+// field writes a single field value: slices become repetitions, structs
+// become components.
+func (e *encodeState) field(v reflect.Value, opts tagOptions) error {
+	rv := indirect(v)
+	if !rv.IsValid() {
+		return nil
+	}
+	if val, presence, ok := asNullable(rv); ok {
+		if presence != Present {
+			return e.null(presence)
+		}
+		return e.field(pointerTo(val), opts)
+	}
+	if rv.Kind() != reflect.Slice {
+		return e.component(v, 0, opts)
+	}
+	if m, ok := asMarshaler(rv); ok {
+		return e.marshaler(m, rv.Type())
+	}
+
+	start := e.Len()
+	end := start
+	for i := range rv.Len() {
+		if i > 0 {
+			e.WriteByte(e.repDelim)
+		}
+
+		mark := e.Len()
+		if err := e.component(rv.Index(i), 0, opts); err != nil {
+			return err
+		}
+		if e.Len() > mark {
+			end = e.Len()
+		}
+	}
+
+	e.Truncate(end)
+	return nil
+}
+
+// component writes v at the given depth. A scalar reached through a non-nil
+// pointer is written even when it holds its zero value.
+func (e *encodeState) component(v reflect.Value, depth int, opts tagOptions) error {
+	explicit := v.Kind() == reflect.Pointer && !v.IsNil()
+	v = indirect(v)
+	if !v.IsValid() {
+		return nil
+	}
+	if val, presence, ok := asNullable(v); ok {
+		if presence != Present {
+			return e.null(presence)
+		}
+		return e.component(pointerTo(val), depth, opts)
+	}
+	if m, ok := asMarshaler(v); ok {
+		return e.marshaler(m, v.Type())
+	}
+
+	switch {
+	case v.Kind() == reflect.String:
+		e.writeEscaped(v.String(), e.opts.KeepFormatting)
+		return nil
+	case isScalar(v.Type()):
+		e.Write(appendScalar(e.AvailableBuffer(), v, opts, explicit))
+		return nil
+	case !isComposite(v.Type()):
+		return &UnsupportedTypeError{v.Type()}
+	}
+
+	sep := e.comDelim
+	if depth > 0 {
+		sep = e.subDelim
+	}
+
+	start := e.Len()
+	end := start
+	n := 1
+	err := indexedFields(v, func(hl7Idx int, fv reflect.Value, opts tagOptions) error {
+		if depth > 1 && hl7Idx > 1 {
+			return nil
+		}
+		for ; n < hl7Idx; n++ {
+			e.WriteByte(sep)
+		}
+
+		mark := e.Len()
+		if err := e.component(fv, depth+1, opts); err != nil {
+			return err
+		}
+		if e.Len() > mark {
+			end = e.Len()
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	e.Truncate(end)
+	return nil
+}
+
+// null writes the explicit null for a null Nullable and nothing for an
+// absent one.
+func (e *encodeState) null(presence Presence) error {
+	if presence == PresentNull {
+		e.WriteString(nullText)
+	}
+
+	return nil
+}
+
+// pointerTo returns a pointer to v, so that component writes v even if it
+// holds its zero value.
+func pointerTo(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
+
+func asMarshaler(v reflect.Value) (Marshaler, bool) {
+	if cachedImplements(v.Type(), marshalerType) {
+		return v.Interface().(Marshaler), true
+	}
+	if v.CanAddr() && cachedImplements(reflect.PointerTo(v.Type()), marshalerType) {
+		return v.Addr().Interface().(Marshaler), true
+	}
+
+	return nil, false
+}
+
+func (e *encodeState) marshaler(m Marshaler, t reflect.Type) error {
+	b, err := m.MarshalHL7(e.delimiters())
+	if err != nil {
+		return &MarshalerError{Type: t, Err: err}
+	}
+
+	e.Write(b)
+	return nil
+}
+
+func (e *encodeState) delimiters() Delimiters {
+	return Delimiters{
+		Field:        e.fldDelim,
+		Component:    e.comDelim,
+		Repetition:   e.repDelim,
+		Escape:       e.escDelim,
+		SubComponent: e.subDelim,
+	}
+}
+
+// indirect dereferences pointers and interfaces, returning the zero Value
+// for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// isSegmentName reports whether name looks like an HL7 segment ID: an
+// upper-case letter followed by two upper-case letters or digits.
+func isSegmentName(name string) bool {
+	if len(name) != 3 {
+		return false
+	}
+	if name[0] < 'A' || name[0] > 'Z' {
+		return false
+	}
+	for i := 1; i < 3; i++ {
+		c := name[i]
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
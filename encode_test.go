@@ -0,0 +1,170 @@
+package hl7
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	v23 "github.com/s-hammon/hl7/proto/standards/v23"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMarshal(t *testing.T) {
+	m := &v23.ORU_R01{
+		MSH: &v23.MSH{
+			FieldDelimiter:     "|",
+			EncodingCharacters: "^~\\&",
+			SendingApplication: "SendingApp",
+			MessageType:        &v23.CMMSG{Type: "ORU", TriggerEvent: "R01"},
+			ControlId:          "123",
+		},
+		Results: []*v23.ResultGroup{
+			{
+				PID: &v23.PID{
+					SetId:       "1",
+					PatientName: []*v23.XPN{{FamilyName: "DOE", GivenName: "JANE"}},
+				},
+				Order: []*v23.ObsOrderGroup{
+					{
+						OBR: &v23.OBR{
+							SetId:                      "1",
+							UniversalServiceId:         &v23.CE{Identifier: "CXR", Text: "Chest 1 View"},
+							PrincipalResultInterpreter: &v23.CMOBS{Name: &v23.CN{IdNumber: "999696", FamilyName: "Graham"}},
+						},
+						Observation: []*v23.ObservationGroup{
+							{OBX: &v23.OBX{SetId: "1", ValueType: "FT", ObservationValue: []string{"FINDINGS:"}}},
+							{OBX: &v23.OBX{SetId: "2", ValueType: "FT"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	want := "MSH|^~\\&|SendingApp||||||ORU^R01|123\r" +
+		"PID|1||||DOE^JANE\r" +
+		"OBR|1|||CXR^Chest 1 View" + strings.Repeat("|", 28) + "999696&Graham\r" +
+		"OBX|1|FT|||FINDINGS:\r" +
+		"OBX|2|FT\r"
+
+	got, err := Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, want, string(got))
+}
+
+func TestMarshal_Delimiters(t *testing.T) {
+	m := &v23.ORU_R01{
+		MSH: &v23.MSH{
+			FieldDelimiter:     "#",
+			EncodingCharacters: "$*/@",
+			MessageType:        &v23.CMMSG{Type: "ORU", TriggerEvent: "R01"},
+		},
+		Results: []*v23.ResultGroup{
+			{
+				PID: &v23.PID{
+					InternalPatientId: []*v23.CX{{Id: "V12345", AssigningAuthority: "ACME"}},
+				},
+			},
+		},
+	}
+
+	got, err := Marshal(m)
+	require.NoError(t, err)
+	require.Equal(t, "MSH#$*/@#######ORU$R01\rPID###V12345$$$ACME\r", string(got))
+}
+
+func TestMarshal_Error(t *testing.T) {
+	var invalidErr *InvalidMarshalError
+
+	_, err := Marshal(nil)
+	require.ErrorAs(t, err, &invalidErr)
+
+	var m *v23.ORU_R01
+	_, err = Marshal(m)
+	require.ErrorAs(t, err, &invalidErr)
+
+	_, err = Marshal("MSH|^~\\&|")
+	require.ErrorAs(t, err, &invalidErr)
+
+	type bad struct {
+		ZZZ struct{ Value chan int }
+	}
+	var unsupportedErr *UnsupportedTypeError
+	_, err = Marshal(bad{ZZZ: struct{ Value chan int }{make(chan int)}})
+	require.ErrorAs(t, err, &unsupportedErr)
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  []byte
+		new  func() proto.Message
+	}{
+		{"ORM", ormMsg, func() proto.Message { return &v23.ORM_O01{} }},
+		{"ORU", oruMsg, func() proto.Message { return &v23.ORU_R01{} }},
+		{"ORU_MultipleOrders", oruMultipleOrdersMsg, func() proto.Message { return &v23.ORU_R01{} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := tt.new()
+			require.NoError(t, Unmarshal(tt.msg, first))
+
+			data, err := Marshal(first)
+			require.NoError(t, err)
+
+			second := tt.new()
+			require.NoError(t, Unmarshal(data, second))
+			require.True(t, proto.Equal(first, second), "round trip mismatch:\n%s", data)
+		})
+	}
+}
+
+func TestMarshal_Marshaler(t *testing.T) {
+	end := hl7Time{time.Date(2025, 1, 1, 9, 30, 0, 0, time.UTC)}
+	m := customMsg{
+		MSH: &v23.MSH{SendingApplication: "App"},
+		PID: customPID{SetId: "1", Id: "V1", Name: "JANE DOE"},
+		ORC: customORC{OrderControl: "XO"},
+		ZPV: []zpv{{"ZPV|1|A^B\\S\\C"}, {"ZPV|2"}},
+	}
+	m.ORC.QuantityTiming.Start = hl7Time{time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)}
+	m.ORC.QuantityTiming.End = &end
+
+	got, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|App\r"+
+		"PID|1||V1||DOE^JANE\r"+
+		"ORC|XO||||||^^^20250101080000^20250101093000\r"+
+		"ZPV|1|A^B\\S\\C\r"+
+		"ZPV|2\r", string(got))
+
+	_, err = Marshal(struct{ ZPV failingMarshaler }{})
+	var marshalerErr *MarshalerError
+	require.ErrorAs(t, err, &marshalerErr)
+	require.ErrorIs(t, err, errFailingMarshaler)
+}
+
+var errFailingMarshaler = errors.New("cannot marshal")
+
+type failingMarshaler struct{}
+
+func (failingMarshaler) MarshalHL7(Delimiters) ([]byte, error) {
+	return nil, errFailingMarshaler
+}
+
+func BenchmarkMarshal_ORU(b *testing.B) {
+	var m v23.ORU_R01
+	if err := Unmarshal(oruMultipleOrdersMsg, &m); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := Marshal(&m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package hl7
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A SyntaxError describes input that is not a well-formed HL7 message.
+type SyntaxError struct {
+	msg    string
+	Offset int // byte offset in the input where the error was found
+}
+
+func (e *SyntaxError) Error() string {
+	return "hl7: " + e.msg + " (offset " + strconv.Itoa(e.Offset) + ")"
+}
+
+// A Location identifies a value within a message. Indexes are 1-based and
+// zero when they do not apply, so a Location with only Segment, Ordinal
+// and Field set refers to a whole field.
+type Location struct {
+	Segment      string // segment ID, such as "PID"
+	Ordinal      int    // occurrence of Segment within the message
+	Field        int
+	Repetition   int
+	Component    int
+	SubComponent int
+	Offset       int // byte offset of the value in the input
+}
+
+// String returns the location in terser notation, for example "OBX(3)-5"
+// or "PID-3(2)-1". Ordinals and repetitions are only shown past the first.
+func (l Location) String() string {
+	var b strings.Builder
+	b.WriteString(l.Segment)
+	if l.Ordinal > 1 {
+		b.WriteString("(" + strconv.Itoa(l.Ordinal) + ")")
+	}
+	if l.Field == 0 {
+		return b.String()
+	}
+
+	b.WriteString("-" + strconv.Itoa(l.Field))
+	if l.Repetition > 1 {
+		b.WriteString("(" + strconv.Itoa(l.Repetition) + ")")
+	}
+	if l.Component > 0 {
+		b.WriteString("-" + strconv.Itoa(l.Component))
+	}
+	if l.SubComponent > 0 {
+		b.WriteString("-" + strconv.Itoa(l.SubComponent))
+	}
+
+	return b.String()
+}
+
+// An UnmarshalTypeError describes an HL7 value that could not be stored in
+// a Go value of a particular type.
+type UnmarshalTypeError struct {
+	Value string       // description of the HL7 value: "string", "composite", ...
+	Type  reflect.Type // type of the Go value it could not be assigned to
+	Location
+	Err error // the conversion error, if the value could not be parsed
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	msg := "hl7: cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String() +
+		" at " + e.Location.String() + " (offset " + strconv.Itoa(e.Offset) + ")"
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e *UnmarshalTypeError) Unwrap() error {
+	return e.Err
+}
+
+// An UnmarshalerError wraps an error returned by an Unmarshaler.
+type UnmarshalerError struct {
+	Type reflect.Type
+	Location
+	Err error
+}
+
+func (e *UnmarshalerError) Error() string {
+	return "hl7: error calling UnmarshalHL7 for type " + e.Type.String() +
+		" at " + e.Location.String() + ": " + e.Err.Error()
+}
+
+func (e *UnmarshalerError) Unwrap() error {
+	return e.Err
+}
+
+// A MarshalerError wraps an error returned by a Marshaler.
+type MarshalerError struct {
+	Type reflect.Type
+	Err  error
+}
+
+func (e *MarshalerError) Error() string {
+	return "hl7: error calling MarshalHL7 for type " + e.Type.String() + ": " + e.Err.Error()
+}
+
+func (e *MarshalerError) Unwrap() error {
+	return e.Err
+}
+
+// A MissingRequiredSegmentError reports that a message or group instance
+// lacks a segment whose struct field is tagged `required`.
+type MissingRequiredSegmentError struct {
+	Segment string // the missing segment ID
+	Group   string // Go type name of the message or group
+	Offset  int    // byte offset where the message or group instance starts
+}
+
+func (e *MissingRequiredSegmentError) Error() string {
+	return "hl7: missing required segment " + e.Segment + " in " + e.Group +
+		" (offset " + strconv.Itoa(e.Offset) + ")"
+}
+
+// An UnexpectedSegmentError reports, in strict mode, a segment that the
+// message struct has no place for at the point where it appears.
+type UnexpectedSegmentError struct {
+	Segment string // the segment ID
+	Group   string // Go type name of the message or group that skipped it
+	Offset  int    // byte offset of the segment
+}
+
+func (e *UnexpectedSegmentError) Error() string {
+	return "hl7: unexpected segment " + e.Segment + " in " + e.Group +
+		" (offset " + strconv.Itoa(e.Offset) + ")"
+}
+
+// A FieldLengthError reports a field longer than
+// UnmarshalOptions.MaxFieldLength allows.
+type FieldLengthError struct {
+	Location
+	Length    int
+	MaxLength int
+}
+
+func (e *FieldLengthError) Error() string {
+	return "hl7: field " + e.Location.String() + " is " + strconv.Itoa(e.Length) +
+		" bytes long, more than the maximum of " + strconv.Itoa(e.MaxLength) +
+		" (offset " + strconv.Itoa(e.Offset) + ")"
+}
+
+// An ErrorList is returned when UnmarshalOptions.AllErrors is set and
+// decoding found one or more problems. The errors are in message order.
+type ErrorList []error
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "hl7: no errors"
+	case 1:
+		return l[0].Error()
+	}
+
+	return l[0].Error() + " (and " + strconv.Itoa(len(l)-1) + " more errors)"
+}
+
+func (l ErrorList) Unwrap() []error {
+	return l
+}
+
+// A BatchCountError reports a batch whose BTS-1 does not match the number
+// of messages in it, or a file whose FTS-1 does not match the number of
+// batches in it.
+type BatchCountError struct {
+	Segment string // "BTS" or "FTS"
+	Count   int    // the count the trailer declares
+	Actual  int    // the number of messages or batches found
+	Offset  int    // byte offset of the trailer
+}
+
+func (e *BatchCountError) Error() string {
+	what := "messages"
+	if e.Segment == "FTS" {
+		what = "batches"
+	}
+
+	return "hl7: " + e.Segment + "-1 counts " + strconv.Itoa(e.Count) + " " + what +
+		", found " + strconv.Itoa(e.Actual) + " (offset " + strconv.Itoa(e.Offset) + ")"
+}
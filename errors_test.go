@@ -0,0 +1,110 @@
+package hl7
+
+import (
+	"bytes"
+	"testing"
+
+	pb "github.com/s-hammon/hl7/proto/standards/v23"
+	v23 "github.com/s-hammon/hl7/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+type zts struct {
+	SetId string
+	Bad   map[string]string
+	Coded zcm
+}
+
+type zcm struct {
+	Identifier string
+	Detail     zsc
+}
+
+type zsc struct {
+	Code string
+	Bad  map[string]string
+}
+
+type typeErrorMsg struct {
+	MSH v23.MSH
+	ZTS []zts
+}
+
+func TestLocation_String(t *testing.T) {
+	tests := []struct {
+		loc  Location
+		want string
+	}{
+		{Location{Segment: "PID", Ordinal: 1}, "PID"},
+		{Location{Segment: "OBX", Ordinal: 3, Field: 5}, "OBX(3)-5"},
+		{Location{Segment: "PID", Ordinal: 1, Field: 3, Repetition: 2, Component: 1}, "PID-3(2)-1"},
+		{Location{Segment: "OBR", Ordinal: 2, Field: 32, Repetition: 1, Component: 1, SubComponent: 2}, "OBR(2)-32-1-2"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, tt.loc.String())
+	}
+}
+
+func TestUnmarshal_SyntaxError(t *testing.T) {
+	var m map[string]any
+
+	var syntaxErr *SyntaxError
+	err := Unmarshal([]byte("ABC|^~\\&|App\r"), &m)
+	require.ErrorAs(t, err, &syntaxErr)
+	require.Equal(t, 0, syntaxErr.Offset)
+
+	err = Unmarshal([]byte("MSH|^~"), &m)
+	require.ErrorAs(t, err, &syntaxErr)
+	require.Equal(t, 6, syntaxErr.Offset)
+}
+
+func TestUnmarshal_UnmarshalTypeError(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App\rZTS|1|x|A^B&C\rZTS|2||A^B&C\r")
+
+	var m typeErrorMsg
+	err := Unmarshal(msg, &m)
+
+	var typeErr *UnmarshalTypeError
+	require.ErrorAs(t, err, &typeErr)
+	require.Equal(t, Location{Segment: "ZTS", Ordinal: 1, Field: 2, Offset: 19}, typeErr.Location)
+	require.Equal(t, "string", typeErr.Value)
+	require.Equal(t, "ZTS-2", typeErr.Location.String())
+
+	// decoding carried on past the error
+	require.Len(t, m.ZTS, 2)
+	require.Equal(t, "B", m.ZTS[0].Coded.Detail.Code)
+
+	err = UnmarshalOptions{AllErrors: true}.Unmarshal(msg, &typeErrorMsg{})
+	var list ErrorList
+	require.ErrorAs(t, err, &list)
+	require.Len(t, list, 3)
+
+	require.ErrorAs(t, list[1], &typeErr)
+	require.Equal(t, "ZTS-3-2-2", typeErr.Location.String())
+
+	require.ErrorAs(t, list[2], &typeErr)
+	require.Equal(t, "ZTS(2)-3-2-2", typeErr.Location.String())
+	require.Equal(t, bytes.LastIndex(msg, []byte("C")), typeErr.Offset)
+}
+
+func TestUnmarshal_MissingRequiredSegment(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1\r" +
+		"ORC|RE||1001\r" +
+		"OBX|1|FT|CXR||first\r" +
+		"ORC|RE||1002\r" +
+		"OBR|1||1002\r")
+
+	var m pb.ORU_R01
+	err := Unmarshal(msg, &m)
+
+	var missingErr *MissingRequiredSegmentError
+	require.ErrorAs(t, err, &missingErr)
+	require.Equal(t, "OBR", missingErr.Segment)
+	require.Equal(t, "ObsOrderGroup", missingErr.Group)
+	require.Equal(t, bytes.Index(msg, []byte("ORC|RE||1001")), missingErr.Offset)
+
+	require.Len(t, m.Results[0].Order, 2)
+	require.Equal(t, "1002", m.Results[0].Order[1].OBR.FillerOrderNumber)
+}
@@ -0,0 +1,172 @@
+package hl7
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// unescape replaces the escape sequences in a component or subcomponent
+// value with the text they stand for. Delimiter (\F\, \S\, \T\, \R\, \E\)
+// and hexadecimal (\Xhh..\) sequences always decode; formatting sequences
+// (\.br\, \H\, ...) decode to their plain-text equivalent unless
+// keepFormatting is set, in which case they are left in place. Sequences
+// that are unknown or unterminated are kept verbatim.
+func (s *scanner) unescape(raw string, keepFormatting bool) string {
+	if s.escDelim == 0 || strings.IndexByte(raw, s.escDelim) < 0 {
+		return raw
+	}
+
+	var b strings.Builder
+	b.Grow(len(raw))
+	for {
+		i := strings.IndexByte(raw, s.escDelim)
+		if i < 0 {
+			break
+		}
+		j := strings.IndexByte(raw[i+1:], s.escDelim)
+		if j < 0 {
+			break
+		}
+
+		b.WriteString(raw[:i])
+		seq := raw[i+1 : i+1+j]
+		if !s.writeUnescaped(&b, seq, keepFormatting) {
+			b.WriteString(raw[i : i+j+2])
+		}
+		raw = raw[i+j+2:]
+	}
+	b.WriteString(raw)
+
+	return b.String()
+}
+
+func (s *scanner) writeUnescaped(b *strings.Builder, seq string, keepFormatting bool) bool {
+	switch seq {
+	case "F":
+		b.WriteByte(s.fldDelim)
+		return true
+	case "S":
+		b.WriteByte(s.comDelim)
+		return true
+	case "T":
+		b.WriteByte(s.subDelim)
+		return true
+	case "R":
+		b.WriteByte(s.repDelim)
+		return true
+	case "E":
+		b.WriteByte(s.escDelim)
+		return true
+	}
+
+	if hexDigits, ok := strings.CutPrefix(seq, "X"); ok {
+		p, err := hex.DecodeString(hexDigits)
+		if err != nil || len(p) == 0 {
+			return false
+		}
+		b.Write(p)
+		return true
+	}
+
+	text, ok := formattingText(seq)
+	if !ok {
+		return false
+	}
+	if keepFormatting {
+		b.WriteByte(s.escDelim)
+		b.WriteString(seq)
+		b.WriteByte(s.escDelim)
+	} else {
+		b.WriteString(text)
+	}
+
+	return true
+}
+
+// formattingText reports whether seq is a formatting escape sequence
+// (without its surrounding escape characters) and returns its plain-text
+// rendering. Highlighting, indentation and fill/justify commands have no
+// plain-text form and render as the empty string.
+func formattingText(seq string) (string, bool) {
+	switch seq {
+	case "H", "N", ".fi", ".nf":
+		return "", true
+	case ".br", ".ce":
+		return "\n", true
+	}
+
+	if len(seq) < 3 || seq[0] != '.' {
+		return "", false
+	}
+
+	cmd, arg := seq[1:3], strings.TrimSpace(seq[3:])
+	switch cmd {
+	default:
+		return "", false
+	case "sp", "sk":
+		n := 1
+		if arg != "" {
+			v, err := strconv.Atoi(arg)
+			if err != nil || v < 0 {
+				return "", false
+			}
+			n = v
+		}
+		if cmd == "sp" {
+			return strings.Repeat("\n", max(n, 1)), true
+		}
+		return strings.Repeat(" ", n), true
+	case "in", "ti":
+		if _, err := strconv.Atoi(arg); err != nil {
+			return "", false
+		}
+		return "", true
+	}
+}
+
+// writeEscaped writes s, replacing delimiter and escape characters with
+// their escape sequences. Line breaks, which would otherwise end the
+// segment, are written as \.br\ and \X0D\. When keepFormatting is set,
+// formatting escape sequences already present in s are written as-is.
+func (e *encodeState) writeEscaped(s string, keepFormatting bool) {
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		var seq string
+		switch c {
+		default:
+			continue
+		case e.fldDelim:
+			seq = "F"
+		case e.comDelim:
+			seq = "S"
+		case e.subDelim:
+			seq = "T"
+		case e.repDelim:
+			seq = "R"
+		case e.escDelim:
+			if keepFormatting {
+				if j := strings.IndexByte(s[i+1:], e.escDelim); j >= 0 {
+					if _, ok := formattingText(s[i+1 : i+1+j]); ok {
+						i += j + 1
+						continue
+					}
+				}
+			}
+			seq = "E"
+		case '\n':
+			seq = ".br"
+		case '\r':
+			seq = "X0D"
+		}
+
+		e.WriteString(s[start:i])
+		e.WriteByte(e.escDelim)
+		e.WriteString(seq)
+		e.WriteByte(e.escDelim)
+		start = i + 1
+	}
+	e.WriteString(s[start:])
+}
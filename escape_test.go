@@ -0,0 +1,84 @@
+package hl7
+
+import (
+	"testing"
+
+	v23 "github.com/s-hammon/hl7/proto/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanner_Unescape(t *testing.T) {
+	s := newState([]byte("MSH|^~\\&|\r")).scan
+
+	tests := []struct {
+		raw  string
+		want string
+		keep string
+	}{
+		{"plain text", "plain text", "plain text"},
+		{`A\F\B\S\C\T\D\R\E\E\`, `A|B^C&D~E\`, `A|B^C&D~E\`},
+		{`caf\XC3A9\`, "café", "café"},
+		{`line one\.br\line two`, "line one\nline two", `line one\.br\line two`},
+		{`\H\BOLD\N\ text`, "BOLD text", `\H\BOLD\N\ text`},
+		{`a\.sp2\b\.sk3\c`, "a\n\nb   c", `a\.sp2\b\.sk3\c`},
+		{`\.in+4\indented`, "indented", `\.in+4\indented`},
+		{`C:\temp`, `C:\temp`, `C:\temp`},
+		{`\Q\ unknown`, `\Q\ unknown`, `\Q\ unknown`},
+		{`\XZZ\ bad hex`, `\XZZ\ bad hex`, `\XZZ\ bad hex`},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, s.unescape(tt.raw, false), tt.raw)
+		require.Equal(t, tt.keep, s.unescape(tt.raw, true), tt.raw)
+	}
+}
+
+func TestEncodeState_WriteEscaped(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+		keep string
+	}{
+		{"plain text", "plain text", "plain text"},
+		{`A|B^C&D~E\`, `A\F\B\S\C\T\D\R\E\E\`, `A\F\B\S\C\T\D\R\E\E\`},
+		{"line one\nline two\r", `line one\.br\line two\X0D\`, `line one\.br\line two\X0D\`},
+		{`keep \.br\ and \H\this\N\`, `keep \E\.br\E\ and \E\H\E\this\E\N\E\`, `keep \.br\ and \H\this\N\`},
+	}
+
+	for _, tt := range tests {
+		e := newEncodeState()
+		e.writeEscaped(tt.s, false)
+		require.Equal(t, tt.want, e.String(), tt.s)
+
+		e = newEncodeState()
+		e.writeEscaped(tt.s, true)
+		require.Equal(t, tt.keep, e.String(), tt.s)
+	}
+}
+
+func TestUnmarshal_Escapes(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App\\T\\Co|Fac\r" +
+		"PID|1||V123^^^A\\S\\B||O\\E\\BRIEN^JANE\\F\\ANN\r" +
+		"ORC|RE\rOBR|1\r" +
+		"OBX|1|FT|CXR^Chest 1 View||FINDINGS:\\.br\\No acute \\H\\disease\\N\\.\r")
+
+	var m v23.ORU_R01
+	require.NoError(t, Unmarshal(msg, &m))
+	require.Equal(t, "App&Co", m.MSH.SendingApplication)
+	require.Equal(t, "A^B", m.Results[0].PID.InternalPatientId[0].AssigningAuthority)
+	require.Equal(t, "O\\BRIEN", m.Results[0].PID.PatientName[0].FamilyName)
+	require.Equal(t, "JANE|ANN", m.Results[0].PID.PatientName[0].GivenName)
+	require.Equal(t, []string{"FINDINGS:\nNo acute disease."}, m.Results[0].Order[0].Observation[0].OBX.ObservationValue)
+
+	var raw v23.ORU_R01
+	require.NoError(t, UnmarshalOptions{KeepFormatting: true}.Unmarshal(msg, &raw))
+	require.Equal(t, []string{"FINDINGS:\\.br\\No acute \\H\\disease\\N\\."}, raw.Results[0].Order[0].Observation[0].OBX.ObservationValue)
+
+	out, err := MarshalOptions{KeepFormatting: true}.Marshal(&raw)
+	require.NoError(t, err)
+	require.Equal(t, string(msg), string(out))
+
+	out, err = Marshal(&m)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "OBX|1|FT|CXR^Chest 1 View||FINDINGS:\\.br\\No acute disease.\r")
+}
@@ -0,0 +1,383 @@
+package hl7
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// A Message is a generic, read-only view of an HL7 message: its segments in
+// the order they appear, split into fields, repetitions, components and
+// subcomponents on demand. Every value is a slice of the input it was
+// parsed from, which must therefore not be modified while the Message is in
+// use. Use a Message to inspect messages whose structure is not known in
+// advance, such as when routing them.
+type Message struct {
+	Delimiters Delimiters
+	Segments   []Segment
+
+	data []byte
+}
+
+// ParseMessage parses data, which must start with an MSH segment, into a
+// Message. Segments may be terminated by CR, LF or CRLF; empty lines are
+// ignored. Data in a character set other than ASCII or UTF-8 is converted
+// to UTF-8 first, in which case the Message no longer refers to it.
+func ParseMessage(data []byte) (*Message, error) {
+	m := new(Message)
+	if err := m.parse(data); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *Message) parse(data []byte) error {
+	data, err := decodeCharset(data)
+	if err != nil {
+		return err
+	}
+
+	return m.parseUTF8(data)
+}
+
+// parseUTF8 parses data, already converted to UTF-8, into m.
+func (m *Message) parseUTF8(data []byte) error {
+	if len(data) < 8 {
+		return &SyntaxError{
+			msg:    fmt.Sprintf("not enough bytes in header: expecting at least 8, got %d", len(data)),
+			Offset: len(data),
+		}
+	}
+	if string(data[:3]) != "MSH" {
+		return &SyntaxError{
+			msg:    fmt.Sprintf("expecting \"MSH\", got %q", string(data[:3])),
+			Offset: 0,
+		}
+	}
+
+	m.data = data
+	m.Delimiters = Delimiters{
+		Field:        data[3],
+		Component:    data[4],
+		Repetition:   data[5],
+		Escape:       data[6],
+		SubComponent: data[7],
+	}
+	m.Segments = slices.Grow(m.Segments[:0], bytes.Count(data, []byte("\r"))+1)
+
+	for off := 0; off < len(data); {
+		end := off + bytes.IndexAny(data[off:], "\r\n")
+		if end < off {
+			end = len(data)
+		}
+
+		if end > off {
+			seg, err := m.parseSegment(data[off:end], off)
+			if err != nil {
+				return err
+			}
+			m.Segments = append(m.Segments, seg)
+		}
+		off = end + 1
+	}
+
+	return nil
+}
+
+func (m *Message) parseSegment(raw []byte, offset int) (Segment, error) {
+	if len(raw) < 3 || (len(raw) > 3 && raw[3] != m.Delimiters.Field) {
+		return Segment{}, &SyntaxError{
+			msg:    fmt.Sprintf("invalid segment %q", string(raw[:min(len(raw), 8)])),
+			Offset: offset,
+		}
+	}
+
+	seg := Segment{Name: string(raw[:3]), Offset: offset, raw: raw}
+	seg.fields = make([]Field, 0, bytes.Count(raw, []byte{m.Delimiters.Field})+1)
+	seg.fields = append(seg.fields, Field{value{raw: raw[:3], delims: m.Delimiters, literal: true}})
+
+	rest := raw[3:]
+	if isHeaderSegment(seg.Name) {
+		// MSH-1 is the field separator itself and MSH-2 holds the other
+		// delimiters, so neither is split or unescaped.
+		seg.fields = append(seg.fields, Field{value{raw: raw[3:4], delims: m.Delimiters, literal: true}})
+		rest = raw[4:]
+		end := bytes.IndexByte(rest, m.Delimiters.Field)
+		if end < 0 {
+			end = len(rest)
+		}
+		seg.fields = append(seg.fields, Field{value{raw: rest[:end], delims: m.Delimiters, literal: true}})
+		rest = rest[end:]
+	}
+
+	for len(rest) > 0 {
+		rest = rest[1:] // the field separator
+		end := bytes.IndexByte(rest, m.Delimiters.Field)
+		if end < 0 {
+			end = len(rest)
+		}
+		seg.fields = append(seg.fields, Field{value{raw: rest[:end], delims: m.Delimiters}})
+		rest = rest[end:]
+	}
+
+	return seg, nil
+}
+
+// Bytes returns the text the message was parsed from.
+func (m *Message) Bytes() []byte {
+	return m.data
+}
+
+// Segment returns the first segment called name.
+func (m *Message) Segment(name string) (Segment, bool) {
+	for _, seg := range m.Segments {
+		if seg.Name == name {
+			return seg, true
+		}
+	}
+
+	return Segment{}, false
+}
+
+// All returns an iterator over the segments called name, in message order.
+func (m *Message) All(name string) iter.Seq[Segment] {
+	return func(yield func(Segment) bool) {
+		for _, seg := range m.Segments {
+			if seg.Name == name && !yield(seg) {
+				return
+			}
+		}
+	}
+}
+
+// A Segment is one segment of a Message.
+type Segment struct {
+	Name   string
+	Offset int // byte offset of the segment in the message
+
+	raw    []byte
+	fields []Field // fields[0] is the segment ID
+}
+
+// Bytes returns the text of the segment, without its terminator.
+func (s Segment) Bytes() []byte {
+	return s.raw
+}
+
+// NumFields returns the number of fields in the segment, not counting the
+// segment ID.
+func (s Segment) NumFields() int {
+	return max(len(s.fields)-1, 0)
+}
+
+// Field returns field n, counted from 1 as in HL7, so that Field(3) of a
+// PID segment is PID-3. Fields past the end of the segment are empty.
+func (s Segment) Field(n int) Field {
+	if n < 1 || n >= len(s.fields) {
+		return Field{}
+	}
+
+	return s.fields[n]
+}
+
+// Fields returns the fields of the segment, starting at field 1.
+func (s Segment) Fields() []Field {
+	if len(s.fields) == 0 {
+		return nil
+	}
+
+	return s.fields[1:]
+}
+
+// A Field is a field of a Segment, which may hold several repetitions.
+// Its component and subcomponent accessors refer to the first repetition.
+type Field struct{ value }
+
+// Repetitions returns each repetition of the field.
+func (f Field) Repetitions() []Repetition {
+	if f.IsEmpty() {
+		return nil
+	}
+
+	var reps []Repetition
+	for raw := range f.split(f.delims.Repetition) {
+		reps = append(reps, Repetition{f.child(raw)})
+	}
+
+	return reps
+}
+
+// Repetition returns repetition n, counted from 1.
+func (f Field) Repetition(n int) Repetition {
+	return Repetition{f.child(f.nth(f.delims.Repetition, n))}
+}
+
+// Component returns component n, counted from 1, of the first repetition.
+func (f Field) Component(n int) Component {
+	return f.Repetition(1).Component(n)
+}
+
+// Components returns the components of the first repetition.
+func (f Field) Components() []Component {
+	return f.Repetition(1).Components()
+}
+
+// A Repetition is one occurrence of a repeating Field.
+type Repetition struct{ value }
+
+// Component returns component n, counted from 1.
+func (r Repetition) Component(n int) Component {
+	return Component{r.child(r.nth(r.delims.Component, n))}
+}
+
+// Components returns each component of the repetition.
+func (r Repetition) Components() []Component {
+	if r.IsEmpty() {
+		return nil
+	}
+
+	var comps []Component
+	for raw := range r.split(r.delims.Component) {
+		comps = append(comps, Component{r.child(raw)})
+	}
+
+	return comps
+}
+
+// A Component is a component of a Repetition.
+type Component struct{ value }
+
+// SubComponent returns subcomponent n, counted from 1.
+func (c Component) SubComponent(n int) SubComponent {
+	return SubComponent{c.child(c.nth(c.delims.SubComponent, n))}
+}
+
+// SubComponents returns each subcomponent of the component.
+func (c Component) SubComponents() []SubComponent {
+	if c.IsEmpty() {
+		return nil
+	}
+
+	var subs []SubComponent
+	for raw := range c.split(c.delims.SubComponent) {
+		subs = append(subs, SubComponent{c.child(raw)})
+	}
+
+	return subs
+}
+
+// A SubComponent is a subcomponent of a Component.
+type SubComponent struct{ value }
+
+// value is the text shared by every level of the tree, with the typed
+// accessors that read it.
+type value struct {
+	raw     []byte
+	delims  Delimiters
+	literal bool // not split or unescaped: the segment ID, MSH-1 and MSH-2
+}
+
+// Bytes returns the raw text of the value, escape sequences included.
+func (v value) Bytes() []byte {
+	return v.raw
+}
+
+// IsEmpty reports whether the value is absent.
+func (v value) IsEmpty() bool {
+	return len(v.raw) == 0
+}
+
+// IsNull reports whether the value is the explicit null, "", which asks
+// the receiver to delete the value it holds.
+func (v value) IsNull() bool {
+	return !v.literal && string(v.raw) == nullText
+}
+
+// String returns the text of the value with its escape sequences decoded.
+func (v value) String() string {
+	if v.literal {
+		return string(v.raw)
+	}
+
+	s := scanner{
+		fldDelim: v.delims.Field,
+		comDelim: v.delims.Component,
+		repDelim: v.delims.Repetition,
+		escDelim: v.delims.Escape,
+		subDelim: v.delims.SubComponent,
+	}
+	return s.unescape(string(v.raw), false)
+}
+
+// Int parses the value as a base-10 integer.
+func (v value) Int() (int64, error) {
+	return strconv.ParseInt(string(bytes.TrimSpace(v.raw)), 10, 64)
+}
+
+// Float parses the value as a decimal number.
+func (v value) Float() (float64, error) {
+	return strconv.ParseFloat(string(bytes.TrimSpace(v.raw)), 64)
+}
+
+// Bool parses the value as an HL7 yes/no indicator (Y or N).
+func (v value) Bool() (bool, error) {
+	return parseBool(string(v.raw))
+}
+
+// Time parses the value as an HL7 timestamp. A timestamp without a UTC
+// offset is taken to be in UTC.
+func (v value) Time() (time.Time, error) {
+	return parseTS(string(v.raw), time.UTC)
+}
+
+func (v value) child(raw []byte) value {
+	return value{raw: raw, delims: v.delims, literal: v.literal}
+}
+
+// nth returns the n-th (1-based) member of v separated by delim.
+func (v value) nth(delim byte, n int) []byte {
+	if n < 1 {
+		return nil
+	}
+	if v.literal {
+		if n == 1 {
+			return v.raw
+		}
+		return nil
+	}
+
+	for raw := range v.split(delim) {
+		if n--; n == 0 {
+			return raw
+		}
+	}
+
+	return nil
+}
+
+// split returns an iterator over the members of v separated by delim.
+func (v value) split(delim byte) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		if v.literal {
+			yield(v.raw)
+			return
+		}
+
+		rest := v.raw
+		for {
+			i := bytes.IndexByte(rest, delim)
+			if i < 0 {
+				yield(rest)
+				return
+			}
+			if !yield(rest[:i]) {
+				return
+			}
+			rest = rest[i+1:]
+		}
+	}
+}
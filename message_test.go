@@ -0,0 +1,147 @@
+package hl7
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMessage(t *testing.T) {
+	data := []byte("MSH|^~\\&|App|Fac|||20250404152530||ORU^R01|42|P|2.3\r" +
+		"PID|1||V1~MRN7^^^HOSP&1.2.3&ISO||DOE^JANE\\S\\ANN\r\n" +
+		"\n" +
+		"OBX|1|NM|GLU||5.4|mmol/L\r" +
+		"OBX|2|ST|NOTE||a\\T\\b\r")
+
+	m, err := ParseMessage(data)
+	require.NoError(t, err)
+	require.Equal(t, Delimiters{'|', '^', '~', '\\', '&'}, m.Delimiters)
+
+	var names []string
+	for _, seg := range m.Segments {
+		names = append(names, seg.Name)
+	}
+	require.Equal(t, []string{"MSH", "PID", "OBX", "OBX"}, names)
+	require.Equal(t, bytes.Index(data, []byte("PID")), m.Segments[1].Offset)
+
+	msh, ok := m.Segment("MSH")
+	require.True(t, ok)
+	require.Equal(t, "|", msh.Field(1).String())
+	require.Equal(t, "^~\\&", msh.Field(2).String())
+	require.Equal(t, "^~\\&", msh.Field(2).Component(1).String())
+	require.Equal(t, "App", msh.Field(3).String())
+	require.Equal(t, "R01", msh.Field(9).Component(2).String())
+	require.Equal(t, 12, msh.NumFields())
+	ts, err := msh.Field(7).Time()
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 4, 4, 15, 25, 30, 0, time.UTC), ts)
+
+	pid, _ := m.Segment("PID")
+	require.Len(t, pid.Field(3).Repetitions(), 2)
+	require.Equal(t, "V1", pid.Field(3).Repetition(1).String())
+	require.Equal(t, "V1~MRN7^^^HOSP&1.2.3&ISO", pid.Field(3).String())
+	require.Equal(t, "MRN7", pid.Field(3).Repetition(2).Component(1).String())
+	require.Equal(t, "1.2.3", pid.Field(3).Repetition(2).Component(4).SubComponent(2).String())
+	require.Len(t, pid.Field(3).Repetition(2).Component(4).SubComponents(), 3)
+	require.Equal(t, "JANE^ANN", pid.Field(5).Component(2).String())
+	require.Equal(t, []byte("JANE\\S\\ANN"), pid.Field(5).Component(2).Bytes())
+	require.True(t, pid.Field(2).IsEmpty())
+	require.True(t, pid.Field(30).IsEmpty())
+	require.True(t, pid.Field(3).Repetition(3).IsEmpty())
+
+	var obx []Segment
+	for seg := range m.All("OBX") {
+		obx = append(obx, seg)
+	}
+	require.Len(t, obx, 2)
+	v, err := obx[0].Field(5).Float()
+	require.NoError(t, err)
+	require.Equal(t, 5.4, v)
+	n, err := obx[1].Field(1).Int()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.Equal(t, "a&b", obx[1].Field(5).String())
+
+	// values are views into the input, not copies
+	data[bytes.Index(data, []byte("App"))] = 'X'
+	require.Equal(t, "Xpp", msh.Field(3).String())
+}
+
+func TestParseMessage_Error(t *testing.T) {
+	var syntaxErr *SyntaxError
+
+	_, err := ParseMessage([]byte("PID|1||V1\r"))
+	require.ErrorAs(t, err, &syntaxErr)
+
+	data := []byte("MSH|^~\\&|App\rPID1\r")
+	_, err = ParseMessage(data)
+	require.ErrorAs(t, err, &syntaxErr)
+	require.Equal(t, bytes.Index(data, []byte("PID1")), syntaxErr.Offset)
+}
+
+func TestUnmarshal_Message(t *testing.T) {
+	var m Message
+	require.NoError(t, Unmarshal(oruMsg, &m))
+	require.Len(t, m.Segments, len(bytes.Split(bytes.TrimRight(oruMsg, "\r"), []byte("\r"))))
+
+	input := "MSH|^~\\&|App1\rPID|1||V1\rMSH|^~\\&|App2\rPID|1||V2\r"
+	dec := NewDecoder(strings.NewReader(input))
+
+	var first, second Message
+	require.NoError(t, dec.Decode(&first))
+	require.NoError(t, dec.Decode(&second))
+	require.Equal(t, "App1", first.Segments[0].Field(3).String())
+	require.Equal(t, "App2", second.Segments[0].Field(3).String())
+}
+
+func TestUnmarshal_MessageOptions(t *testing.T) {
+	sloppy := "\xef\xbb\xbf\nMSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3\npid|1||V1\n\n"
+
+	var m Message
+	require.Error(t, Unmarshal([]byte(sloppy), &m))
+	require.NoError(t, UnmarshalOptions{Lenient: true}.Unmarshal([]byte(sloppy), &m))
+	require.Equal(t, []string{"MSH", "PID"}, []string{m.Segments[0].Name, m.Segments[1].Name})
+	require.Equal(t, "V1", m.Segments[1].Field(3).String())
+
+	in := []byte("MSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3\rPid|1||V1\r")
+	require.NoError(t, Unmarshal(in, &m))
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, UnmarshalOptions{Strict: true}.Unmarshal(in, &m), &syntaxErr)
+
+	var lengthErr *FieldLengthError
+	require.ErrorAs(t, UnmarshalOptions{MaxFieldLength: 2}.Unmarshal(in, &m), &lengthErr)
+	require.Equal(t, "MSH-3", lengthErr.Location.String())
+
+	// text in another character set is converted once
+	latin := []byte("MSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3||||||8859/1\rPID|1||V1||M\xdcLLER\r")
+	require.NoError(t, UnmarshalOptions{Lenient: true}.Unmarshal(latin, &m))
+	require.Equal(t, "MÜLLER", m.Segments[1].Field(5).String())
+
+	// the options apply to a Message from Parse or a Decoder too
+	v, err := UnmarshalOptions{Lenient: true}.Parse([]byte(strings.Replace(sloppy, "ADT^A08", "ZZZ^Z01", 1)))
+	require.NoError(t, err)
+	require.IsType(t, &Message{}, v)
+	dec := NewDecoder(bytes.NewReader(in))
+	dec.SetOptions(UnmarshalOptions{Strict: true})
+	require.ErrorAs(t, dec.Decode(&m), &syntaxErr)
+
+	// a Message holds one message
+	two := "MSH|^~\\&|App1\rPID|1||V1\rMSH|^~\\&|App2\r"
+	require.ErrorContains(t, Unmarshal([]byte(two), &m), "more than one MSH segment")
+	require.ErrorContains(t, UnmarshalOptions{Strict: true}.Unmarshal([]byte(two), &m), "more than one MSH segment")
+}
+
+func BenchmarkParseMessage_ORU(b *testing.B) {
+	var m Message
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(oruMultipleOrdersMsg)))
+	for b.Loop() {
+		if err := m.parse(oruMultipleOrdersMsg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
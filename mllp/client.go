@@ -0,0 +1,382 @@
+package mllp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/s-hammon/hl7"
+)
+
+// An AckCode is the acknowledgment code of MSA-1.
+type AckCode = hl7.AckCode
+
+const (
+	ApplicationAccept = hl7.ApplicationAccept
+	ApplicationError  = hl7.ApplicationError
+	ApplicationReject = hl7.ApplicationReject
+	CommitAccept      = hl7.CommitAccept
+	CommitError       = hl7.CommitError
+	CommitReject      = hl7.CommitReject
+)
+
+// An Ack is the acknowledgment a Client received for a message.
+type Ack struct {
+	Code      AckCode // MSA-1
+	ControlId string  // MSA-2, the MSH-10 of the message acknowledged
+	Text      string  // MSA-3
+
+	// Message is the whole acknowledgment, for its ERR segments and
+	// anything else the receiver sent.
+	Message *hl7.Message
+}
+
+// Err returns an *AckError if the message was not accepted, and nil if it
+// was.
+func (a *Ack) Err() error {
+	if a.Code.Accepted() {
+		return nil
+	}
+
+	return &AckError{Ack: a}
+}
+
+// An AckError reports a message that the receiver did not accept.
+type AckError struct {
+	Ack *Ack
+}
+
+func (e *AckError) Error() string {
+	s := "mllp: message " + e.Ack.ControlId + " not accepted: " + string(e.Ack.Code)
+	if e.Ack.Text != "" {
+		s += ": " + e.Ack.Text
+	}
+
+	return s
+}
+
+// ErrClientClosed is returned by Send after the Client is closed.
+var ErrClientClosed = errors.New("mllp: Client closed")
+
+// A Client sends messages over MLLP and waits for their acknowledgments.
+// It keeps its connections open between messages and sends on up to
+// MaxConns of them at once, so that one Client may be used by several
+// goroutines. The zero Client, given an Addr, is ready to use; its fields
+// must not be changed once it has sent a message.
+type Client struct {
+	Addr string // TCP address of the receiver
+
+	// DialContext, if set, opens the connections to Addr in place of a
+	// net.Dialer.
+	DialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// TLSConfig, if set, makes the connections use TLS. ServerName, if
+	// empty, is taken from Addr. Certificates or GetClientCertificate give
+	// the certificate to present to a receiver that asks for one; a
+	// CertReloader takes up renewed certificates without a restart.
+	TLSConfig *tls.Config
+
+	// MaxConns is the number of connections messages are sent on at once.
+	// It defaults to 1, which keeps messages in the order they are sent.
+	MaxConns int
+
+	// MaxRetries is the number of times a message is sent again after the
+	// connection it was sent on fails, waiting Backoff before the first
+	// retry and twice as long before each one after it, up to MaxBackoff.
+	// Backoff defaults to 100ms and MaxBackoff to 10s.
+	MaxRetries int
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// MaxFrameSize is the longest acknowledgment accepted, in bytes of
+	// content. It defaults to DefaultMaxFrameSize.
+	MaxFrameSize int
+
+	mu     sync.Mutex
+	idle   []*clientConn
+	slots  chan struct{}
+	closed bool
+}
+
+// Send sends msg and returns the acknowledgment whose MSA-2 matches its
+// MSH-10, skipping any other replies, such as late ones to messages whose
+// Send gave up on them. An acknowledgment that does not accept the message
+// is returned without an error; use Ack.Err to treat it as one.
+//
+// The deadline and cancellation of ctx apply to the whole exchange,
+// retries included. A connection whose Send was interrupted is closed
+// rather than reused, since its acknowledgment may still be on the way.
+func (c *Client) Send(ctx context.Context, msg []byte) (*Ack, error) {
+	id := header(msg).Field(10).String()
+	if id == "" {
+		return nil, errors.New("mllp: message has no control ID (MSH-10)")
+	}
+
+	delay := c.Backoff
+	if delay <= 0 {
+		delay = 100 * time.Millisecond
+	}
+	maxDelay := c.MaxBackoff
+	if maxDelay <= 0 {
+		maxDelay = 10 * time.Second
+	}
+
+	for retry := 0; ; retry++ {
+		ack, err := c.send(ctx, msg, id)
+		var ce *connError
+		if err == nil || !errors.As(err, &ce) || retry >= c.MaxRetries {
+			return ack, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		delay = min(delay*2, maxDelay)
+	}
+}
+
+// send sends msg on a connection from the pool. A pooled connection that
+// turns out to have been closed by the receiver is replaced at once.
+func (c *Client) send(ctx context.Context, msg []byte, id string) (*Ack, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+
+	cc, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ack, err := cc.roundTrip(ctx, msg, id, c.MaxFrameSize)
+	var ce *connError
+	if cc.reused && errors.As(err, &ce) {
+		cc.close()
+		if cc, err = c.dial(ctx); err != nil {
+			return nil, err
+		}
+		ack, err = cc.roundTrip(ctx, msg, id, c.MaxFrameSize)
+	}
+
+	if err != nil || cc.broken {
+		cc.close()
+	} else {
+		c.putIdle(cc)
+	}
+
+	return ack, err
+}
+
+// Close closes the idle connections of c and makes later calls to Send
+// fail. Connections in use are closed once their Send returns.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	for _, cc := range c.idle {
+		cc.close()
+	}
+	c.idle = nil
+
+	return nil
+}
+
+func (c *Client) acquire(ctx context.Context) error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return ErrClientClosed
+	}
+	if c.slots == nil {
+		c.slots = make(chan struct{}, max(c.MaxConns, 1))
+	}
+	slots := c.slots
+	c.mu.Unlock()
+
+	select {
+	case slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) release() {
+	<-c.slots
+}
+
+// conn returns an idle connection, or a new one if there is none.
+func (c *Client) conn(ctx context.Context) (*clientConn, error) {
+	c.mu.Lock()
+	if n := len(c.idle); n > 0 {
+		cc := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		cc.reused = true
+		return cc, nil
+	}
+	c.mu.Unlock()
+
+	return c.dial(ctx)
+}
+
+func (c *Client) dial(ctx context.Context) (*clientConn, error) {
+	dial := c.DialContext
+	if dial == nil {
+		var d net.Dialer
+		dial = d.DialContext
+	}
+
+	rwc, err := dial(ctx, "tcp", c.Addr)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, &connError{err}
+	}
+
+	if c.TLSConfig != nil {
+		cfg := c.TLSConfig
+		if cfg.ServerName == "" {
+			cfg = cfg.Clone()
+			cfg.ServerName, _, _ = net.SplitHostPort(c.Addr)
+		}
+		tc := tls.Client(rwc, cfg)
+		if err := tc.HandshakeContext(ctx); err != nil {
+			rwc.Close()
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// a certificate that is not trusted will not be on retrying
+			var opErr *net.OpError
+			if errors.Is(err, io.EOF) || errors.As(err, &opErr) && !tlsAlert(err) {
+				return nil, &connError{err}
+			}
+			return nil, err
+		}
+		rwc = tc
+	}
+
+	return &clientConn{rwc: rwc, r: bufio.NewReader(rwc)}, nil
+}
+
+func (c *Client) putIdle(cc *clientConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		cc.close()
+		return
+	}
+	c.idle = append(c.idle, cc)
+}
+
+// A connError is the failure of a connection, after which a message may
+// be sent again on another.
+type connError struct {
+	err error
+}
+
+func (e *connError) Error() string { return "mllp: " + e.err.Error() }
+func (e *connError) Unwrap() error { return e.err }
+
+// A clientConn is a connection of a Client.
+type clientConn struct {
+	rwc    net.Conn
+	r      *bufio.Reader
+	buf    []byte
+	reused bool // taken from the idle connections
+	broken bool // not to be reused
+}
+
+// roundTrip sends msg and reads replies until the one acknowledging id.
+func (cc *clientConn) roundTrip(ctx context.Context, msg []byte, id string, maxFrame int) (*Ack, error) {
+	deadline, _ := ctx.Deadline()
+	cc.rwc.SetDeadline(deadline)
+	stop := context.AfterFunc(ctx, func() {
+		// interrupt the read or write in progress
+		cc.rwc.SetDeadline(time.Unix(1, 0))
+	})
+	defer func() {
+		if !stop() {
+			cc.broken = true
+		}
+	}()
+
+	if err := writeFrame(cc.rwc, msg); err != nil {
+		return nil, cc.fail(ctx, err)
+	}
+	for {
+		buf, err := readFrame(cc.r, cc.buf[:0], maxFrame)
+		if err != nil {
+			return nil, cc.fail(ctx, err)
+		}
+		cc.buf = buf
+
+		ack, err := parseAck(buf)
+		if err != nil {
+			return nil, err
+		}
+		if ack.ControlId == id {
+			return ack, nil
+		}
+	}
+}
+
+// fail returns the error to report for err, which ended an exchange on
+// cc.
+func (cc *clientConn) fail(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	var ne net.Error
+	if _, ok := ctx.Deadline(); ok && errors.As(err, &ne) && ne.Timeout() {
+		// the deadline of ctx, reached before ctx noticed
+		return context.DeadlineExceeded
+	}
+	if err == ErrFrameTooLarge || err == ErrBadFrame || tlsAlert(err) {
+		return err
+	}
+
+	return &connError{err}
+}
+
+// tlsAlert reports whether err is an alert from the TLS peer, such as one
+// refusing the certificate presented, which another connection would get
+// as well.
+func tlsAlert(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "remote error"
+}
+
+func (cc *clientConn) close() {
+	cc.rwc.Close()
+}
+
+// parseAck parses reply, a copy of which the Ack keeps.
+func parseAck(reply []byte) (*Ack, error) {
+	m, err := hl7.ParseMessage(bytes.Clone(reply))
+	if err != nil {
+		return nil, fmt.Errorf("mllp: parsing acknowledgment: %w", err)
+	}
+	msa, ok := m.Segment("MSA")
+	if !ok {
+		return nil, errors.New("mllp: acknowledgment has no MSA segment")
+	}
+
+	return &Ack{
+		Code:      AckCode(msa.Field(1).String()),
+		ControlId: msa.Field(2).String(),
+		Text:      msa.Field(3).String(),
+		Message:   m,
+	}, nil
+}
@@ -0,0 +1,201 @@
+package mllp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// ack returns an acknowledgment of the message in f.
+func ack(f *Frame, code AckCode, text string) []byte {
+	return fmt.Appendf(nil, "MSH|^~\\&|Rcv|RcvFac|Lab|LabFac|||ACK|A%s|P|2.3\rMSA|%s|%s|%s\r",
+		f.MSH.Field(10).String(), code, f.MSH.Field(10).String(), text)
+}
+
+func message(id string) []byte {
+	return []byte(strings.Replace(testMessage, "|42|", "|"+id+"|", 1))
+}
+
+func TestClient_Send(t *testing.T) {
+	var remotes sync.Map
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		remotes.Store(f.RemoteAddr.String(), true)
+		if f.MSH.Field(10).String() == "2" {
+			return ack(f, ApplicationReject, "unknown patient"), nil
+		}
+		return ack(f, ApplicationAccept, ""), nil
+	})}
+	addr, _ := startServer(t, s)
+
+	c := &Client{Addr: addr}
+	defer c.Close()
+
+	a, err := c.Send(context.Background(), message("1"))
+	require.NoError(t, err)
+	require.Equal(t, ApplicationAccept, a.Code)
+	require.Equal(t, "1", a.ControlId)
+	require.NoError(t, a.Err())
+	msh, _ := a.Message.Segment("MSH")
+	require.Equal(t, "A1", msh.Field(10).String())
+
+	a, err = c.Send(context.Background(), message("2"))
+	require.NoError(t, err)
+	require.Equal(t, ApplicationReject, a.Code)
+	require.Equal(t, "unknown patient", a.Text)
+	var ackErr *AckError
+	require.ErrorAs(t, a.Err(), &ackErr)
+	require.Equal(t, "mllp: message 2 not accepted: AR: unknown patient", ackErr.Error())
+
+	// both messages went over the one connection
+	n := 0
+	remotes.Range(func(any, any) bool { n++; return true })
+	require.Equal(t, 1, n)
+
+	_, err = c.Send(context.Background(), []byte("MSH|^~\\&|Lab\r"))
+	require.ErrorContains(t, err, "no control ID")
+
+	require.NoError(t, c.Close())
+	_, err = c.Send(context.Background(), message("3"))
+	require.ErrorIs(t, err, ErrClientClosed)
+}
+
+func TestClient_Correlation(t *testing.T) {
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		// a late reply to another message comes first
+		stale := strings.Replace(string(ack(f, ApplicationAccept, "")), "MSA|AA|"+f.MSH.Field(10).String(), "MSA|AA|old", 1)
+		return append([]byte(stale+"\x1c\r\x0b"), ack(f, CommitAccept, "")...), nil
+	})}
+	addr, _ := startServer(t, s)
+
+	c := &Client{Addr: addr}
+	defer c.Close()
+	a, err := c.Send(context.Background(), message("7"))
+	require.NoError(t, err)
+	require.Equal(t, CommitAccept, a.Code)
+	require.Equal(t, "7", a.ControlId)
+}
+
+func TestClient_Timeout(t *testing.T) {
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		if f.MSH.Field(10).String() == "slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		return ack(f, ApplicationAccept, ""), nil
+	})}
+	addr, _ := startServer(t, s)
+
+	c := &Client{Addr: addr, MaxRetries: 3}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.Send(ctx, message("slow"))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err = c.Send(ctx, message("slow"))
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the interrupted connections were dropped
+	a, err := c.Send(context.Background(), message("1"))
+	require.NoError(t, err)
+	require.Equal(t, "1", a.ControlId)
+}
+
+func TestClient_Retries(t *testing.T) {
+	var calls atomic.Int32
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		if calls.Add(1) <= 2 {
+			return nil, io.ErrUnexpectedEOF // drop the connection
+		}
+		return ack(f, ApplicationAccept, ""), nil
+	})}
+	addr, _ := startServer(t, s)
+
+	c := &Client{Addr: addr, MaxRetries: 1, Backoff: time.Millisecond}
+	defer c.Close()
+	_, err := c.Send(context.Background(), message("1"))
+	require.ErrorIs(t, err, io.EOF)
+	require.EqualValues(t, 2, calls.Load())
+
+	calls.Store(0)
+	c.MaxRetries = 2
+	a, err := c.Send(context.Background(), message("1"))
+	require.NoError(t, err)
+	require.Equal(t, ApplicationAccept, a.Code)
+	require.EqualValues(t, 3, calls.Load())
+
+	// nothing listening
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	l.Close()
+	c = &Client{Addr: l.Addr().String(), MaxRetries: 2, Backoff: 10 * time.Millisecond}
+	start := time.Now()
+	_, err = c.Send(context.Background(), message("1"))
+	var opErr *net.OpError
+	require.ErrorAs(t, err, &opErr)
+	require.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+}
+
+func TestClient_Reconnect(t *testing.T) {
+	s := &Server{
+		Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+			return ack(f, ApplicationAccept, ""), nil
+		}),
+		IdleTimeout: 20 * time.Millisecond,
+	}
+	addr, _ := startServer(t, s)
+
+	c := &Client{Addr: addr}
+	defer c.Close()
+	for i := range 3 {
+		a, err := c.Send(context.Background(), message(strconv.Itoa(i)))
+		require.NoError(t, err)
+		require.Equal(t, strconv.Itoa(i), a.ControlId)
+		// the receiver closes the connection in the meantime
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestClient_Pool(t *testing.T) {
+	var active, peak atomic.Int32
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return ack(f, ApplicationAccept, ""), nil
+	})}
+	addr, _ := startServer(t, s)
+
+	c := &Client{Addr: addr, MaxConns: 3}
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := range 9 {
+		wg.Go(func() {
+			id := strconv.Itoa(i)
+			a, err := c.Send(context.Background(), message(id))
+			require.NoError(t, err)
+			require.Equal(t, id, a.ControlId)
+		})
+	}
+	wg.Wait()
+	require.EqualValues(t, 3, peak.Load())
+	require.Len(t, c.idle, 3)
+}
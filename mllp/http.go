@@ -0,0 +1,145 @@
+package mllp
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/netip"
+
+	"github.com/s-hammon/hl7"
+)
+
+// ER7MediaType is the media type of HL7 v2 messages in their usual,
+// pipe-delimited encoding.
+const ER7MediaType = "x-application/hl7-v2+er7"
+
+// An HTTPHandler receives HL7 messages POSTed over HTTP and passes each one
+// to Handler, the same way a Server does for messages received over MLLP,
+// answering with the reply in the response body. The body is the message
+// in ER7, with segments terminated by CR, LF or CRLF, and its content type
+// ER7MediaType or text/plain; the reply is sent with the same content
+// type.
+//
+// The status of the response tells whether the message was received, not
+// whether it was accepted, which is for the acknowledgment to say: 200 with
+// the reply, 204 if Handler returned none, 400 for a body that is not an
+// HL7 message, 413 for one longer than MaxBodySize, 415 for another content
+// type, and 500 if Handler returned an error. A 400 or 500 response carries
+// an AR or AE acknowledgment, with the problem in its ERR segment, if the
+// MSH of the message can be read.
+type HTTPHandler struct {
+	Handler Handler
+
+	// MaxBodySize is the longest body accepted, in bytes. It defaults to
+	// DefaultMaxFrameSize.
+	MaxBodySize int64
+
+	// Batches accepts bodies holding several messages, as a batch file with
+	// or without its FHS, BHS, BTS and FTS segments. The messages are
+	// handled in order and the replies sent back as one batch, while a
+	// body of one message is still answered with the bare reply. A message
+	// Handler returns an error for is answered in the batch with an AE
+	// acknowledgment, and the messages after it are still handled, so that
+	// a sender can tell which ones to send again. A batch whose BTS-1 or
+	// FTS-1 count does not match the messages in it is turned away whole.
+	Batches bool
+}
+
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || (mediaType != ER7MediaType && mediaType != "text/plain") {
+		http.Error(w, "content type must be "+ER7MediaType+" or text/plain", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	limit := h.MaxBodySize
+	if limit <= 0 {
+		limit = DefaultMaxFrameSize
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "message too large", http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, "reading message: "+err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	f, err := hl7.ParseFile(body)
+	if err != nil {
+		reject(w, body, mediaType, hl7.ApplicationReject, http.StatusBadRequest, err)
+		return
+	}
+	batch := len(f.Batches) != 1 || len(f.Batches[0].Messages) != 1 ||
+		f.Header != nil || f.Trailer != nil || f.Batches[0].Header != nil || f.Batches[0].Trailer != nil
+	if batch && !h.Batches {
+		reject(w, body, mediaType, hl7.ApplicationReject, http.StatusBadRequest, errors.New("body must hold one message"))
+		return
+	}
+
+	var remote net.Addr
+	if ap, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		remote = net.TCPAddrFromAddrPort(ap)
+	}
+
+	var replies [][]byte
+	for msg := range f.Messages() {
+		frame := &Frame{Data: msg, MSH: header(msg), RemoteAddr: remote, TLS: r.TLS}
+		reply, err := h.Handler.ServeMLLP(r.Context(), frame)
+		if err != nil && !batch {
+			reject(w, msg, mediaType, hl7.ApplicationError, http.StatusInternalServerError, err)
+			return
+		}
+		if err != nil {
+			// the messages before it have been handled, so the batch is
+			// answered message by message rather than failed whole
+			reply, _ = hl7.NewACK(msg, hl7.ApplicationError, hl7.ACKOptions{Err: err})
+		}
+		if reply != nil {
+			replies = append(replies, reply)
+		}
+	}
+
+	var out []byte
+	switch {
+	case len(replies) == 0:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case batch:
+		out, err = (&hl7.File{Batches: []hl7.Batch{{Messages: replies}}}).Marshal()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	default:
+		out = replies[0]
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(out)
+}
+
+// reject answers the message msg, which could not be received, with the
+// acknowledgment code and err, or with err as plain text if the header of
+// msg cannot be read or asks for no such acknowledgment.
+func reject(w http.ResponseWriter, msg []byte, mediaType string, code hl7.AckCode, status int, err error) {
+	ack, ackErr := hl7.NewACK(msg, code, hl7.ACKOptions{Err: err})
+	if ackErr != nil || ack == nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(status)
+	w.Write(ack)
+}
@@ -0,0 +1,140 @@
+package mllp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/s-hammon/hl7"
+	"github.com/stretchr/testify/require"
+)
+
+// acknowledge answers each message with an ACK, or none for control ID
+// "quiet", and fails for control ID "fail".
+var acknowledge = HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+	switch f.MSH.Field(10).String() {
+	case "quiet":
+		return nil, nil
+	case "fail":
+		return nil, errors.New("store unavailable")
+	}
+
+	return hl7.NewACK(f.Data, hl7.ApplicationAccept, hl7.ACKOptions{
+		ControlId: "A" + f.MSH.Field(10).String(),
+		Time:      time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+})
+
+func post(h http.Handler, contentType, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/hl7", strings.NewReader(body))
+	if contentType != "" {
+		r.Header.Set("Content-Type", contentType)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestHTTPHandler(t *testing.T) {
+	var remote string
+	h := &HTTPHandler{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		remote = f.RemoteAddr.String()
+		return acknowledge(ctx, f)
+	})}
+
+	body := strings.ReplaceAll(testMessage, "\r", "\r\n")
+	w := post(h, ER7MediaType, body)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, ER7MediaType, w.Header().Get("Content-Type"))
+	require.Equal(t, "MSH|^~\\&|||Lab|LabFac|20250102030405||ACK^R01|A42|P|2.3\rMSA|AA|42\r", w.Body.String())
+	require.Equal(t, "192.0.2.1:1234", remote)
+
+	w = post(h, "text/plain; charset=utf-8", testMessage)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	require.Contains(t, w.Body.String(), "\rMSA|AA|42\r")
+}
+
+func TestHTTPHandler_Status(t *testing.T) {
+	h := &HTTPHandler{Handler: acknowledge, MaxBodySize: int64(len(testMessage) + 4)}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        int
+		wantMSA     string // MSA of the acknowledgment in the body, if any
+	}{
+		{"no reply", ER7MediaType, strings.Replace(testMessage, "|42|", "|quiet|", 1), http.StatusNoContent, ""},
+		{"handler error", ER7MediaType, strings.Replace(testMessage, "|42|", "|fail|", 1), http.StatusInternalServerError, "MSA|AE|fail|store unavailable"},
+		{"not HL7", ER7MediaType, "hello", http.StatusBadRequest, ""},
+		{"empty", ER7MediaType, "", http.StatusBadRequest, ""},
+		{"after FTS", ER7MediaType, "MSH|^~\\&|A||||||ORU^R01|1\rFTS|1\rPID|1\r", http.StatusBadRequest, "MSA|AR|1|hl7: segment PID after FTS"},
+		{"two messages", ER7MediaType, "MSH|^~\\&|A||||||ORU^R01|1\rMSH|^~\\&|B\r", http.StatusBadRequest, "MSA|AR|1|body must hold one message"},
+		{"batch", ER7MediaType, "BHS|^~\\&\rMSH|^~\\&|A\r", http.StatusBadRequest, ""},
+		{"too large", ER7MediaType, testMessage + "NTE|1\r", http.StatusRequestEntityTooLarge, ""},
+		{"JSON", "application/json", testMessage, http.StatusUnsupportedMediaType, ""},
+		{"no content type", "", testMessage, http.StatusUnsupportedMediaType, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := post(h, tt.contentType, tt.body)
+			require.Equal(t, tt.want, w.Code, w.Body.String())
+			if tt.wantMSA == "" {
+				require.NotContains(t, w.Body.String(), "MSA|")
+				return
+			}
+			require.Equal(t, ER7MediaType, w.Header().Get("Content-Type"))
+			require.Contains(t, w.Body.String(), "\r"+tt.wantMSA)
+			require.Contains(t, w.Body.String(), "\rERR|")
+		})
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/hl7", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	require.Equal(t, http.MethodPost, w.Header().Get("Allow"))
+}
+
+func TestHTTPHandler_Batches(t *testing.T) {
+	h := &HTTPHandler{Handler: acknowledge, Batches: true}
+
+	body := "FHS|^~\\&\n" +
+		"BHS|^~\\&\n" +
+		"MSH|^~\\&|Lab|LabFac|||||ORU^R01|1|P|2.3\nPID|1||V1\n" +
+		"MSH|^~\\&|Lab|LabFac|||||ORU^R01|quiet|P|2.3\nPID|1||V2\n" +
+		"MSH|^~\\&|Lab|LabFac|||||ORU^R01|fail|P|2.3\nPID|1||V3\n" +
+		"MSH|^~\\&|Lab|LabFac|||||ORU^R01|4|P|2.3\nPID|1||V4\n" +
+		"BTS|4\n" +
+		"FTS|1\n"
+	w := post(h, ER7MediaType, body)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	f, err := hl7.ParseFile(w.Body.Bytes())
+	require.NoError(t, err)
+	require.Len(t, f.Batches, 1)
+	// a message the handler failed on does not fail those around it
+	var results []string
+	for msg := range f.Messages() {
+		m, err := hl7.ParseMessage(msg)
+		require.NoError(t, err)
+		msa, _ := m.Segment("MSA")
+		results = append(results, msa.Field(2).String()+" "+msa.Field(1).String())
+	}
+	require.Equal(t, []string{"1 AA", "fail AE", "4 AA"}, results)
+
+	// a single message is answered as one
+	w = post(h, ER7MediaType, testMessage)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, strings.HasPrefix(w.Body.String(), "MSH|"))
+
+	w = post(h, ER7MediaType, strings.Replace(body, "BTS|4", "BTS|2", 1))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "BTS-1 counts 2 messages, found 4")
+}
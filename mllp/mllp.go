@@ -0,0 +1,101 @@
+// Package mllp carries HL7 messages over TCP in the Minimal Lower Layer
+// Protocol, which frames each message between a start block byte (0x0B)
+// and an end block byte (0x1C) followed by a carriage return:
+//
+//	<VT> message <FS><CR>
+//
+// The receiver of a message normally answers it with an acknowledgement
+// in a frame of its own on the same connection. Servers and Clients given
+// a tls.Config carry the frames over TLS instead of plain TCP.
+package mllp
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+)
+
+const (
+	startBlock     byte = 0x0B // VT
+	endBlock       byte = 0x1C // FS
+	carriageReturn byte = 0x0D // CR
+)
+
+// DefaultMaxFrameSize is the largest frame, in bytes of content, that is
+// read when no other limit is set.
+const DefaultMaxFrameSize = 1 << 20
+
+var (
+	// ErrFrameTooLarge is returned for a frame longer than the limit set on
+	// its reader.
+	ErrFrameTooLarge = errors.New("mllp: frame too large")
+
+	// ErrBadFrame is returned for input that is not a well-formed frame:
+	// anything but whitespace between frames, or an end block byte that is
+	// not followed by a carriage return.
+	ErrBadFrame = errors.New("mllp: malformed frame")
+)
+
+// readFrame reads the next frame from r, appending its content to buf,
+// and returns the extended buffer. Whitespace before the start block is
+// skipped. It returns io.EOF if r ends between frames and
+// io.ErrUnexpectedEOF if it ends within one.
+func readFrame(r *bufio.Reader, buf []byte, max int) ([]byte, error) {
+	if max <= 0 {
+		max = DefaultMaxFrameSize
+	}
+
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return buf, err
+		}
+		if b == startBlock {
+			break
+		}
+		if b != ' ' && b != '\t' && b != '\r' && b != '\n' {
+			return buf, ErrBadFrame
+		}
+	}
+
+	start := len(buf)
+	for {
+		chunk, err := r.ReadSlice(endBlock)
+		if len(buf)-start+len(chunk) > max+1 {
+			return buf, ErrFrameTooLarge
+		}
+		buf = append(buf, chunk...)
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF {
+			return buf, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return buf, err
+		}
+		break
+	}
+	buf = buf[:len(buf)-1] // the end block
+
+	b, err := r.ReadByte()
+	if err == io.EOF {
+		return buf, io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return buf, err
+	}
+	if b != carriageReturn {
+		return buf, ErrBadFrame
+	}
+
+	return buf, nil
+}
+
+// writeFrame writes data to w as one frame.
+func writeFrame(w io.Writer, data []byte) error {
+	bufs := net.Buffers{{startBlock}, data, {endBlock, carriageReturn}}
+	_, err := bufs.WriteTo(w)
+	return err
+}
@@ -0,0 +1,70 @@
+package mllp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFrame(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		max   int
+		want  []string
+		err   error
+	}{
+		{"one", "\x0bMSH|^~\\&\rPID|1\r\x1c\r", 0, []string{"MSH|^~\\&\rPID|1\r"}, io.EOF},
+		{"several", "\x0bA\x1c\r\r\n\x0bB\x1c\r \x0b\x1c\r", 0, []string{"A", "B", ""}, io.EOF},
+		{"at the limit", "\x0bABCD\x1c\r", 4, []string{"ABCD"}, io.EOF},
+		{"too large", "\x0bABCDE\x1c\r", 4, nil, ErrFrameTooLarge},
+		{"no start block", "MSH|^~\\&\x1c\r", 0, nil, ErrBadFrame},
+		{"no carriage return", "\x0bA\x1c\n", 0, nil, ErrBadFrame},
+		{"cut short", "\x0bA\x1c\r\x0bMSH|", 0, []string{"A"}, io.ErrUnexpectedEOF},
+		{"cut after end block", "\x0bA\x1c", 0, nil, io.ErrUnexpectedEOF},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a small buffer makes frames span several reads
+			r := bufio.NewReaderSize(strings.NewReader(tt.input), 16)
+			var got []string
+			for {
+				frame, err := readFrame(r, nil, tt.max)
+				if err != nil {
+					require.ErrorIs(t, err, tt.err)
+					break
+				}
+				got = append(got, string(frame))
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestReadFrame_Large(t *testing.T) {
+	data := bytes.Repeat([]byte("OBX|1|TX|||text\r"), 1000)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeFrame(&buf, data))
+	require.NoError(t, writeFrame(&buf, data[:10]))
+
+	r := bufio.NewReaderSize(&buf, 16)
+	frame, err := readFrame(r, []byte("kept"), 0)
+	require.NoError(t, err)
+	require.Equal(t, append([]byte("kept"), data...), frame)
+
+	frame, err = readFrame(r, frame[:0], 0)
+	require.NoError(t, err)
+	require.Equal(t, data[:10], frame)
+}
+
+func TestWriteFrame(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, writeFrame(&buf, []byte("MSH|^~\\&\r")))
+	require.Equal(t, "\x0bMSH|^~\\&\r\x1c\r", buf.String())
+}
@@ -0,0 +1,475 @@
+package mllp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/s-hammon/hl7"
+)
+
+// A Frame is one message received by a Server.
+type Frame struct {
+	// Data is the content of the frame, without the framing bytes. It is
+	// only valid until the handler returns.
+	Data []byte
+
+	// MSH is the first segment of Data, parsed without the rest of the
+	// message, so that a handler can route the message by its header
+	// alone. It is the zero Segment if Data does not start with an MSH.
+	// Like Data, it is only valid until the handler returns.
+	MSH hl7.Segment
+
+	RemoteAddr net.Addr
+
+	// TLS describes the TLS connection the frame arrived on, or is nil for
+	// a connection without TLS. With client certificates required, the
+	// identity of the sender is that of its certificate:
+	//
+	//	cn := f.TLS.PeerCertificates[0].Subject.CommonName
+	TLS *tls.ConnectionState
+}
+
+// A Handler responds to the messages a Server receives.
+//
+// ServeMLLP returns the reply to send back for f, normally an
+// acknowledgement, or nil to send none. If it returns an error, the
+// connection is closed without a reply, which tells the sender to try the
+// message again. ctx is canceled when the connection is closed.
+type Handler interface {
+	ServeMLLP(ctx context.Context, f *Frame) ([]byte, error)
+}
+
+// HandlerFunc adapts an ordinary function to a Handler.
+type HandlerFunc func(ctx context.Context, f *Frame) ([]byte, error)
+
+// ServeMLLP calls h(ctx, f).
+func (h HandlerFunc) ServeMLLP(ctx context.Context, f *Frame) ([]byte, error) {
+	return h(ctx, f)
+}
+
+// ErrServerClosed is returned by a Server's Serve and ListenAndServe
+// methods after a call to Shutdown or Close.
+var ErrServerClosed = errors.New("mllp: Server closed")
+
+// A Server receives messages over MLLP and passes each one to its Handler,
+// sending back the reply. Each connection is served on its own goroutine,
+// one message at a time, in the order they arrive. The zero Server, given
+// a Handler, is ready to use; its fields must not be changed once it is
+// serving.
+type Server struct {
+	Addr    string // TCP address to listen on, ":2575" if empty
+	Handler Handler
+
+	// ReadTimeout limits the time taken to read a frame, from its first
+	// byte. WriteTimeout limits the time taken to write a reply.
+	// IdleTimeout limits the time a connection waits for its next frame.
+	// Zero means no limit.
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+
+	// MaxFrameSize is the longest a frame may be, in bytes of content. A
+	// connection that sends a longer one is closed. It defaults to
+	// DefaultMaxFrameSize.
+	MaxFrameSize int
+
+	// MaxConns, if positive, limits the number of connections served at
+	// once. Further connections wait to be accepted until one closes.
+	MaxConns int
+
+	// TLSConfig, if set, makes Serve and ListenAndServe accept TLS
+	// connections only. It needs a certificate, given by Certificates or
+	// GetCertificate; a CertReloader takes up renewed certificates without
+	// a restart. Setting ClientAuth to tls.RequireAndVerifyClientCert,
+	// with ClientCAs, accepts only senders with a certificate from those
+	// authorities, which handlers find in Frame.TLS. ReadTimeout also
+	// limits the TLS handshake.
+	TLSConfig *tls.Config
+
+	// ErrorLog receives errors that end a connection. If nil, they go to
+	// the log package's standard logger.
+	ErrorLog *log.Logger
+
+	inShutdown atomic.Bool
+
+	mu        sync.Mutex
+	listeners map[*net.Listener]struct{}
+	conns     map[*conn]struct{}
+	done      chan struct{}
+}
+
+// ListenAndServe listens on the TCP address addr and serves the
+// connections it accepts with handler.
+func ListenAndServe(addr string, handler Handler) error {
+	s := &Server{Addr: addr, Handler: handler}
+	return s.ListenAndServe()
+}
+
+// ListenAndServe listens on s.Addr and serves the connections it accepts.
+// It always returns a non-nil error; after Shutdown or Close, the error is
+// ErrServerClosed.
+func (s *Server) ListenAndServe() error {
+	if s.shuttingDown() {
+		return ErrServerClosed
+	}
+
+	addr := s.Addr
+	if addr == "" {
+		addr = ":2575"
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(l)
+}
+
+// Serve accepts connections on l and serves each one on its own goroutine,
+// over TLS if s.TLSConfig is set. l is closed when Serve returns. It
+// always returns a non-nil error; after Shutdown or Close, the error is
+// ErrServerClosed.
+func (s *Server) Serve(l net.Listener) error {
+	if s.TLSConfig != nil {
+		l = tls.NewListener(l, s.TLSConfig)
+	}
+	defer l.Close()
+	if !s.trackListener(&l, true) {
+		return ErrServerClosed
+	}
+	defer s.trackListener(&l, false)
+
+	var slots chan struct{}
+	if s.MaxConns > 0 {
+		slots = make(chan struct{}, s.MaxConns)
+	}
+
+	for {
+		if slots != nil {
+			select {
+			case slots <- struct{}{}:
+			case <-s.doneChan():
+				return ErrServerClosed
+			}
+		}
+
+		rwc, err := l.Accept()
+		if err != nil {
+			if s.shuttingDown() {
+				return ErrServerClosed
+			}
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		c := &conn{srv: s, rwc: rwc, cancel: cancel, idle: true}
+		if !s.trackConn(c, true) {
+			rwc.Close()
+			cancel()
+			return ErrServerClosed
+		}
+		go func() {
+			defer func() {
+				if slots != nil {
+					<-slots
+				}
+			}()
+			c.serve(ctx)
+		}()
+	}
+}
+
+// Shutdown stops the server without interrupting the messages being
+// handled: it closes its listeners, then closes each connection once it
+// has sent the reply to the message in hand, or straight away if it is
+// waiting for one. Shutdown returns when every connection is closed, or
+// with the context's error if ctx ends first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.inShutdown.Store(true)
+
+	s.mu.Lock()
+	err := s.closeListenersLocked()
+	s.closeDoneLocked()
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(shutdownPollInterval)
+	defer ticker.Stop()
+	for {
+		if s.closeIdleConns() {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// shutdownPollInterval is how often Shutdown looks for connections that
+// have become idle.
+const shutdownPollInterval = 50 * time.Millisecond
+
+// Close stops the server at once: it closes its listeners and every
+// connection, canceling the contexts of the messages being handled.
+func (s *Server) Close() error {
+	s.inShutdown.Store(true)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.closeListenersLocked()
+	s.closeDoneLocked()
+	for c := range s.conns {
+		c.close()
+	}
+
+	return err
+}
+
+func (s *Server) shuttingDown() bool {
+	return s.inShutdown.Load()
+}
+
+func (s *Server) doneChan() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+
+	return s.done
+}
+
+func (s *Server) closeDoneLocked() {
+	if s.done == nil {
+		s.done = make(chan struct{})
+	}
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
+
+// trackListener adds l to or removes it from the listeners closed on
+// shutdown. It reports false if l is to be added after shutdown began.
+func (s *Server) trackListener(l *net.Listener, add bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !add {
+		delete(s.listeners, l)
+		return true
+	}
+	if s.shuttingDown() {
+		return false
+	}
+	if s.listeners == nil {
+		s.listeners = make(map[*net.Listener]struct{})
+	}
+	s.listeners[l] = struct{}{}
+
+	return true
+}
+
+func (s *Server) trackConn(c *conn, add bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !add {
+		delete(s.conns, c)
+		return true
+	}
+	if s.shuttingDown() {
+		return false
+	}
+	if s.conns == nil {
+		s.conns = make(map[*conn]struct{})
+	}
+	s.conns[c] = struct{}{}
+
+	return true
+}
+
+func (s *Server) closeListenersLocked() error {
+	var err error
+	for l := range s.listeners {
+		if cerr := (*l).Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+		delete(s.listeners, l)
+	}
+
+	return err
+}
+
+// closeIdleConns closes the connections waiting for a message and reports
+// whether all of them were.
+func (s *Server) closeIdleConns() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	quiescent := true
+	for c := range s.conns {
+		if !c.closeIfIdle() {
+			quiescent = false
+		}
+	}
+
+	return quiescent
+}
+
+func (s *Server) logf(format string, args ...any) {
+	if s.ErrorLog != nil {
+		s.ErrorLog.Printf(format, args...)
+	} else {
+		log.Printf(format, args...)
+	}
+}
+
+// A conn is a connection being served.
+type conn struct {
+	srv    *Server
+	rwc    net.Conn
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	idle   bool // waiting for the next frame
+	closed bool
+}
+
+func (c *conn) serve(ctx context.Context) {
+	defer func() {
+		if err := recover(); err != nil {
+			c.srv.logf("mllp: panic serving %v: %v\n%s", c.rwc.RemoteAddr(), err, debug.Stack())
+		}
+		c.close()
+		c.srv.trackConn(c, false)
+	}()
+
+	var state *tls.ConnectionState
+	if tc, ok := c.rwc.(*tls.Conn); ok {
+		if d := c.srv.ReadTimeout; d > 0 {
+			c.rwc.SetDeadline(time.Now().Add(d))
+		}
+		if err := tc.HandshakeContext(ctx); err != nil {
+			c.srv.logf("mllp: TLS handshake error from %v: %v", c.rwc.RemoteAddr(), err)
+			return
+		}
+		c.rwc.SetDeadline(time.Time{})
+		cs := tc.ConnectionState()
+		state = &cs
+	}
+
+	r := bufio.NewReader(c.rwc)
+	var buf []byte
+	for {
+		if d := c.srv.IdleTimeout; d > 0 {
+			c.rwc.SetReadDeadline(time.Now().Add(d))
+		} else {
+			c.rwc.SetReadDeadline(time.Time{})
+		}
+		if _, err := r.Peek(1); err != nil {
+			var ne net.Error
+			if !errors.As(err, &ne) || !ne.Timeout() {
+				c.logErr(err)
+			}
+			return
+		}
+		if !c.setIdle(false) {
+			return
+		}
+
+		if d := c.srv.ReadTimeout; d > 0 {
+			c.rwc.SetReadDeadline(time.Now().Add(d))
+		}
+		var err error
+		buf, err = readFrame(r, buf[:0], c.srv.MaxFrameSize)
+		if err != nil {
+			c.logErr(err)
+			return
+		}
+
+		f := &Frame{Data: buf, MSH: header(buf), RemoteAddr: c.rwc.RemoteAddr(), TLS: state}
+		reply, err := c.srv.Handler.ServeMLLP(ctx, f)
+		if err != nil {
+			c.srv.logf("mllp: handling message from %v: %v", c.rwc.RemoteAddr(), err)
+			return
+		}
+
+		if reply != nil {
+			if d := c.srv.WriteTimeout; d > 0 {
+				c.rwc.SetWriteDeadline(time.Now().Add(d))
+			}
+			if err := writeFrame(c.rwc, reply); err != nil {
+				c.logErr(err)
+				return
+			}
+		}
+
+		if !c.setIdle(true) {
+			return
+		}
+	}
+}
+
+// setIdle marks c as waiting for a frame or as handling one. It reports
+// false if c is closed or, once idle, to be closed for shutdown.
+func (c *conn) setIdle(idle bool) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.idle = idle
+
+	return !c.closed && !(idle && c.srv.shuttingDown())
+}
+
+func (c *conn) closeIfIdle() bool {
+	c.mu.Lock()
+	idle := c.idle
+	c.mu.Unlock()
+	if idle {
+		c.close()
+	}
+
+	return idle
+}
+
+func (c *conn) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		c.rwc.Close()
+		c.cancel()
+	}
+}
+
+// logErr logs err unless it is the end of the connection, by either side.
+func (c *conn) logErr(err error) {
+	if err == io.EOF || errors.Is(err, net.ErrClosed) {
+		return
+	}
+
+	c.srv.logf("mllp: connection from %v: %v", c.rwc.RemoteAddr(), err)
+}
+
+// header parses the MSH segment at the start of data, if there is one.
+func header(data []byte) hl7.Segment {
+	end := bytes.IndexAny(data, "\r\n")
+	if end < 0 {
+		end = len(data)
+	}
+	m, err := hl7.ParseMessage(data[:end])
+	if err != nil {
+		return hl7.Segment{}
+	}
+
+	return m.Segments[0]
+}
@@ -0,0 +1,236 @@
+package mllp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testMessage = "MSH|^~\\&|Lab|LabFac|||||ORU^R01|42|P|2.3\rPID|1||V1\r"
+
+// echoID replies with the control ID of each message.
+var echoID = HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+	return []byte("ACK " + f.MSH.Field(10).String()), nil
+})
+
+// startServer serves s on a loopback listener and returns its address
+// and the error Serve returns.
+func startServer(t *testing.T, s *Server) (string, <-chan error) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	if s.ErrorLog == nil {
+		s.ErrorLog = log.New(io.Discard, "", 0)
+	}
+
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(l) }()
+	t.Cleanup(func() { s.Close() })
+
+	return l.Addr().String(), served
+}
+
+type testConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func dial(t *testing.T, addr string) *testConn {
+	t.Helper()
+	c, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+	c.SetDeadline(time.Now().Add(5 * time.Second))
+
+	return &testConn{Conn: c, r: bufio.NewReader(c)}
+}
+
+func (c *testConn) send(t *testing.T, msg string) {
+	t.Helper()
+	require.NoError(t, writeFrame(c, []byte(msg)))
+}
+
+func (c *testConn) recv() (string, error) {
+	frame, err := readFrame(c.r, nil, 0)
+	return string(frame), err
+}
+
+func TestServer(t *testing.T) {
+	var remote net.Addr
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		remote = f.RemoteAddr
+		require.Equal(t, testMessage, string(f.Data))
+		require.Equal(t, "MSH", f.MSH.Name)
+		return echoID(ctx, f)
+	})}
+	addr, _ := startServer(t, s)
+
+	c := dial(t, addr)
+	for range 3 {
+		c.send(t, testMessage)
+		reply, err := c.recv()
+		require.NoError(t, err)
+		require.Equal(t, "ACK 42", reply)
+	}
+	require.Equal(t, c.LocalAddr().String(), remote.String())
+}
+
+func TestServer_NoHeader(t *testing.T) {
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		require.Empty(t, f.MSH.Name)
+		return f.Data, nil
+	})}
+	addr, _ := startServer(t, s)
+
+	c := dial(t, addr)
+	c.send(t, "PID|1\r")
+	reply, err := c.recv()
+	require.NoError(t, err)
+	require.Equal(t, "PID|1\r", reply)
+}
+
+func TestServer_HandlerError(t *testing.T) {
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		if f.MSH.Field(10).String() == "fail" {
+			return nil, errors.New("store unavailable")
+		}
+		return echoID(ctx, f)
+	})}
+	addr, _ := startServer(t, s)
+
+	c := dial(t, addr)
+	c.send(t, strings.Replace(testMessage, "|42|", "|fail|", 1))
+	_, err := c.recv()
+	require.ErrorIs(t, err, io.EOF)
+
+	c = dial(t, addr)
+	c.send(t, testMessage)
+	reply, err := c.recv()
+	require.NoError(t, err)
+	require.Equal(t, "ACK 42", reply)
+}
+
+func TestServer_Limits(t *testing.T) {
+	s := &Server{
+		Handler:      echoID,
+		MaxFrameSize: len(testMessage),
+		IdleTimeout:  50 * time.Millisecond,
+	}
+	addr, _ := startServer(t, s)
+
+	c := dial(t, addr)
+	c.send(t, testMessage+"NTE|1\r")
+	_, err := c.recv()
+	require.ErrorIs(t, err, io.EOF)
+
+	c = dial(t, addr)
+	c.send(t, testMessage)
+	_, err = c.recv()
+	require.NoError(t, err)
+	start := time.Now()
+	_, err = c.recv()
+	require.ErrorIs(t, err, io.EOF)
+	require.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestServer_MaxConns(t *testing.T) {
+	s := &Server{Handler: echoID, MaxConns: 1}
+	addr, _ := startServer(t, s)
+
+	first := dial(t, addr)
+	first.send(t, testMessage)
+	_, err := first.recv()
+	require.NoError(t, err)
+
+	// the second connection is not served until the first one closes
+	second := dial(t, addr)
+	second.send(t, testMessage)
+	second.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, err = second.recv()
+	var ne net.Error
+	require.ErrorAs(t, err, &ne)
+	require.True(t, ne.Timeout())
+
+	first.Close()
+	second.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reply, err := second.recv()
+	require.NoError(t, err)
+	require.Equal(t, "ACK 42", reply)
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	handling, release := make(chan struct{}), make(chan struct{})
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		if f.MSH.Field(10).String() == "slow" {
+			close(handling)
+			<-release
+		}
+		return echoID(ctx, f)
+	})}
+	addr, served := startServer(t, s)
+
+	idle := dial(t, addr)
+	idle.send(t, testMessage)
+	_, err := idle.recv()
+	require.NoError(t, err)
+
+	busy := dial(t, addr)
+	busy.send(t, strings.Replace(testMessage, "|42|", "|slow|", 1))
+	<-handling
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- s.Shutdown(context.Background()) }()
+	require.ErrorIs(t, <-served, ErrServerClosed)
+
+	// the idle connection is closed, the busy one sends its reply first
+	_, err = idle.recv()
+	require.ErrorIs(t, err, io.EOF)
+	select {
+	case err := <-shutdown:
+		t.Fatalf("Shutdown returned %v while a message was being handled", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	reply, err := busy.recv()
+	require.NoError(t, err)
+	require.Equal(t, "ACK slow", reply)
+	_, err = busy.recv()
+	require.ErrorIs(t, err, io.EOF)
+	require.NoError(t, <-shutdown)
+
+	_, err = net.Dial("tcp", addr)
+	require.Error(t, err)
+	require.ErrorIs(t, s.ListenAndServe(), ErrServerClosed)
+}
+
+func TestServer_ShutdownTimeout(t *testing.T) {
+	handling := make(chan struct{})
+	s := &Server{Handler: HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+		close(handling)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})}
+	addr, _ := startServer(t, s)
+
+	c := dial(t, addr)
+	c.send(t, testMessage)
+	<-handling
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, s.Shutdown(ctx), context.DeadlineExceeded)
+
+	// Close cancels the handler's context
+	require.NoError(t, s.Close())
+	_, err := c.recv()
+	require.ErrorIs(t, err, io.EOF)
+}
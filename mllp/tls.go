@@ -0,0 +1,104 @@
+package mllp
+
+import (
+	"crypto/tls"
+	"os"
+	"sync"
+	"time"
+)
+
+// A CertReloader serves a certificate and key kept in PEM files, loading
+// them again when either file changes, so that a renewed certificate is
+// used for new connections without a restart. A change that cannot be
+// loaded, such as a certificate written before its key, leaves the
+// certificate loaded last in use until the pair matches again.
+//
+// Use GetCertificate in the tls.Config of a Server and
+// GetClientCertificate in that of a Client.
+type CertReloader struct {
+	certFile, keyFile string
+
+	mu     sync.Mutex
+	cert   *tls.Certificate
+	mods   [2]fileVersion // of certFile and keyFile, when cert was loaded
+	failed [2]fileVersion // the last versions that could not be loaded
+}
+
+// fileVersion tells one content of a file from the next.
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewCertReloader returns a CertReloader for the certificate in certFile
+// and its key in keyFile, which it loads first.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload loads the certificate and key again, whether or not the files
+// have changed. On error, the certificate loaded last stays in use.
+func (r *CertReloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	mods, err := r.versions()
+	if err != nil {
+		return err
+	}
+
+	return r.load(mods)
+}
+
+// GetCertificate returns the certificate, for tls.Config.GetCertificate.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.certificate()
+}
+
+// GetClientCertificate returns the certificate, for
+// tls.Config.GetClientCertificate.
+func (r *CertReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.certificate()
+}
+
+func (r *CertReloader) certificate() (*tls.Certificate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if mods, err := r.versions(); err == nil && mods != r.mods && mods != r.failed {
+		// keep the loaded certificate if the new one is not usable yet
+		if r.load(mods) != nil {
+			r.failed = mods
+		}
+	}
+
+	return r.cert, nil
+}
+
+func (r *CertReloader) versions() ([2]fileVersion, error) {
+	var mods [2]fileVersion
+	for i, name := range []string{r.certFile, r.keyFile} {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return mods, err
+		}
+		mods[i] = fileVersion{fi.ModTime(), fi.Size()}
+	}
+
+	return mods, nil
+}
+
+func (r *CertReloader) load(mods [2]fileVersion) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	r.cert, r.mods = &cert, mods
+
+	return nil
+}
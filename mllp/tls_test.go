@@ -0,0 +1,190 @@
+package mllp
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testCA issues certificates for the tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// issue returns a certificate for cn, valid for 127.0.0.1, and its
+// certificate and key in PEM.
+func (ca *testCA) issue(t *testing.T, cn string) (tls.Certificate, []byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	return cert, certPEM, keyPEM
+}
+
+// peerName replies with the common name of the sender's certificate.
+var peerName = HandlerFunc(func(ctx context.Context, f *Frame) ([]byte, error) {
+	name := "none"
+	if f.TLS != nil && len(f.TLS.PeerCertificates) > 0 {
+		name = f.TLS.PeerCertificates[0].Subject.CommonName
+	}
+	return ack(f, ApplicationAccept, name), nil
+})
+
+func TestTLS(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert, _, _ := ca.issue(t, "receiver")
+
+	s := &Server{Handler: peerName, TLSConfig: &tls.Config{Certificates: []tls.Certificate{serverCert}}}
+	addr, _ := startServer(t, s)
+
+	c := &Client{Addr: addr, TLSConfig: &tls.Config{RootCAs: ca.pool}}
+	defer c.Close()
+	a, err := c.Send(context.Background(), message("1"))
+	require.NoError(t, err)
+	require.Equal(t, "none", a.Text)
+
+	// a receiver with a certificate that is not trusted
+	other := newTestCA(t)
+	c2 := &Client{Addr: addr, TLSConfig: &tls.Config{RootCAs: other.pool}, MaxRetries: 3, Backoff: time.Second}
+	start := time.Now()
+	_, err = c2.Send(context.Background(), message("1"))
+	var verifyErr *tls.CertificateVerificationError
+	require.ErrorAs(t, err, &verifyErr)
+	require.Less(t, time.Since(start), time.Second, "retried")
+
+	// plain text is not accepted
+	_, err = (&Client{Addr: addr}).Send(context.Background(), message("1"))
+	require.Error(t, err)
+}
+
+func TestTLS_ClientCertificates(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert, _, _ := ca.issue(t, "receiver")
+	clientCert, _, _ := ca.issue(t, "lab-east")
+
+	s := &Server{Handler: peerName, TLSConfig: &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    ca.pool,
+	}}
+	addr, _ := startServer(t, s)
+
+	c := &Client{Addr: addr, TLSConfig: &tls.Config{RootCAs: ca.pool, Certificates: []tls.Certificate{clientCert}}}
+	defer c.Close()
+	a, err := c.Send(context.Background(), message("1"))
+	require.NoError(t, err)
+	require.Equal(t, "lab-east", a.Text)
+
+	// a sender without a certificate, or with one from elsewhere
+	otherCert, _, _ := newTestCA(t).issue(t, "intruder")
+	for _, certs := range [][]tls.Certificate{nil, {otherCert}} {
+		c := &Client{Addr: addr, TLSConfig: &tls.Config{RootCAs: ca.pool, Certificates: certs}, MaxRetries: 3, Backoff: time.Second}
+		start := time.Now()
+		_, err := c.Send(context.Background(), message("1"))
+		require.ErrorContains(t, err, "remote error: tls")
+		require.Less(t, time.Since(start), time.Second, "retried")
+	}
+}
+
+func TestCertReloader(t *testing.T) {
+	ca := newTestCA(t)
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	write := func(certPEM, keyPEM []byte, mod time.Time) {
+		require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+		require.NoError(t, os.WriteFile(keyFile, keyPEM, 0o600))
+		require.NoError(t, os.Chtimes(certFile, mod, mod))
+		require.NoError(t, os.Chtimes(keyFile, mod, mod))
+	}
+
+	_, certPEM, keyPEM := ca.issue(t, "receiver-1")
+	write(certPEM, keyPEM, time.Now().Add(-time.Minute))
+
+	_, err := NewCertReloader(certFile, filepath.Join(dir, "missing.pem"))
+	require.Error(t, err)
+	r, err := NewCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+
+	s := &Server{Handler: peerName, TLSConfig: &tls.Config{GetCertificate: r.GetCertificate}}
+	addr, _ := startServer(t, s)
+	serverName := func() string {
+		t.Helper()
+		conn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: ca.pool})
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].Subject.CommonName
+	}
+	require.Equal(t, "receiver-1", serverName())
+
+	// a renewed certificate is served without a restart
+	_, certPEM, keyPEM = ca.issue(t, "receiver-2")
+	write(certPEM, keyPEM, time.Now())
+	require.Equal(t, "receiver-2", serverName())
+
+	// a certificate whose key is not there yet leaves the last one in use
+	_, certPEM, _ = ca.issue(t, "receiver-3")
+	mod := time.Now().Add(time.Minute)
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	require.NoError(t, os.Chtimes(certFile, mod, mod))
+	require.Equal(t, "receiver-2", serverName())
+	require.Error(t, r.Reload())
+	require.Equal(t, "receiver-2", serverName())
+}
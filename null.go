@@ -0,0 +1,82 @@
+package hl7
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// nullText is the HL7 explicit null: a value of two double quotes, which
+// tells the receiver to delete what it holds, where an empty value tells
+// it to keep it.
+const nullText = `""`
+
+// NullValue is the type of Null.
+type NullValue struct{}
+
+// Null stands for the explicit null in the maps and interface values that
+// Unmarshal decodes, where an absent value is an empty string. Marshal
+// writes it as "".
+var Null NullValue
+
+// MarshalHL7 implements Marshaler.
+func (NullValue) MarshalHL7(Delimiters) ([]byte, error) {
+	return []byte(nullText), nil
+}
+
+// Presence tells an absent value from an explicit null and a value that
+// is present.
+type Presence uint8
+
+const (
+	Absent      Presence = iota // not sent: keep the value held
+	Present                     // sent with a value
+	PresentNull                 // sent as "": delete the value held
+)
+
+func (p Presence) String() string {
+	switch p {
+	case Absent:
+		return "absent"
+	case Present:
+		return "present"
+	case PresentNull:
+		return "null"
+	}
+
+	return "Presence(" + strconv.Itoa(int(p)) + ")"
+}
+
+// A Nullable is a field, component or subcomponent that reports whether
+// it was absent, null or present, for messages such as ADT^A08 updates
+// where each means something different. Value holds the decoded value
+// when Presence is Present and the zero value otherwise. Marshal writes
+// nothing for an absent Nullable, "" for a null one and Value, even if it
+// is the zero value, for a present one.
+type Nullable[T any] struct {
+	Value    T
+	Presence Presence
+}
+
+// IsNull reports whether n was sent as the explicit null.
+func (n Nullable[T]) IsNull() bool {
+	return n.Presence == PresentNull
+}
+
+// IsPresent reports whether n was sent with a value.
+func (n Nullable[T]) IsPresent() bool {
+	return n.Presence == Present
+}
+
+func (Nullable[T]) nullable() {}
+
+var nullableType = reflect.TypeFor[interface{ nullable() }]()
+
+// asNullable returns the Value field and the presence of v if v is a
+// Nullable.
+func asNullable(v reflect.Value) (reflect.Value, Presence, bool) {
+	if v.Kind() != reflect.Struct || !cachedImplements(v.Type(), nullableType) {
+		return reflect.Value{}, Absent, false
+	}
+
+	return v.Field(0), Presence(v.Field(1).Uint()), true
+}
@@ -0,0 +1,150 @@
+package hl7
+
+import (
+	"testing"
+
+	v23 "github.com/s-hammon/hl7/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+type nullName struct {
+	Family Nullable[string]
+	Given  Nullable[string]
+}
+
+type nullPID struct {
+	SetId       int
+	Name        Nullable[nullName] `hl7:"5"`
+	Mother      Nullable[string]   `hl7:"6"`
+	BirthDate   Nullable[string]   `hl7:"7"`
+	Sex         string             `hl7:"8"`
+	BirthOrder  int                `hl7:"25"`
+	Citizenship Nullable[[]string] `hl7:"26"`
+}
+
+type nullMsg struct {
+	MSH v23.MSH
+	PID nullPID
+}
+
+const nullInput = "MSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3\r" +
+	"PID|1||V1||DOE^\"\"|\"\"||\"\"" + "|||||||||||||||||\"\"|US~CA\r"
+
+func TestUnmarshal_Null(t *testing.T) {
+	var m nullMsg
+	require.NoError(t, Unmarshal([]byte(nullInput), &m))
+
+	pid := m.PID
+	require.Equal(t, Present, pid.Name.Presence)
+	require.Equal(t, Nullable[string]{Value: "DOE", Presence: Present}, pid.Name.Value.Family)
+	require.True(t, pid.Name.Value.Given.IsNull())
+	require.True(t, pid.Mother.IsNull())
+	require.Equal(t, Absent, pid.BirthDate.Presence)
+	require.False(t, pid.BirthDate.IsPresent())
+
+	// a string keeps the null as text; other scalars are cleared by it
+	require.Equal(t, `""`, pid.Sex)
+	require.Zero(t, pid.BirthOrder)
+
+	require.Equal(t, []string{"US", "CA"}, pid.Citizenship.Value)
+	require.True(t, pid.Citizenship.IsPresent())
+
+	// PID-3 has no field to go to, and the null in PID-25 was lost to an int
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3\r"+
+		"PID|1||||DOE^\"\"|\"\"||\"\"||||||||||||||||||US~CA\r", string(b))
+}
+
+func TestMarshal_Nullable(t *testing.T) {
+	m := nullMsg{
+		MSH: v23.MSH{MessageType: v23.CM_MSG{Type: "ADT", TriggerEvent: "A08"}},
+		PID: nullPID{
+			SetId:     1,
+			Mother:    Nullable[string]{Presence: PresentNull},
+			BirthDate: Nullable[string]{Value: "19700101"},
+			Name: Nullable[nullName]{Presence: Present, Value: nullName{
+				Given: Nullable[string]{Presence: Present},
+			}},
+		},
+	}
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	// an absent value is left out even if it is set
+	require.Contains(t, string(b), "\rPID|1|||||\"\"\r")
+
+	type intMsg struct {
+		MSH v23.MSH
+		PID struct {
+			SetId Nullable[int]
+		}
+	}
+	b, err = Marshal(&intMsg{PID: struct{ SetId Nullable[int] }{Nullable[int]{Presence: Present}}})
+	require.NoError(t, err)
+	require.Contains(t, string(b), "\rPID|0\r")
+}
+
+func TestUnmarshal_NullMap(t *testing.T) {
+	var m map[string]any
+	require.NoError(t, Unmarshal([]byte(nullInput), &m))
+
+	pid := m["PID"].(map[int]any)
+	require.Equal(t, Null, pid[6])
+	require.Equal(t, map[int]any{1: "DOE", 2: Null}, pid[5])
+	require.Equal(t, "", pid[7])
+
+	var v struct {
+		MSH v23.MSH
+		PID struct {
+			SetId  string
+			Values []any `hl7:"6"`
+		}
+	}
+	require.NoError(t, Unmarshal([]byte(nullInput), &v))
+	require.Equal(t, []any{Null}, v.PID.Values)
+
+	b, err := Marshal(&struct {
+		MSH v23.MSH
+		PID struct {
+			SetId  string
+			Mother NullValue `hl7:"6"`
+		}
+	}{})
+	require.NoError(t, err)
+	require.Contains(t, string(b), "\rPID||||||\"\"\r")
+}
+
+func TestMarshal_NullAny(t *testing.T) {
+	in := "MSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3\r" +
+		"PID|1||V1^^^H&1.2&ISO~V2||DOE^\"\"|\"\"|19700101\r"
+
+	var m struct {
+		MSH v23.MSH
+		PID struct {
+			SetId     any
+			PatientId any `hl7:"3"`
+			Name      any `hl7:"5"`
+			Mother    any `hl7:"6"`
+			BirthDate any `hl7:"7"`
+			Sex       any `hl7:"8"`
+		}
+	}
+	require.NoError(t, Unmarshal([]byte(in), &m))
+	require.Equal(t, Null, m.PID.Mother)
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, in, string(b))
+}
+
+func TestMessage_IsNull(t *testing.T) {
+	msg, err := ParseMessage([]byte(nullInput))
+	require.NoError(t, err)
+
+	seg := pid(msg)
+	require.True(t, seg.Field(6).IsNull())
+	require.True(t, seg.Field(5).Component(2).IsNull())
+	require.False(t, seg.Field(5).Component(1).IsNull())
+	require.False(t, seg.Field(7).IsNull())
+}
@@ -0,0 +1,766 @@
+package hl7
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Path addresses a value in a message using terser notation:
+//
+//	PID-3            field 3 of the first PID segment
+//	PID-3(2)-1       component 1 of the second repetition of PID-3
+//	OBX(3)-5         field 5 of the third OBX segment
+//	ORDER(2)/OBR-4-2 component 2 of OBR-4 in the second ORDER group
+//	PID-11-4-2       subcomponent 2 of PID-11-4
+//
+// Segment and group occurrences are counted in message order within the
+// enclosing scope: the whole message, or the group instance selected by
+// the preceding group step.
+type Path struct {
+	Groups       []PathGroup
+	Segment      string
+	Ordinal      int // 1-based occurrence of Segment
+	Field        int
+	Repetition   int // 0 when the path does not name one
+	Component    int // 0 addresses the whole repetition
+	SubComponent int // 0 addresses the whole component
+}
+
+// A PathGroup is a group step in a Path.
+type PathGroup struct {
+	Name  string
+	Index int // 1-based occurrence of the group
+}
+
+// A PathError describes a malformed path or one that cannot be applied to
+// a message.
+type PathError struct {
+	Path string
+	msg  string
+}
+
+func (e *PathError) Error() string {
+	return "hl7: path " + strconv.Quote(e.Path) + ": " + e.msg
+}
+
+// ParsePath parses a path in terser notation.
+func ParsePath(s string) (Path, error) {
+	bad := func(format string, args ...any) (Path, error) {
+		return Path{}, &PathError{Path: s, msg: fmt.Sprintf(format, args...)}
+	}
+
+	var p Path
+	steps := strings.Split(s, "/")
+	for _, step := range steps[:len(steps)-1] {
+		name, n, err := parseStep(step)
+		if err != nil {
+			return bad("group %q: %v", step, err)
+		}
+		if name == "" {
+			return bad("empty group name")
+		}
+		p.Groups = append(p.Groups, PathGroup{Name: name, Index: n})
+	}
+
+	parts := strings.Split(steps[len(steps)-1], "-")
+	if len(parts) < 2 {
+		return bad("missing field number")
+	}
+	if len(parts) > 4 {
+		return bad("too many components")
+	}
+
+	var err error
+	p.Segment, p.Ordinal, err = parseStep(parts[0])
+	if err != nil {
+		return bad("segment %q: %v", parts[0], err)
+	}
+	if !isSegmentName(p.Segment) {
+		return bad("invalid segment ID %q", p.Segment)
+	}
+
+	field, rep, err := parseStep(parts[1])
+	if err != nil {
+		return bad("field %q: %v", parts[1], err)
+	}
+	if p.Field, err = parseIndex(field); err != nil {
+		return bad("field %q: %v", parts[1], err)
+	}
+	if strings.Contains(parts[1], "(") {
+		p.Repetition = rep
+	}
+
+	if len(parts) > 2 {
+		if p.Component, err = parseIndex(parts[2]); err != nil {
+			return bad("component %q: %v", parts[2], err)
+		}
+	}
+	if len(parts) > 3 {
+		if p.SubComponent, err = parseIndex(parts[3]); err != nil {
+			return bad("subcomponent %q: %v", parts[3], err)
+		}
+	}
+
+	return p, nil
+}
+
+// parseStep splits NAME or NAME(n) into its name and 1-based index, which
+// is 1 when absent.
+func parseStep(s string) (string, int, error) {
+	name, rest, found := strings.Cut(s, "(")
+	if !found {
+		return s, 1, nil
+	}
+
+	idx, ok := strings.CutSuffix(rest, ")")
+	if !ok {
+		return "", 0, fmt.Errorf("missing )")
+	}
+	n, err := parseIndex(idx)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return name, n, nil
+}
+
+func parseIndex(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("index must be a positive integer")
+	}
+
+	return n, nil
+}
+
+// String returns p in terser notation.
+func (p Path) String() string {
+	var b strings.Builder
+	for _, g := range p.Groups {
+		b.WriteString(g.Name)
+		if g.Index > 1 {
+			b.WriteString("(" + strconv.Itoa(g.Index) + ")")
+		}
+		b.WriteByte('/')
+	}
+
+	b.WriteString(p.Segment)
+	if p.Ordinal > 1 {
+		b.WriteString("(" + strconv.Itoa(p.Ordinal) + ")")
+	}
+	b.WriteString("-" + strconv.Itoa(p.Field))
+	if p.Repetition > 0 {
+		b.WriteString("(" + strconv.Itoa(p.Repetition) + ")")
+	}
+	if p.Component > 0 {
+		b.WriteString("-" + strconv.Itoa(p.Component))
+	}
+	if p.SubComponent > 0 {
+		b.WriteString("-" + strconv.Itoa(p.SubComponent))
+	}
+
+	return b.String()
+}
+
+// levels returns the repetition, component and subcomponent indexes below
+// the field, with the first repetition implied when a component is named.
+func (p Path) levels() []int {
+	rep := p.Repetition
+	if rep == 0 && p.Component > 0 {
+		rep = 1
+	}
+
+	return []int{rep, p.Component, p.SubComponent}
+}
+
+// Get returns the text of the value at path in msg, which is either a
+// *Message or a pointer to a message struct. Escape sequences are decoded.
+// A path that names a whole field, repetition or component returns it
+// with its inner delimiters in place. An absent value is returned as "".
+func Get(msg any, path string) (string, error) {
+	p, err := ParsePath(path)
+	if err != nil {
+		return "", err
+	}
+
+	switch m := msg.(type) {
+	case *Message:
+		return m.get(p, path)
+	default:
+		rv, err := messageStruct(msg, path)
+		if err != nil {
+			return "", err
+		}
+		return getStruct(rv, p, path)
+	}
+}
+
+// Set stores text at path in msg, which is either a *Message or a pointer
+// to a message struct, escaping any delimiters it contains so that Get
+// returns it unchanged. Segments, groups, repetitions and components that
+// do not exist yet are added, as long as the occurrence asked for is the
+// next one; setting OBX(4) requires three OBX segments to be present.
+//
+// Setting a value in a *Message rewrites its text; segments and values
+// obtained from it earlier keep referring to the old text.
+func Set(msg any, path, text string) error {
+	p, err := ParsePath(path)
+	if err != nil {
+		return err
+	}
+
+	switch m := msg.(type) {
+	case *Message:
+		return m.set(p, path, text)
+	default:
+		rv, err := messageStruct(msg, path)
+		if err != nil {
+			return err
+		}
+		return setStruct(rv, p, path, text)
+	}
+}
+
+func messageStruct(msg any, path string) (reflect.Value, error) {
+	rv := reflect.ValueOf(msg)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, &PathError{Path: path, msg: fmt.Sprintf("cannot address %T", msg)}
+	}
+
+	return rv.Elem(), nil
+}
+
+func (m *Message) get(p Path, path string) (string, error) {
+	if len(p.Groups) > 0 {
+		return "", &PathError{Path: path, msg: "group steps need a message struct"}
+	}
+
+	seg, ok := m.occurrence(p.Segment, p.Ordinal)
+	if !ok {
+		return "", nil
+	}
+
+	f := seg.Field(p.Field)
+	lv := p.levels()
+	switch {
+	case lv[0] == 0:
+		return f.String(), nil
+	case lv[1] == 0:
+		return f.Repetition(lv[0]).String(), nil
+	case lv[2] == 0:
+		return f.Repetition(lv[0]).Component(lv[1]).String(), nil
+	}
+
+	return f.Repetition(lv[0]).Component(lv[1]).SubComponent(lv[2]).String(), nil
+}
+
+func (m *Message) occurrence(name string, n int) (Segment, bool) {
+	for seg := range m.All(name) {
+		if n--; n == 0 {
+			return seg, true
+		}
+	}
+
+	return Segment{}, false
+}
+
+func (m *Message) set(p Path, path, text string) error {
+	bad := func(format string, args ...any) error {
+		return &PathError{Path: path, msg: fmt.Sprintf(format, args...)}
+	}
+
+	if len(p.Groups) > 0 {
+		return bad("group steps need a message struct")
+	}
+	if p.Segment == "MSH" && p.Field <= 2 {
+		return bad("the delimiters in MSH-1 and MSH-2 cannot be set")
+	}
+
+	e := &encodeState{
+		fldDelim: m.Delimiters.Field,
+		comDelim: m.Delimiters.Component,
+		repDelim: m.Delimiters.Repetition,
+		escDelim: m.Delimiters.Escape,
+		subDelim: m.Delimiters.SubComponent,
+	}
+	e.writeEscaped(text, false)
+
+	count := 0
+	for range m.All(p.Segment) {
+		count++
+	}
+
+	var (
+		seg         Segment
+		start, stop int
+	)
+	switch {
+	case p.Ordinal <= count:
+		seg, _ = m.occurrence(p.Segment, p.Ordinal)
+		start, stop = seg.Offset, seg.Offset+len(seg.raw)
+	case p.Ordinal == count+1:
+		seg = Segment{Name: p.Segment, fields: []Field{{value{raw: []byte(p.Segment)}}}}
+		start, stop = len(m.data), len(m.data)
+	default:
+		return bad("cannot add %s(%d): the message has %d", p.Segment, p.Ordinal, count)
+	}
+
+	fields := make([][]byte, max(len(seg.fields), p.Field+1))
+	for i, f := range seg.fields {
+		fields[i] = f.raw
+	}
+	delims := []byte{m.Delimiters.Repetition, m.Delimiters.Component, m.Delimiters.SubComponent}
+	fields[p.Field] = splice(fields[p.Field], delims, p.levels(), e.Bytes())
+
+	var out []byte
+	out = append(out, m.data[:start]...)
+	if start == len(m.data) && start > 0 && m.data[start-1] != '\r' && m.data[start-1] != '\n' {
+		out = append(out, '\r')
+	}
+	out = append(out, fields[0]...)
+	for i := 1; i < len(fields); i++ {
+		if seg.Name == "MSH" && i <= 2 {
+			// MSH-1 is the field separator itself
+			out = append(out, fields[i]...)
+			continue
+		}
+		out = append(out, m.Delimiters.Field)
+		out = append(out, fields[i]...)
+	}
+	if stop == len(m.data) && start == stop {
+		out = append(out, '\r')
+	}
+	out = append(out, m.data[stop:]...)
+
+	return m.parseUTF8(out)
+}
+
+// splice replaces the member of raw addressed by idx, one index per level
+// of delims, with val, adding empty members as needed. An index of 0
+// replaces the whole value at that level.
+func splice(raw []byte, delims []byte, idx []int, val []byte) []byte {
+	if len(idx) == 0 || idx[0] == 0 {
+		return val
+	}
+
+	parts := bytes.Split(raw, delims[:1])
+	for len(parts) < idx[0] {
+		parts = append(parts, nil)
+	}
+	parts[idx[0]-1] = splice(parts[idx[0]-1], delims[1:], idx[1:], val)
+
+	return bytes.Join(parts, delims[:1])
+}
+
+// getStruct resolves p against the message struct v.
+func getStruct(v reflect.Value, p Path, path string) (string, error) {
+	for _, g := range p.Groups {
+		var ok bool
+		if v, ok = nthGroup(v, g.Name, g.Index); !ok {
+			return "", nil
+		}
+	}
+
+	seg, ok := nthSegment(v, p.Segment, p.Ordinal)
+	if !ok {
+		return "", nil
+	}
+
+	fv, depth, err := valueAt(seg, p, path, false)
+	if err != nil || !fv.IsValid() {
+		return "", err
+	}
+
+	e := newEncodeState()
+	if depth == 0 && p.Repetition == 0 {
+		err = e.field(fv, "")
+	} else {
+		err = e.component(fv, depth, "")
+	}
+	if err != nil {
+		return "", err
+	}
+
+	s := scanner{
+		fldDelim: e.fldDelim,
+		comDelim: e.comDelim,
+		repDelim: e.repDelim,
+		escDelim: e.escDelim,
+		subDelim: e.subDelim,
+	}
+	return s.unescape(e.String(), false), nil
+}
+
+// setStruct resolves p against the message struct v, adding missing
+// groups and segments, and stores text there.
+func setStruct(v reflect.Value, p Path, path, text string) error {
+	for _, g := range p.Groups {
+		inst, ok := nthGroup(v, g.Name, g.Index)
+		if !ok {
+			inst, ok = addOccurrence(v, g.Index, func(sf reflect.StructField, c groupChild) bool {
+				return c.group && isGroupNamed(g.Name, sf, c)
+			})
+		}
+		if !ok {
+			return &PathError{Path: path, msg: fmt.Sprintf("cannot add group %s(%d)", g.Name, g.Index)}
+		}
+		v = inst
+	}
+
+	seg, ok := nthSegment(v, p.Segment, p.Ordinal)
+	if !ok {
+		seg, ok = addOccurrence(v, p.Ordinal, func(_ reflect.StructField, c groupChild) bool {
+			return !c.group && c.name == p.Segment
+		})
+		if !ok {
+			return &PathError{Path: path, msg: fmt.Sprintf("cannot add segment %s(%d)", p.Segment, p.Ordinal)}
+		}
+	}
+
+	fv, _, err := valueAt(seg, p, path, true)
+	if err != nil {
+		return err
+	}
+
+	return setText(fv, text)
+}
+
+// valueAt returns the value within the segment struct seg that p
+// addresses, and its depth for encoding: 0 for a field or repetition, 1
+// for a component and 2 for a subcomponent. When create is set, nil
+// pointers and short slices on the way are filled in; otherwise an absent
+// value is returned as the zero Value.
+func valueAt(seg reflect.Value, p Path, path string, create bool) (reflect.Value, int, error) {
+	bad := func(format string, args ...any) (reflect.Value, int, error) {
+		return reflect.Value{}, 0, &PathError{Path: path, msg: fmt.Sprintf(format, args...)}
+	}
+
+	v, ok := deref(seg, create)
+	if !ok {
+		return reflect.Value{}, 0, nil
+	}
+
+	v, ok = member(v, p.Field)
+	if !ok {
+		return bad("%s has no field %d", p.Segment, p.Field)
+	}
+
+	lv := p.levels()
+	if lv[0] > 0 {
+		if v, ok = open(v, create); !ok {
+			return v, 0, nil
+		}
+		switch {
+		case v.Kind() == reflect.Slice:
+			if v.Len() < lv[0] {
+				if !create {
+					return reflect.Value{}, 0, nil
+				}
+				v.Set(reflect.AppendSlice(v, reflect.MakeSlice(v.Type(), lv[0]-v.Len(), lv[0]-v.Len())))
+			}
+			v = v.Index(lv[0] - 1)
+		case lv[0] > 1:
+			return bad("%s-%d does not repeat", p.Segment, p.Field)
+		}
+	}
+
+	depth := 0
+	for _, n := range lv[1:] {
+		if n == 0 {
+			break
+		}
+		depth++
+
+		if v, ok = open(v, create); !ok {
+			return v, depth, nil
+		}
+		if _, isUnmarshaler := asUnmarshaler(v); isUnmarshaler || v.Kind() != reflect.Struct || v.Type() == timeType {
+			// a primitive stands for its own first component
+			if n > 1 {
+				return bad("%s has no component %d", v.Type(), n)
+			}
+			continue
+		}
+
+		if v, ok = member(v, n); !ok {
+			return bad("%s has no component %d", v.Type(), n)
+		}
+	}
+
+	return v, depth, nil
+}
+
+// deref follows pointers from v, allocating nil ones when create is set.
+// It reports false if it reached a nil pointer it did not allocate.
+func deref(v reflect.Value, create bool) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			if !create {
+				return reflect.Value{}, false
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	return v, true
+}
+
+// open follows pointers and Nullables from v to the value they hold.
+// When create is set, nil pointers are allocated and Nullables marked
+// present. Otherwise it reports false at a nil pointer, returning the zero
+// Value, or at a Nullable that is absent or null, returning the Nullable.
+func open(v reflect.Value, create bool) (reflect.Value, bool) {
+	for {
+		var ok bool
+		if v, ok = deref(v, create); !ok {
+			return reflect.Value{}, false
+		}
+
+		val, presence, ok := asNullable(v)
+		if !ok {
+			return v, true
+		}
+		if presence != Present {
+			if !create {
+				return v, false
+			}
+			val.SetZero()
+			v.Field(1).SetUint(uint64(Present))
+		}
+		v = val
+	}
+}
+
+// member returns the field of the segment or composite struct v at the
+// 1-based HL7 index n.
+func member(v reflect.Value, n int) (reflect.Value, bool) {
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	for _, f := range cachedCompositeFields(v.Type()) {
+		if f.hl7Idx == n {
+			return v.Field(f.index), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// setText stores the plain text s in dst the way decoding would store a
+// value with no components.
+func setText(dst reflect.Value, s string) error {
+	dst, _ = deref(dst, true)
+
+	if u, ok := asUnmarshaler(dst); ok {
+		e := newEncodeState()
+		e.writeEscaped(s, false)
+		return u.UnmarshalHL7(e.Bytes(), e.delimiters())
+	}
+
+	if val, _, ok := asNullable(dst); ok {
+		val.SetZero()
+		switch s {
+		case "":
+			dst.Field(1).SetUint(uint64(Absent))
+			return nil
+		case nullText:
+			dst.Field(1).SetUint(uint64(PresentNull))
+			return nil
+		}
+		dst.Field(1).SetUint(uint64(Present))
+		return setText(val, s)
+	}
+
+	switch {
+	case isScalar(dst.Type()):
+		if s == "" {
+			dst.SetZero()
+			return nil
+		}
+		return setScalar(dst, s, time.UTC)
+	case dst.Kind() == reflect.Struct:
+		dst.SetZero()
+		first, ok := member(dst, 1)
+		if !ok {
+			return &UnsupportedTypeError{dst.Type()}
+		}
+		return setText(first, s)
+	case dst.Kind() == reflect.Slice:
+		dst.Set(reflect.MakeSlice(dst.Type(), 1, 1))
+		return setText(dst.Index(0), s)
+	}
+
+	return &UnsupportedTypeError{dst.Type()}
+}
+
+func asUnmarshaler(v reflect.Value) (Unmarshaler, bool) {
+	if v.CanAddr() && cachedImplements(reflect.PointerTo(v.Type()), unmarshalerType) {
+		return v.Addr().Interface().(Unmarshaler), true
+	}
+
+	return nil, false
+}
+
+// nthSegment returns the n-th segment struct called name within the
+// message or group struct v, counting in message order.
+func nthSegment(v reflect.Value, name string, n int) (reflect.Value, bool) {
+	return nthChild(v, n, func(_ reflect.StructField, c groupChild) bool {
+		return !c.group && c.name == name
+	})
+}
+
+// nthGroup returns the n-th instance of the group called name within the
+// message or group struct v, counting in message order.
+func nthGroup(v reflect.Value, name string, n int) (reflect.Value, bool) {
+	return nthChild(v, n, func(sf reflect.StructField, c groupChild) bool {
+		return c.group && isGroupNamed(name, sf, c)
+	})
+}
+
+// isGroupNamed reports whether a path's group step refers to the group
+// field sf. The step may use the field name, its hl7 tag name or the
+// group's type name, ignoring case and underscores, so that ORDER,
+// Order and ObsOrderGroup all name the same field.
+func isGroupNamed(name string, sf reflect.StructField, c groupChild) bool {
+	norm := func(s string) string {
+		return strings.ToUpper(strings.ReplaceAll(s, "_", ""))
+	}
+
+	name = norm(name)
+	return name == norm(sf.Name) ||
+		name == norm(parseTag(sf.Tag.Get("hl7")).Name) ||
+		name == norm(c.typ.Name())
+}
+
+func nthChild(v reflect.Value, n int, match func(reflect.StructField, groupChild) bool) (reflect.Value, bool) {
+	var found reflect.Value
+	walkChildren(v, match, func(inst reflect.Value) bool {
+		if n--; n == 0 {
+			found = inst
+			return false
+		}
+		return true
+	})
+
+	return found, found.IsValid()
+}
+
+// walkChildren calls fn, in message order, with every instance of a child
+// of the group struct v that match accepts, descending into groups that it
+// does not. It stops early if fn returns false.
+func walkChildren(v reflect.Value, match func(reflect.StructField, groupChild) bool, fn func(reflect.Value) bool) bool {
+	for _, c := range cachedGroup(v.Type()).children {
+		matched := match(v.Type().Field(c.index), c)
+		if !matched && !c.group {
+			continue
+		}
+
+		for inst := range instances(v.Field(c.index)) {
+			if matched {
+				if !fn(inst) {
+					return false
+				}
+			} else if !walkChildren(inst, match, fn) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// instances yields each struct held by v, a struct, a pointer to one, or a
+// slice of either. Nil pointers are skipped.
+func instances(v reflect.Value) func(func(reflect.Value) bool) {
+	return func(yield func(reflect.Value) bool) {
+		var walk func(v reflect.Value) bool
+		walk = func(v reflect.Value) bool {
+			switch v.Kind() {
+			case reflect.Struct:
+				return yield(v)
+			case reflect.Pointer, reflect.Interface:
+				if v.IsNil() {
+					return true
+				}
+				return walk(v.Elem())
+			case reflect.Slice:
+				for i := range v.Len() {
+					if !walk(v.Index(i)) {
+						return false
+					}
+				}
+			}
+			return true
+		}
+		walk(v)
+	}
+}
+
+// addOccurrence adds the n-th instance of the first child, in declaration
+// order, of the group struct v that match accepts, provided n-1 instances
+// exist. The new instance goes into the most recent instance of each
+// enclosing group, and a new instance of the innermost repeating field on
+// the way is appended to hold it.
+func addOccurrence(v reflect.Value, n int, match func(reflect.StructField, groupChild) bool) (reflect.Value, bool) {
+	count := 0
+	walkChildren(v, match, func(reflect.Value) bool {
+		count++
+		return true
+	})
+	if count != n-1 {
+		return reflect.Value{}, false
+	}
+
+	chain := findChain(v.Type(), match, nil)
+	if chain == nil {
+		return reflect.Value{}, false
+	}
+
+	grow := -1 // the level that gets a new instance
+	for i, c := range chain {
+		if c.repeated {
+			grow = i
+		}
+	}
+	if grow < 0 && count > 0 {
+		return reflect.Value{}, false
+	}
+
+	for i, c := range chain {
+		fv := v.Field(c.index)
+		if c.repeated {
+			if i == grow || fv.Len() == 0 {
+				fv.Set(reflect.Append(fv, reflect.Zero(fv.Type().Elem())))
+			}
+			fv = fv.Index(fv.Len() - 1)
+		}
+		v, _ = deref(fv, true)
+	}
+
+	return v, true
+}
+
+// findChain returns the children leading from the group type t to the
+// first child that match accepts, searching groups depth first in
+// declaration order, or nil if there is none.
+func findChain(t reflect.Type, match func(reflect.StructField, groupChild) bool, prefix []groupChild) []groupChild {
+	for _, c := range cachedGroup(t).children {
+		chain := append(prefix[:len(prefix):len(prefix)], c)
+		if match(t.Field(c.index), c) {
+			return chain
+		}
+		if c.group {
+			if found := findChain(c.typ, match, chain); found != nil {
+				return found
+			}
+		}
+	}
+
+	return nil
+}
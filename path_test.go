@@ -0,0 +1,190 @@
+package hl7
+
+import (
+	"testing"
+
+	v23 "github.com/s-hammon/hl7/proto/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Path
+	}{
+		{"PID-5", Path{Segment: "PID", Ordinal: 1, Field: 5}},
+		{"PID-5-1", Path{Segment: "PID", Ordinal: 1, Field: 5, Component: 1}},
+		{"PID-3(2)-1", Path{Segment: "PID", Ordinal: 1, Field: 3, Repetition: 2, Component: 1}},
+		{"OBX(3)-5", Path{Segment: "OBX", Ordinal: 3, Field: 5}},
+		{"PID-11-4-2", Path{Segment: "PID", Ordinal: 1, Field: 11, Component: 4, SubComponent: 2}},
+		{"ORDER(2)/OBR-4-2", Path{
+			Groups:  []PathGroup{{Name: "ORDER", Index: 2}},
+			Segment: "OBR", Ordinal: 1, Field: 4, Component: 2,
+		}},
+		{"RESULTS/ORDER/OBSERVATION(4)/OBX-5", Path{
+			Groups:  []PathGroup{{Name: "RESULTS", Index: 1}, {Name: "ORDER", Index: 1}, {Name: "OBSERVATION", Index: 4}},
+			Segment: "OBX", Ordinal: 1, Field: 5,
+		}},
+	}
+
+	for _, tt := range tests {
+		got, err := ParsePath(tt.in)
+		require.NoError(t, err, tt.in)
+		require.Equal(t, tt.want, got, tt.in)
+		require.Equal(t, tt.in, got.String())
+	}
+
+	for _, in := range []string{"", "PID", "PID-", "PID-0", "PID-x", "PID(0)-3", "PID-3(2", "pid-3", "PID-1-2-3-4", "/PID-3", "ORDER()/OBR-4"} {
+		_, err := ParsePath(in)
+		var pathErr *PathError
+		require.ErrorAs(t, err, &pathErr, in)
+		require.Equal(t, in, pathErr.Path)
+	}
+}
+
+func TestGetSet_Message(t *testing.T) {
+	m, err := ParseMessage([]byte("MSH|^~\\&|App|Fac|||||ORU^R01|1|P|2.3\r" +
+		"PID|1||V1~MRN7^^^HOSP&1.2.3||DOE^JANE\r" +
+		"OBX|1|ST|A||first\r" +
+		"OBX|2|ST|B||second\r"))
+	require.NoError(t, err)
+
+	get := func(path string) string {
+		t.Helper()
+		s, err := Get(m, path)
+		require.NoError(t, err)
+		return s
+	}
+
+	require.Equal(t, "ORU", get("MSH-9-1"))
+	require.Equal(t, "MRN7", get("PID-3(2)-1"))
+	require.Equal(t, "1.2.3", get("PID-3(2)-4-2"))
+	require.Equal(t, "V1", get("PID-3-1"))
+	require.Equal(t, "DOE^JANE", get("PID-5"))
+	require.Equal(t, "second", get("OBX(2)-5"))
+	require.Equal(t, "", get("OBX(3)-5"))
+	require.Equal(t, "", get("PID-30"))
+
+	require.NoError(t, Set(m, "PID-5-2", "JO^ANN"))
+	require.Equal(t, "JO^ANN", get("PID-5-2"))
+	require.NoError(t, Set(m, "PID-3(3)-4-2", "9.9"))
+	require.NoError(t, Set(m, "OBX(3)-5", "third"))
+	require.NoError(t, Set(m, "ZZZ-2", "z"))
+	require.NoError(t, Set(m, "MSH-10", "2"))
+
+	require.Equal(t, "MSH|^~\\&|App|Fac|||||ORU^R01|2|P|2.3\r"+
+		"PID|1||V1~MRN7^^^HOSP&1.2.3~^^^&9.9||DOE^JO\\S\\ANN\r"+
+		"OBX|1|ST|A||first\r"+
+		"OBX|2|ST|B||second\r"+
+		"OBX|||||third\r"+
+		"ZZZ||z\r", string(m.Bytes()))
+
+	var pathErr *PathError
+	require.ErrorAs(t, Set(m, "OBX(5)-5", "fifth"), &pathErr)
+	require.ErrorAs(t, Set(m, "MSH-2", "#"), &pathErr)
+	_, err = Get(m, "ORDER/OBR-4")
+	require.ErrorAs(t, err, &pathErr)
+}
+
+func TestSet_MessageCharset(t *testing.T) {
+	m, err := ParseMessage([]byte("MSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3||||||8859/1\r" +
+		"PID|1||V1||M\xdcLLER\r"))
+	require.NoError(t, err)
+
+	// text converted once is not converted again
+	require.NoError(t, Set(m, "PID-3", "V2"))
+	s, err := Get(m, "PID-5")
+	require.NoError(t, err)
+	require.Equal(t, "MÜLLER", s)
+	require.Contains(t, string(m.Bytes()), "|MÜLLER\r")
+}
+
+func TestGetSet_Struct(t *testing.T) {
+	var m v23.ORU_R01
+	require.NoError(t, Unmarshal(oruMultipleOrdersMsg, &m))
+
+	get := func(path string) string {
+		t.Helper()
+		s, err := Get(&m, path)
+		require.NoError(t, err)
+		return s
+	}
+
+	require.Equal(t, "ORU", get("MSH-9-1"))
+	require.Equal(t, "BANANA", get("PID-5-1"))
+	require.Equal(t, "V00272475", get("PID-3-1"))
+	require.Equal(t, "US Pelvis Non-OB", get("OBR-4-2"))
+	require.Equal(t, "US Doppler Pelvis", get("ORDER(2)/OBR-4-2"))
+	require.Equal(t, "US Doppler Pelvis", get("ObsOrderGroup(2)/OBR-4-2"))
+	require.Equal(t, "DATE:  12/15/2025", get("OBX(3)-5"))
+	require.Equal(t, "DATE:  12/15/2025", get("ORDER(2)/OBSERVATION(3)/OBX-5"))
+	require.Equal(t, "Graham", get("OBR-32-1-2"))
+	require.Equal(t, "", get("ORDER(3)/OBR-4-2"))
+
+	require.NoError(t, Set(&m, "ORDER(2)/OBR-4-2", "US Pelvis^Doppler"))
+	require.Equal(t, "US Pelvis^Doppler", m.Results[0].Order[1].OBR.UniversalServiceId.Text)
+	require.NoError(t, Set(&m, "OBX(32)-5", "addendum"))
+	require.Len(t, m.Results[0].Order[1].Observation, 32)
+	require.Equal(t, []string{"addendum"}, m.Results[0].Order[1].Observation[31].OBX.ObservationValue)
+
+	var pathErr *PathError
+	require.ErrorAs(t, Set(&m, "OBX(40)-5", "x"), &pathErr)
+	require.ErrorAs(t, Set(&m, "PID-99", "x"), &pathErr)
+	require.ErrorAs(t, Set(&m, "PID-1(2)", "x"), &pathErr)
+	_, err := Get(v23.ORU_R01{}, "PID-3")
+	require.ErrorAs(t, err, &pathErr)
+
+	// building a message from scratch
+	var out v23.ORU_R01
+	require.NoError(t, Set(&out, "MSH-9-1", "ORU"))
+	require.NoError(t, Set(&out, "PID-3-1", "V1"))
+	require.NoError(t, Set(&out, "ORDER/OBR-4-1", "CXR"))
+	require.NoError(t, Set(&out, "OBX-5", "first"))
+	require.NoError(t, Set(&out, "OBX(2)-5", "second"))
+	require.Equal(t, "V1", out.Results[0].PID.InternalPatientId[0].Id)
+	require.Len(t, out.Results[0].Order, 1)
+	require.Equal(t, "CXR", out.Results[0].Order[0].OBR.UniversalServiceId.Identifier)
+	require.Len(t, out.Results[0].Order[0].Observation, 2)
+	require.Equal(t, []string{"second"}, out.Results[0].Order[0].Observation[1].OBX.ObservationValue)
+}
+
+func TestGetSet_Nullable(t *testing.T) {
+	var m nullMsg
+	require.NoError(t, Unmarshal([]byte(nullInput), &m))
+
+	get := func(path string) string {
+		t.Helper()
+		s, err := Get(&m, path)
+		require.NoError(t, err)
+		return s
+	}
+
+	require.Equal(t, "DOE^\"\"", get("PID-5"))
+	require.Equal(t, "\"\"", get("PID-5-2"))
+	require.Equal(t, "\"\"", get("PID-6"))
+	require.Equal(t, "\"\"", get("PID-6-1"))
+	require.Equal(t, "", get("PID-7"))
+	require.Equal(t, "CA", get("PID-26(2)"))
+
+	require.NoError(t, Set(&m, "PID-5-2", "JANE"))
+	require.NoError(t, Set(&m, "PID-6", "ROE"))
+	require.NoError(t, Set(&m, "PID-7", "19700101"))
+	require.NoError(t, Set(&m, "PID-26", `""`))
+	require.Equal(t, Nullable[string]{Value: "JANE", Presence: Present}, m.PID.Name.Value.Given)
+	require.Equal(t, "ROE", get("PID-6"))
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3\r"+
+		"PID|1||||DOE^JANE|ROE|19700101|\"\"||||||||||||||||||\"\"\r", string(b))
+
+	// an empty value makes it absent; a component makes its field present
+	var out nullMsg
+	require.NoError(t, Set(&out, "PID-6", ""))
+	require.Equal(t, Absent, out.PID.Mother.Presence)
+	require.NoError(t, Set(&out, "PID-5-1", "DOE"))
+	require.Equal(t, Present, out.PID.Name.Presence)
+	s, err := Get(&out, "PID-5")
+	require.NoError(t, err)
+	require.Equal(t, "DOE", s)
+}
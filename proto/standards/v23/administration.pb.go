@@ -109,18 +109,18 @@ type PID struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
 	SetId                  string                 `protobuf:"bytes,1,opt,name=set_id,json=setId,proto3" json:"set_id,omitempty"`
 	ExternalPatientId      *CX                    `protobuf:"bytes,2,opt,name=external_patient_id,json=externalPatientId,proto3" json:"external_patient_id,omitempty"`
-	InternalPatientId      *CX                    `protobuf:"bytes,3,opt,name=internal_patient_id,json=internalPatientId,proto3" json:"internal_patient_id,omitempty"`
-	AlternatePatientId     *CX                    `protobuf:"bytes,4,opt,name=alternate_patient_id,json=alternatePatientId,proto3" json:"alternate_patient_id,omitempty"`
-	PatientName            *XPN                   `protobuf:"bytes,5,opt,name=patient_name,json=patientName,proto3" json:"patient_name,omitempty"`
+	InternalPatientId      []*CX                  `protobuf:"bytes,3,rep,name=internal_patient_id,json=internalPatientId,proto3" json:"internal_patient_id,omitempty"`
+	AlternatePatientId     []*CX                  `protobuf:"bytes,4,rep,name=alternate_patient_id,json=alternatePatientId,proto3" json:"alternate_patient_id,omitempty"`
+	PatientName            []*XPN                 `protobuf:"bytes,5,rep,name=patient_name,json=patientName,proto3" json:"patient_name,omitempty"`
 	MotherMaidenName       *XPN                   `protobuf:"bytes,6,opt,name=mother_maiden_name,json=motherMaidenName,proto3" json:"mother_maiden_name,omitempty"`
 	Dob                    string                 `protobuf:"bytes,7,opt,name=dob,proto3" json:"dob,omitempty"`
 	Sex                    string                 `protobuf:"bytes,8,opt,name=sex,proto3" json:"sex,omitempty"`
-	PatientAlias           *XPN                   `protobuf:"bytes,9,opt,name=patient_alias,json=patientAlias,proto3" json:"patient_alias,omitempty"`
+	PatientAlias           []*XPN                 `protobuf:"bytes,9,rep,name=patient_alias,json=patientAlias,proto3" json:"patient_alias,omitempty"`
 	Race                   string                 `protobuf:"bytes,10,opt,name=race,proto3" json:"race,omitempty"`
-	PatientAddress         *XAD                   `protobuf:"bytes,11,opt,name=patient_address,json=patientAddress,proto3" json:"patient_address,omitempty"`
+	PatientAddress         []*XAD                 `protobuf:"bytes,11,rep,name=patient_address,json=patientAddress,proto3" json:"patient_address,omitempty"`
 	CountyCode             string                 `protobuf:"bytes,12,opt,name=county_code,json=countyCode,proto3" json:"county_code,omitempty"`
-	HomePhoneNumber        *XTN                   `protobuf:"bytes,13,opt,name=home_phone_number,json=homePhoneNumber,proto3" json:"home_phone_number,omitempty"`
-	WorkPhoneNumber        *XTN                   `protobuf:"bytes,14,opt,name=work_phone_number,json=workPhoneNumber,proto3" json:"work_phone_number,omitempty"`
+	HomePhoneNumber        []*XTN                 `protobuf:"bytes,13,rep,name=home_phone_number,json=homePhoneNumber,proto3" json:"home_phone_number,omitempty"`
+	WorkPhoneNumber        []*XTN                 `protobuf:"bytes,14,rep,name=work_phone_number,json=workPhoneNumber,proto3" json:"work_phone_number,omitempty"`
 	PrimaryLanguage        *CE                    `protobuf:"bytes,15,opt,name=primary_language,json=primaryLanguage,proto3" json:"primary_language,omitempty"`
 	MaritalStatus          string                 `protobuf:"bytes,16,opt,name=marital_status,json=maritalStatus,proto3" json:"marital_status,omitempty"`
 	Religion               string                 `protobuf:"bytes,17,opt,name=religion,proto3" json:"religion,omitempty"`
@@ -132,7 +132,7 @@ type PID struct {
 	BirthPlace             string                 `protobuf:"bytes,23,opt,name=birth_place,json=birthPlace,proto3" json:"birth_place,omitempty"`
 	MultipleBirthIndicator string                 `protobuf:"bytes,24,opt,name=multiple_birth_indicator,json=multipleBirthIndicator,proto3" json:"multiple_birth_indicator,omitempty"`
 	BirthOrder             string                 `protobuf:"bytes,25,opt,name=birth_order,json=birthOrder,proto3" json:"birth_order,omitempty"`
-	Citizenship            string                 `protobuf:"bytes,26,opt,name=citizenship,proto3" json:"citizenship,omitempty"`
+	Citizenship            []string               `protobuf:"bytes,26,rep,name=citizenship,proto3" json:"citizenship,omitempty"`
 	VeteranStatus          *CE                    `protobuf:"bytes,27,opt,name=veteran_status,json=veteranStatus,proto3" json:"veteran_status,omitempty"`
 	Nationality            *CE                    `protobuf:"bytes,28,opt,name=nationality,proto3" json:"nationality,omitempty"`
 	PatientDeathDateTime   string                 `protobuf:"bytes,29,opt,name=patient_death_date_time,json=patientDeathDateTime,proto3" json:"patient_death_date_time,omitempty"`
@@ -185,21 +185,21 @@ func (x *PID) GetExternalPatientId() *CX {
 	return nil
 }
 
-func (x *PID) GetInternalPatientId() *CX {
+func (x *PID) GetInternalPatientId() []*CX {
 	if x != nil {
 		return x.InternalPatientId
 	}
 	return nil
 }
 
-func (x *PID) GetAlternatePatientId() *CX {
+func (x *PID) GetAlternatePatientId() []*CX {
 	if x != nil {
 		return x.AlternatePatientId
 	}
 	return nil
 }
 
-func (x *PID) GetPatientName() *XPN {
+func (x *PID) GetPatientName() []*XPN {
 	if x != nil {
 		return x.PatientName
 	}
@@ -227,7 +227,7 @@ func (x *PID) GetSex() string {
 	return ""
 }
 
-func (x *PID) GetPatientAlias() *XPN {
+func (x *PID) GetPatientAlias() []*XPN {
 	if x != nil {
 		return x.PatientAlias
 	}
@@ -241,7 +241,7 @@ func (x *PID) GetRace() string {
 	return ""
 }
 
-func (x *PID) GetPatientAddress() *XAD {
+func (x *PID) GetPatientAddress() []*XAD {
 	if x != nil {
 		return x.PatientAddress
 	}
@@ -255,14 +255,14 @@ func (x *PID) GetCountyCode() string {
 	return ""
 }
 
-func (x *PID) GetHomePhoneNumber() *XTN {
+func (x *PID) GetHomePhoneNumber() []*XTN {
 	if x != nil {
 		return x.HomePhoneNumber
 	}
 	return nil
 }
 
-func (x *PID) GetWorkPhoneNumber() *XTN {
+func (x *PID) GetWorkPhoneNumber() []*XTN {
 	if x != nil {
 		return x.WorkPhoneNumber
 	}
@@ -346,11 +346,11 @@ func (x *PID) GetBirthOrder() string {
 	return ""
 }
 
-func (x *PID) GetCitizenship() string {
+func (x *PID) GetCitizenship() []string {
 	if x != nil {
 		return x.Citizenship
 	}
-	return ""
+	return nil
 }
 
 func (x *PID) GetVeteranStatus() *CE {
@@ -385,8 +385,8 @@ type PD1 struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
 	LivingDependency       string                 `protobuf:"bytes,1,opt,name=living_dependency,json=livingDependency,proto3" json:"living_dependency,omitempty"`
 	LivingArrangement      string                 `protobuf:"bytes,2,opt,name=living_arrangement,json=livingArrangement,proto3" json:"living_arrangement,omitempty"`
-	PatientPrimaryFacility *XON                   `protobuf:"bytes,3,opt,name=patient_primary_facility,json=patientPrimaryFacility,proto3" json:"patient_primary_facility,omitempty"`
-	PatientPcpName         *XCN                   `protobuf:"bytes,4,opt,name=patient_pcp_name,json=patientPcpName,proto3" json:"patient_pcp_name,omitempty"`
+	PatientPrimaryFacility []*XON                 `protobuf:"bytes,3,rep,name=patient_primary_facility,json=patientPrimaryFacility,proto3" json:"patient_primary_facility,omitempty"`
+	PatientPcpName         []*XCN                 `protobuf:"bytes,4,rep,name=patient_pcp_name,json=patientPcpName,proto3" json:"patient_pcp_name,omitempty"`
 	StudentIndicator       string                 `protobuf:"bytes,5,opt,name=student_indicator,json=studentIndicator,proto3" json:"student_indicator,omitempty"`
 	Handicap               string                 `protobuf:"bytes,6,opt,name=handicap,proto3" json:"handicap,omitempty"`
 	LivingWill             string                 `protobuf:"bytes,7,opt,name=living_will,json=livingWill,proto3" json:"living_will,omitempty"`
@@ -443,14 +443,14 @@ func (x *PD1) GetLivingArrangement() string {
 	return ""
 }
 
-func (x *PD1) GetPatientPrimaryFacility() *XON {
+func (x *PD1) GetPatientPrimaryFacility() []*XON {
 	if x != nil {
 		return x.PatientPrimaryFacility
 	}
 	return nil
 }
 
-func (x *PD1) GetPatientPcpName() *XCN {
+func (x *PD1) GetPatientPcpName() []*XCN {
 	if x != nil {
 		return x.PatientPcpName
 	}
@@ -519,7 +519,7 @@ type AL1 struct {
 	AllergyType        string                 `protobuf:"bytes,2,opt,name=allergy_type,json=allergyType,proto3" json:"allergy_type,omitempty"`
 	AllergyCode        *CE                    `protobuf:"bytes,3,opt,name=allergy_code,json=allergyCode,proto3" json:"allergy_code,omitempty"`
 	AllergySeverity    string                 `protobuf:"bytes,4,opt,name=allergy_severity,json=allergySeverity,proto3" json:"allergy_severity,omitempty"`
-	AllergyReaction    string                 `protobuf:"bytes,5,opt,name=allergy_reaction,json=allergyReaction,proto3" json:"allergy_reaction,omitempty"`
+	AllergyReaction    []string               `protobuf:"bytes,5,rep,name=allergy_reaction,json=allergyReaction,proto3" json:"allergy_reaction,omitempty"`
 	IdentificationDate string                 `protobuf:"bytes,6,opt,name=identification_date,json=identificationDate,proto3" json:"identification_date,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
@@ -583,11 +583,11 @@ func (x *AL1) GetAllergySeverity() string {
 	return ""
 }
 
-func (x *AL1) GetAllergyReaction() string {
+func (x *AL1) GetAllergyReaction() []string {
 	if x != nil {
 		return x.AllergyReaction
 	}
-	return ""
+	return nil
 }
 
 func (x *AL1) GetIdentificationDate() string {
@@ -605,9 +605,9 @@ type PV1 struct {
 	AdmissionType           string                 `protobuf:"bytes,4,opt,name=admission_type,json=admissionType,proto3" json:"admission_type,omitempty"`
 	PreadmitNumber          *CX                    `protobuf:"bytes,5,opt,name=preadmit_number,json=preadmitNumber,proto3" json:"preadmit_number,omitempty"`
 	PriorPatientLocation    *PL                    `protobuf:"bytes,6,opt,name=prior_patient_location,json=priorPatientLocation,proto3" json:"prior_patient_location,omitempty"`
-	AttendingDoctor         *XCN                   `protobuf:"bytes,7,opt,name=attending_doctor,json=attendingDoctor,proto3" json:"attending_doctor,omitempty"`
-	ReferringDoctor         *XCN                   `protobuf:"bytes,8,opt,name=referring_doctor,json=referringDoctor,proto3" json:"referring_doctor,omitempty"`
-	ConsultingDoctor        *XCN                   `protobuf:"bytes,9,opt,name=consulting_doctor,json=consultingDoctor,proto3" json:"consulting_doctor,omitempty"`
+	AttendingDoctor         []*XCN                 `protobuf:"bytes,7,rep,name=attending_doctor,json=attendingDoctor,proto3" json:"attending_doctor,omitempty"`
+	ReferringDoctor         []*XCN                 `protobuf:"bytes,8,rep,name=referring_doctor,json=referringDoctor,proto3" json:"referring_doctor,omitempty"`
+	ConsultingDoctor        []*XCN                 `protobuf:"bytes,9,rep,name=consulting_doctor,json=consultingDoctor,proto3" json:"consulting_doctor,omitempty"`
 	HospitalService         string                 `protobuf:"bytes,10,opt,name=hospital_service,json=hospitalService,proto3" json:"hospital_service,omitempty"`
 	TemporaryLocation       *PL                    `protobuf:"bytes,11,opt,name=temporary_location,json=temporaryLocation,proto3" json:"temporary_location,omitempty"`
 	PreadmitTestIndicator   string                 `protobuf:"bytes,12,opt,name=preadmit_test_indicator,json=preadmitTestIndicator,proto3" json:"preadmit_test_indicator,omitempty"`
@@ -615,7 +615,7 @@ type PV1 struct {
 	AdmitSource             string                 `protobuf:"bytes,14,opt,name=admit_source,json=admitSource,proto3" json:"admit_source,omitempty"`
 	AmbulatoryStatus        string                 `protobuf:"bytes,15,opt,name=ambulatory_status,json=ambulatoryStatus,proto3" json:"ambulatory_status,omitempty"`
 	VipIndicator            string                 `protobuf:"bytes,16,opt,name=vip_indicator,json=vipIndicator,proto3" json:"vip_indicator,omitempty"`
-	AdmittingDoctor         *XCN                   `protobuf:"bytes,17,opt,name=admitting_doctor,json=admittingDoctor,proto3" json:"admitting_doctor,omitempty"`
+	AdmittingDoctor         []*XCN                 `protobuf:"bytes,17,rep,name=admitting_doctor,json=admittingDoctor,proto3" json:"admitting_doctor,omitempty"`
 	PatientType             string                 `protobuf:"bytes,18,opt,name=patient_type,json=patientType,proto3" json:"patient_type,omitempty"`
 	VisitNumber             *CX                    `protobuf:"bytes,19,opt,name=visit_number,json=visitNumber,proto3" json:"visit_number,omitempty"`
 	FinancialClass          *FC                    `protobuf:"bytes,20,opt,name=financial_class,json=financialClass,proto3" json:"financial_class,omitempty"`
@@ -650,7 +650,7 @@ type PV1 struct {
 	TotalPayments           string                 `protobuf:"bytes,49,opt,name=total_payments,json=totalPayments,proto3" json:"total_payments,omitempty"`
 	AlternateVisitId        *CX                    `protobuf:"bytes,50,opt,name=alternate_visit_id,json=alternateVisitId,proto3" json:"alternate_visit_id,omitempty"`
 	VisitIndicator          string                 `protobuf:"bytes,51,opt,name=visit_indicator,json=visitIndicator,proto3" json:"visit_indicator,omitempty"`
-	OtherHealthcareProvider *XCN                   `protobuf:"bytes,52,opt,name=other_healthcare_provider,json=otherHealthcareProvider,proto3" json:"other_healthcare_provider,omitempty"`
+	OtherHealthcareProvider []*XCN                 `protobuf:"bytes,52,rep,name=other_healthcare_provider,json=otherHealthcareProvider,proto3" json:"other_healthcare_provider,omitempty"`
 	unknownFields           protoimpl.UnknownFields
 	sizeCache               protoimpl.SizeCache
 }
@@ -727,21 +727,21 @@ func (x *PV1) GetPriorPatientLocation() *PL {
 	return nil
 }
 
-func (x *PV1) GetAttendingDoctor() *XCN {
+func (x *PV1) GetAttendingDoctor() []*XCN {
 	if x != nil {
 		return x.AttendingDoctor
 	}
 	return nil
 }
 
-func (x *PV1) GetReferringDoctor() *XCN {
+func (x *PV1) GetReferringDoctor() []*XCN {
 	if x != nil {
 		return x.ReferringDoctor
 	}
 	return nil
 }
 
-func (x *PV1) GetConsultingDoctor() *XCN {
+func (x *PV1) GetConsultingDoctor() []*XCN {
 	if x != nil {
 		return x.ConsultingDoctor
 	}
@@ -797,7 +797,7 @@ func (x *PV1) GetVipIndicator() string {
 	return ""
 }
 
-func (x *PV1) GetAdmittingDoctor() *XCN {
+func (x *PV1) GetAdmittingDoctor() []*XCN {
 	if x != nil {
 		return x.AdmittingDoctor
 	}
@@ -1042,7 +1042,7 @@ func (x *PV1) GetVisitIndicator() string {
 	return ""
 }
 
-func (x *PV1) GetOtherHealthcareProvider() *XCN {
+func (x *PV1) GetOtherHealthcareProvider() []*XCN {
 	if x != nil {
 		return x.OtherHealthcareProvider
 	}
@@ -1398,20 +1398,20 @@ const file_standards_v23_administration_proto_rawDesc = "" +
 	"\x03PID\x12\x15\n" +
 	"\x06set_id\x18\x01 \x01(\tR\x05setId\x12A\n" +
 	"\x13external_patient_id\x18\x02 \x01(\v2\x11.standards.v23.CXR\x11externalPatientId\x12A\n" +
-	"\x13internal_patient_id\x18\x03 \x01(\v2\x11.standards.v23.CXR\x11internalPatientId\x12C\n" +
-	"\x14alternate_patient_id\x18\x04 \x01(\v2\x11.standards.v23.CXR\x12alternatePatientId\x125\n" +
-	"\fpatient_name\x18\x05 \x01(\v2\x12.standards.v23.XPNR\vpatientName\x12@\n" +
+	"\x13internal_patient_id\x18\x03 \x03(\v2\x11.standards.v23.CXR\x11internalPatientId\x12C\n" +
+	"\x14alternate_patient_id\x18\x04 \x03(\v2\x11.standards.v23.CXR\x12alternatePatientId\x125\n" +
+	"\fpatient_name\x18\x05 \x03(\v2\x12.standards.v23.XPNR\vpatientName\x12@\n" +
 	"\x12mother_maiden_name\x18\x06 \x01(\v2\x12.standards.v23.XPNR\x10motherMaidenName\x12\x10\n" +
 	"\x03dob\x18\a \x01(\tR\x03dob\x12\x10\n" +
 	"\x03sex\x18\b \x01(\tR\x03sex\x127\n" +
-	"\rpatient_alias\x18\t \x01(\v2\x12.standards.v23.XPNR\fpatientAlias\x12\x12\n" +
+	"\rpatient_alias\x18\t \x03(\v2\x12.standards.v23.XPNR\fpatientAlias\x12\x12\n" +
 	"\x04race\x18\n" +
 	" \x01(\tR\x04race\x12;\n" +
-	"\x0fpatient_address\x18\v \x01(\v2\x12.standards.v23.XADR\x0epatientAddress\x12\x1f\n" +
+	"\x0fpatient_address\x18\v \x03(\v2\x12.standards.v23.XADR\x0epatientAddress\x12\x1f\n" +
 	"\vcounty_code\x18\f \x01(\tR\n" +
 	"countyCode\x12>\n" +
-	"\x11home_phone_number\x18\r \x01(\v2\x12.standards.v23.XTNR\x0fhomePhoneNumber\x12>\n" +
-	"\x11work_phone_number\x18\x0e \x01(\v2\x12.standards.v23.XTNR\x0fworkPhoneNumber\x12<\n" +
+	"\x11home_phone_number\x18\r \x03(\v2\x12.standards.v23.XTNR\x0fhomePhoneNumber\x12>\n" +
+	"\x11work_phone_number\x18\x0e \x03(\v2\x12.standards.v23.XTNR\x0fworkPhoneNumber\x12<\n" +
 	"\x10primary_language\x18\x0f \x01(\v2\x11.standards.v23.CER\x0fprimaryLanguage\x12%\n" +
 	"\x0emarital_status\x18\x10 \x01(\tR\rmaritalStatus\x12\x1a\n" +
 	"\breligion\x18\x11 \x01(\tR\breligion\x12G\n" +
@@ -1425,7 +1425,7 @@ const file_standards_v23_administration_proto_rawDesc = "" +
 	"\x18multiple_birth_indicator\x18\x18 \x01(\tR\x16multipleBirthIndicator\x12\x1f\n" +
 	"\vbirth_order\x18\x19 \x01(\tR\n" +
 	"birthOrder\x12 \n" +
-	"\vcitizenship\x18\x1a \x01(\tR\vcitizenship\x128\n" +
+	"\vcitizenship\x18\x1a \x03(\tR\vcitizenship\x128\n" +
 	"\x0eveteran_status\x18\x1b \x01(\v2\x11.standards.v23.CER\rveteranStatus\x123\n" +
 	"\vnationality\x18\x1c \x01(\v2\x11.standards.v23.CER\vnationality\x125\n" +
 	"\x17patient_death_date_time\x18\x1d \x01(\tR\x14patientDeathDateTime\x126\n" +
@@ -1433,8 +1433,8 @@ const file_standards_v23_administration_proto_rawDesc = "" +
 	"\x03PD1\x12+\n" +
 	"\x11living_dependency\x18\x01 \x01(\tR\x10livingDependency\x12-\n" +
 	"\x12living_arrangement\x18\x02 \x01(\tR\x11livingArrangement\x12L\n" +
-	"\x18patient_primary_facility\x18\x03 \x01(\v2\x12.standards.v23.XONR\x16patientPrimaryFacility\x12<\n" +
-	"\x10patient_pcp_name\x18\x04 \x01(\v2\x12.standards.v23.XCNR\x0epatientPcpName\x12+\n" +
+	"\x18patient_primary_facility\x18\x03 \x03(\v2\x12.standards.v23.XONR\x16patientPrimaryFacility\x12<\n" +
+	"\x10patient_pcp_name\x18\x04 \x03(\v2\x12.standards.v23.XCNR\x0epatientPcpName\x12+\n" +
 	"\x11student_indicator\x18\x05 \x01(\tR\x10studentIndicator\x12\x1a\n" +
 	"\bhandicap\x18\x06 \x01(\tR\bhandicap\x12\x1f\n" +
 	"\vliving_will\x18\a \x01(\tR\n" +
@@ -1451,7 +1451,7 @@ const file_standards_v23_administration_proto_rawDesc = "" +
 	"\fallergy_type\x18\x02 \x01(\tR\vallergyType\x124\n" +
 	"\fallergy_code\x18\x03 \x01(\v2\x11.standards.v23.CER\vallergyCode\x12)\n" +
 	"\x10allergy_severity\x18\x04 \x01(\tR\x0fallergySeverity\x12)\n" +
-	"\x10allergy_reaction\x18\x05 \x01(\tR\x0fallergyReaction\x12/\n" +
+	"\x10allergy_reaction\x18\x05 \x03(\tR\x0fallergyReaction\x12/\n" +
 	"\x13identification_date\x18\x06 \x01(\tR\x12identificationDate\"\xca\x14\n" +
 	"\x03PV1\x12\x15\n" +
 	"\x06set_id\x18\x01 \x01(\tR\x05setId\x12#\n" +
@@ -1460,9 +1460,9 @@ const file_standards_v23_administration_proto_rawDesc = "" +
 	"\x0eadmission_type\x18\x04 \x01(\tR\radmissionType\x12:\n" +
 	"\x0fpreadmit_number\x18\x05 \x01(\v2\x11.standards.v23.CXR\x0epreadmitNumber\x12G\n" +
 	"\x16prior_patient_location\x18\x06 \x01(\v2\x11.standards.v23.PLR\x14priorPatientLocation\x12=\n" +
-	"\x10attending_doctor\x18\a \x01(\v2\x12.standards.v23.XCNR\x0fattendingDoctor\x12=\n" +
-	"\x10referring_doctor\x18\b \x01(\v2\x12.standards.v23.XCNR\x0freferringDoctor\x12?\n" +
-	"\x11consulting_doctor\x18\t \x01(\v2\x12.standards.v23.XCNR\x10consultingDoctor\x12)\n" +
+	"\x10attending_doctor\x18\a \x03(\v2\x12.standards.v23.XCNR\x0fattendingDoctor\x12=\n" +
+	"\x10referring_doctor\x18\b \x03(\v2\x12.standards.v23.XCNR\x0freferringDoctor\x12?\n" +
+	"\x11consulting_doctor\x18\t \x03(\v2\x12.standards.v23.XCNR\x10consultingDoctor\x12)\n" +
 	"\x10hospital_service\x18\n" +
 	" \x01(\tR\x0fhospitalService\x12@\n" +
 	"\x12temporary_location\x18\v \x01(\v2\x11.standards.v23.PLR\x11temporaryLocation\x126\n" +
@@ -1471,7 +1471,7 @@ const file_standards_v23_administration_proto_rawDesc = "" +
 	"\fadmit_source\x18\x0e \x01(\tR\vadmitSource\x12+\n" +
 	"\x11ambulatory_status\x18\x0f \x01(\tR\x10ambulatoryStatus\x12#\n" +
 	"\rvip_indicator\x18\x10 \x01(\tR\fvipIndicator\x12=\n" +
-	"\x10admitting_doctor\x18\x11 \x01(\v2\x12.standards.v23.XCNR\x0fadmittingDoctor\x12!\n" +
+	"\x10admitting_doctor\x18\x11 \x03(\v2\x12.standards.v23.XCNR\x0fadmittingDoctor\x12!\n" +
 	"\fpatient_type\x18\x12 \x01(\tR\vpatientType\x124\n" +
 	"\fvisit_number\x18\x13 \x01(\v2\x11.standards.v23.CXR\vvisitNumber\x12:\n" +
 	"\x0ffinancial_class\x18\x14 \x01(\v2\x11.standards.v23.FCR\x0efinancialClass\x124\n" +
@@ -1507,7 +1507,7 @@ const file_standards_v23_administration_proto_rawDesc = "" +
 	"\x0etotal_payments\x181 \x01(\tR\rtotalPayments\x12?\n" +
 	"\x12alternate_visit_id\x182 \x01(\v2\x11.standards.v23.CXR\x10alternateVisitId\x12'\n" +
 	"\x0fvisit_indicator\x183 \x01(\tR\x0evisitIndicator\x12N\n" +
-	"\x19other_healthcare_provider\x184 \x01(\v2\x12.standards.v23.XCNR\x17otherHealthcareProvider\"\xeb\x10\n" +
+	"\x19other_healthcare_provider\x184 \x03(\v2\x12.standards.v23.XCNR\x17otherHealthcareProvider\"\xeb\x10\n" +
 	"\x03PV2\x12G\n" +
 	"\x16prior_pending_location\x18\x01 \x01(\v2\x11.standards.v23.PLR\x14priorPendingLocation\x12>\n" +
 	"\x11accomodation_code\x18\x02 \x01(\v2\x11.standards.v23.CER\x10accomodationCode\x124\n" +
@@ -22,27 +22,28 @@ const (
 )
 
 type MSH struct {
-	state                     protoimpl.MessageState `protogen:"open.v1"`
-	FieldDelimiter            string                 `protobuf:"bytes,1,opt,name=field_delimiter,json=fieldDelimiter,proto3" json:"field_delimiter,omitempty"`
-	EncodingCharacters        string                 `protobuf:"bytes,2,opt,name=encoding_characters,json=encodingCharacters,proto3" json:"encoding_characters,omitempty"`
-	SendingApplication        string                 `protobuf:"bytes,3,opt,name=sending_application,json=sendingApplication,proto3" json:"sending_application,omitempty"`
-	SendingFacility           string                 `protobuf:"bytes,4,opt,name=sending_facility,json=sendingFacility,proto3" json:"sending_facility,omitempty"`
-	ReceivingApplication      string                 `protobuf:"bytes,5,opt,name=receiving_application,json=receivingApplication,proto3" json:"receiving_application,omitempty"`
-	ReceivingFacility         string                 `protobuf:"bytes,6,opt,name=receiving_facility,json=receivingFacility,proto3" json:"receiving_facility,omitempty"`
-	DateTime                  string                 `protobuf:"bytes,7,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
-	Security                  string                 `protobuf:"bytes,8,opt,name=security,proto3" json:"security,omitempty"`
-	MessageType               *CMMSG                 `protobuf:"bytes,9,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
-	ControlId                 string                 `protobuf:"bytes,10,opt,name=control_id,json=controlId,proto3" json:"control_id,omitempty"`
-	ProcessingId              string                 `protobuf:"bytes,11,opt,name=processing_id,json=processingId,proto3" json:"processing_id,omitempty"`
-	VersionId                 string                 `protobuf:"bytes,12,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
-	SequenceNumber            string                 `protobuf:"bytes,13,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
-	ContinuationPointer       string                 `protobuf:"bytes,14,opt,name=continuation_pointer,json=continuationPointer,proto3" json:"continuation_pointer,omitempty"`
-	AcceptAcknowledgementType string                 `protobuf:"bytes,15,opt,name=accept_acknowledgement_type,json=acceptAcknowledgementType,proto3" json:"accept_acknowledgement_type,omitempty"`
-	CountryCode               string                 `protobuf:"bytes,16,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
-	CharacterSet              string                 `protobuf:"bytes,17,opt,name=character_set,json=characterSet,proto3" json:"character_set,omitempty"`
-	PrincipalLanguage         string                 `protobuf:"bytes,18,opt,name=principal_language,json=principalLanguage,proto3" json:"principal_language,omitempty"`
-	unknownFields             protoimpl.UnknownFields
-	sizeCache                 protoimpl.SizeCache
+	state                          protoimpl.MessageState `protogen:"open.v1"`
+	FieldDelimiter                 string                 `protobuf:"bytes,1,opt,name=field_delimiter,json=fieldDelimiter,proto3" json:"field_delimiter,omitempty"`
+	EncodingCharacters             string                 `protobuf:"bytes,2,opt,name=encoding_characters,json=encodingCharacters,proto3" json:"encoding_characters,omitempty"`
+	SendingApplication             string                 `protobuf:"bytes,3,opt,name=sending_application,json=sendingApplication,proto3" json:"sending_application,omitempty"`
+	SendingFacility                string                 `protobuf:"bytes,4,opt,name=sending_facility,json=sendingFacility,proto3" json:"sending_facility,omitempty"`
+	ReceivingApplication           string                 `protobuf:"bytes,5,opt,name=receiving_application,json=receivingApplication,proto3" json:"receiving_application,omitempty"`
+	ReceivingFacility              string                 `protobuf:"bytes,6,opt,name=receiving_facility,json=receivingFacility,proto3" json:"receiving_facility,omitempty"`
+	DateTime                       string                 `protobuf:"bytes,7,opt,name=date_time,json=dateTime,proto3" json:"date_time,omitempty"`
+	Security                       string                 `protobuf:"bytes,8,opt,name=security,proto3" json:"security,omitempty"`
+	MessageType                    *CMMSG                 `protobuf:"bytes,9,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	ControlId                      string                 `protobuf:"bytes,10,opt,name=control_id,json=controlId,proto3" json:"control_id,omitempty"`
+	ProcessingId                   string                 `protobuf:"bytes,11,opt,name=processing_id,json=processingId,proto3" json:"processing_id,omitempty"`
+	VersionId                      string                 `protobuf:"bytes,12,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	SequenceNumber                 string                 `protobuf:"bytes,13,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	ContinuationPointer            string                 `protobuf:"bytes,14,opt,name=continuation_pointer,json=continuationPointer,proto3" json:"continuation_pointer,omitempty"`
+	AcceptAcknowledgementType      string                 `protobuf:"bytes,15,opt,name=accept_acknowledgement_type,json=acceptAcknowledgementType,proto3" json:"accept_acknowledgement_type,omitempty"`
+	ApplicationAcknowledgementType string                 `protobuf:"bytes,19,opt,name=application_acknowledgement_type,json=applicationAcknowledgementType,proto3" json:"application_acknowledgement_type,omitempty"`
+	CountryCode                    string                 `protobuf:"bytes,16,opt,name=country_code,json=countryCode,proto3" json:"country_code,omitempty"`
+	CharacterSet                   string                 `protobuf:"bytes,17,opt,name=character_set,json=characterSet,proto3" json:"character_set,omitempty"`
+	PrincipalLanguage              string                 `protobuf:"bytes,18,opt,name=principal_language,json=principalLanguage,proto3" json:"principal_language,omitempty"`
+	unknownFields                  protoimpl.UnknownFields
+	sizeCache                      protoimpl.SizeCache
 }
 
 func (x *MSH) Reset() {
@@ -180,6 +181,13 @@ func (x *MSH) GetAcceptAcknowledgementType() string {
 	return ""
 }
 
+func (x *MSH) GetApplicationAcknowledgementType() string {
+	if x != nil {
+		return x.ApplicationAcknowledgementType
+	}
+	return ""
+}
+
 func (x *MSH) GetCountryCode() string {
 	if x != nil {
 		return x.CountryCode
@@ -205,7 +213,7 @@ type NTE struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	SetId           string                 `protobuf:"bytes,1,opt,name=set_id,json=setId,proto3" json:"set_id,omitempty"`
 	SourceOfComment string                 `protobuf:"bytes,2,opt,name=source_of_comment,json=sourceOfComment,proto3" json:"source_of_comment,omitempty"`
-	Comment         string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	Comment         []string               `protobuf:"bytes,3,rep,name=comment,proto3" json:"comment,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -254,11 +262,11 @@ func (x *NTE) GetSourceOfComment() string {
 	return ""
 }
 
-func (x *NTE) GetComment() string {
+func (x *NTE) GetComment() []string {
 	if x != nil {
 		return x.Comment
 	}
-	return ""
+	return nil
 }
 
 type DSC struct {
@@ -305,11 +313,139 @@ func (x *DSC) GetContinuationPointer() string {
 	return ""
 }
 
+type MSA struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	AcknowledgementCode        string                 `protobuf:"bytes,1,opt,name=acknowledgement_code,json=acknowledgementCode,proto3" json:"acknowledgement_code,omitempty"`
+	ControlId                  string                 `protobuf:"bytes,2,opt,name=control_id,json=controlId,proto3" json:"control_id,omitempty"`
+	TextMessage                string                 `protobuf:"bytes,3,opt,name=text_message,json=textMessage,proto3" json:"text_message,omitempty"`
+	ExpectedSequenceNumber     string                 `protobuf:"bytes,4,opt,name=expected_sequence_number,json=expectedSequenceNumber,proto3" json:"expected_sequence_number,omitempty"`
+	DelayedAcknowledgementType string                 `protobuf:"bytes,5,opt,name=delayed_acknowledgement_type,json=delayedAcknowledgementType,proto3" json:"delayed_acknowledgement_type,omitempty"`
+	ErrorCondition             *CE                    `protobuf:"bytes,6,opt,name=error_condition,json=errorCondition,proto3" json:"error_condition,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *MSA) Reset() {
+	*x = MSA{}
+	mi := &file_standards_v23_control_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MSA) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MSA) ProtoMessage() {}
+
+func (x *MSA) ProtoReflect() protoreflect.Message {
+	mi := &file_standards_v23_control_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MSA.ProtoReflect.Descriptor instead.
+func (*MSA) Descriptor() ([]byte, []int) {
+	return file_standards_v23_control_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MSA) GetAcknowledgementCode() string {
+	if x != nil {
+		return x.AcknowledgementCode
+	}
+	return ""
+}
+
+func (x *MSA) GetControlId() string {
+	if x != nil {
+		return x.ControlId
+	}
+	return ""
+}
+
+func (x *MSA) GetTextMessage() string {
+	if x != nil {
+		return x.TextMessage
+	}
+	return ""
+}
+
+func (x *MSA) GetExpectedSequenceNumber() string {
+	if x != nil {
+		return x.ExpectedSequenceNumber
+	}
+	return ""
+}
+
+func (x *MSA) GetDelayedAcknowledgementType() string {
+	if x != nil {
+		return x.DelayedAcknowledgementType
+	}
+	return ""
+}
+
+func (x *MSA) GetErrorCondition() *CE {
+	if x != nil {
+		return x.ErrorCondition
+	}
+	return nil
+}
+
+type ERR struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ErrorCodeAndLocation []*CMELD               `protobuf:"bytes,1,rep,name=error_code_and_location,json=errorCodeAndLocation,proto3" json:"error_code_and_location,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ERR) Reset() {
+	*x = ERR{}
+	mi := &file_standards_v23_control_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ERR) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ERR) ProtoMessage() {}
+
+func (x *ERR) ProtoReflect() protoreflect.Message {
+	mi := &file_standards_v23_control_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ERR.ProtoReflect.Descriptor instead.
+func (*ERR) Descriptor() ([]byte, []int) {
+	return file_standards_v23_control_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ERR) GetErrorCodeAndLocation() []*CMELD {
+	if x != nil {
+		return x.ErrorCodeAndLocation
+	}
+	return nil
+}
+
 var File_standards_v23_control_proto protoreflect.FileDescriptor
 
 const file_standards_v23_control_proto_rawDesc = "" +
 	"\n" +
-	"\x1bstandards/v23/control.proto\x12\rstandards.v23\x1a\x19standards/v23/types.proto\"\x87\x06\n" +
+	"\x1bstandards/v23/control.proto\x12\rstandards.v23\x1a\x19standards/v23/types.proto\"\xd1\x06\n" +
 	"\x03MSH\x12'\n" +
 	"\x0ffield_delimiter\x18\x01 \x01(\tR\x0efieldDelimiter\x12/\n" +
 	"\x13encoding_characters\x18\x02 \x01(\tR\x12encodingCharacters\x12/\n" +
@@ -328,16 +464,27 @@ const file_standards_v23_control_proto_rawDesc = "" +
 	"version_id\x18\f \x01(\tR\tversionId\x12'\n" +
 	"\x0fsequence_number\x18\r \x01(\tR\x0esequenceNumber\x121\n" +
 	"\x14continuation_pointer\x18\x0e \x01(\tR\x13continuationPointer\x12>\n" +
-	"\x1baccept_acknowledgement_type\x18\x0f \x01(\tR\x19acceptAcknowledgementType\x12!\n" +
+	"\x1baccept_acknowledgement_type\x18\x0f \x01(\tR\x19acceptAcknowledgementType\x12H\n" +
+	" application_acknowledgement_type\x18\x13 \x01(\tR\x1eapplicationAcknowledgementType\x12!\n" +
 	"\fcountry_code\x18\x10 \x01(\tR\vcountryCode\x12#\n" +
 	"\rcharacter_set\x18\x11 \x01(\tR\fcharacterSet\x12-\n" +
 	"\x12principal_language\x18\x12 \x01(\tR\x11principalLanguage\"b\n" +
 	"\x03NTE\x12\x15\n" +
 	"\x06set_id\x18\x01 \x01(\tR\x05setId\x12*\n" +
 	"\x11source_of_comment\x18\x02 \x01(\tR\x0fsourceOfComment\x12\x18\n" +
-	"\acomment\x18\x03 \x01(\tR\acomment\"8\n" +
+	"\acomment\x18\x03 \x03(\tR\acomment\"8\n" +
 	"\x03DSC\x121\n" +
-	"\x14continuation_pointer\x18\x01 \x01(\tR\x13continuationPointerB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
+	"\x14continuation_pointer\x18\x01 \x01(\tR\x13continuationPointer\"\xb2\x02\n" +
+	"\x03MSA\x121\n" +
+	"\x14acknowledgement_code\x18\x01 \x01(\tR\x13acknowledgementCode\x12\x1d\n" +
+	"\n" +
+	"control_id\x18\x02 \x01(\tR\tcontrolId\x12!\n" +
+	"\ftext_message\x18\x03 \x01(\tR\vtextMessage\x128\n" +
+	"\x18expected_sequence_number\x18\x04 \x01(\tR\x16expectedSequenceNumber\x12@\n" +
+	"\x1cdelayed_acknowledgement_type\x18\x05 \x01(\tR\x1adelayedAcknowledgementType\x12:\n" +
+	"\x0ferror_condition\x18\x06 \x01(\v2\x11.standards.v23.CER\x0eerrorCondition\"R\n" +
+	"\x03ERR\x12K\n" +
+	"\x17error_code_and_location\x18\x01 \x03(\v2\x14.standards.v23.CMELDR\x14errorCodeAndLocationB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
 
 var (
 	file_standards_v23_control_proto_rawDescOnce sync.Once
@@ -351,20 +498,26 @@ func file_standards_v23_control_proto_rawDescGZIP() []byte {
 	return file_standards_v23_control_proto_rawDescData
 }
 
-var file_standards_v23_control_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_standards_v23_control_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_standards_v23_control_proto_goTypes = []any{
 	(*MSH)(nil),   // 0: standards.v23.MSH
 	(*NTE)(nil),   // 1: standards.v23.NTE
 	(*DSC)(nil),   // 2: standards.v23.DSC
-	(*CMMSG)(nil), // 3: standards.v23.CMMSG
+	(*MSA)(nil),   // 3: standards.v23.MSA
+	(*ERR)(nil),   // 4: standards.v23.ERR
+	(*CMMSG)(nil), // 5: standards.v23.CMMSG
+	(*CE)(nil),    // 6: standards.v23.CE
+	(*CMELD)(nil), // 7: standards.v23.CMELD
 }
 var file_standards_v23_control_proto_depIdxs = []int32{
-	3, // 0: standards.v23.MSH.message_type:type_name -> standards.v23.CMMSG
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5, // 0: standards.v23.MSH.message_type:type_name -> standards.v23.CMMSG
+	6, // 1: standards.v23.MSA.error_condition:type_name -> standards.v23.CE
+	7, // 2: standards.v23.ERR.error_code_and_location:type_name -> standards.v23.CMELD
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_standards_v23_control_proto_init() }
@@ -379,7 +532,7 @@ func file_standards_v23_control_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_standards_v23_control_proto_rawDesc), len(file_standards_v23_control_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
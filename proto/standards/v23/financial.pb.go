@@ -25,11 +25,11 @@ type GT1 struct {
 	state                    protoimpl.MessageState `protogen:"open.v1"`
 	SetId                    string                 `protobuf:"bytes,1,opt,name=set_id,json=setId,proto3" json:"set_id,omitempty"`
 	GuarantorNumber          *CX                    `protobuf:"bytes,2,opt,name=guarantor_number,json=guarantorNumber,proto3" json:"guarantor_number,omitempty"`
-	Name                     *XPN                   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	SpouseName               *XPN                   `protobuf:"bytes,4,opt,name=spouse_name,json=spouseName,proto3" json:"spouse_name,omitempty"`
-	Address                  *XAD                   `protobuf:"bytes,5,opt,name=address,proto3" json:"address,omitempty"`
-	HomePhoneNumber          *XTN                   `protobuf:"bytes,6,opt,name=home_phone_number,json=homePhoneNumber,proto3" json:"home_phone_number,omitempty"`
-	WorkPhoneNumber          *XTN                   `protobuf:"bytes,7,opt,name=work_phone_number,json=workPhoneNumber,proto3" json:"work_phone_number,omitempty"`
+	Name                     []*XPN                 `protobuf:"bytes,3,rep,name=name,proto3" json:"name,omitempty"`
+	SpouseName               []*XPN                 `protobuf:"bytes,4,rep,name=spouse_name,json=spouseName,proto3" json:"spouse_name,omitempty"`
+	Address                  []*XAD                 `protobuf:"bytes,5,rep,name=address,proto3" json:"address,omitempty"`
+	HomePhoneNumber          []*XTN                 `protobuf:"bytes,6,rep,name=home_phone_number,json=homePhoneNumber,proto3" json:"home_phone_number,omitempty"`
+	WorkPhoneNumber          []*XTN                 `protobuf:"bytes,7,rep,name=work_phone_number,json=workPhoneNumber,proto3" json:"work_phone_number,omitempty"`
 	Dob                      string                 `protobuf:"bytes,8,opt,name=dob,proto3" json:"dob,omitempty"`
 	Sex                      string                 `protobuf:"bytes,9,opt,name=sex,proto3" json:"sex,omitempty"`
 	Type                     string                 `protobuf:"bytes,10,opt,name=type,proto3" json:"type,omitempty"`
@@ -38,12 +38,12 @@ type GT1 struct {
 	BeginDate                string                 `protobuf:"bytes,13,opt,name=begin_date,json=beginDate,proto3" json:"begin_date,omitempty"`
 	EndDate                  string                 `protobuf:"bytes,14,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
 	Priority                 string                 `protobuf:"bytes,15,opt,name=priority,proto3" json:"priority,omitempty"`
-	EmployerName             *XPN                   `protobuf:"bytes,16,opt,name=employer_name,json=employerName,proto3" json:"employer_name,omitempty"`
-	EmployerAddress          *XAD                   `protobuf:"bytes,17,opt,name=employer_address,json=employerAddress,proto3" json:"employer_address,omitempty"`
-	EmployerPhoneNumber      *XTN                   `protobuf:"bytes,18,opt,name=employer_phone_number,json=employerPhoneNumber,proto3" json:"employer_phone_number,omitempty"`
-	EmployeeIdNumber         *CX                    `protobuf:"bytes,19,opt,name=employee_id_number,json=employeeIdNumber,proto3" json:"employee_id_number,omitempty"`
+	EmployerName             []*XPN                 `protobuf:"bytes,16,rep,name=employer_name,json=employerName,proto3" json:"employer_name,omitempty"`
+	EmployerAddress          []*XAD                 `protobuf:"bytes,17,rep,name=employer_address,json=employerAddress,proto3" json:"employer_address,omitempty"`
+	EmployerPhoneNumber      []*XTN                 `protobuf:"bytes,18,rep,name=employer_phone_number,json=employerPhoneNumber,proto3" json:"employer_phone_number,omitempty"`
+	EmployeeIdNumber         []*CX                  `protobuf:"bytes,19,rep,name=employee_id_number,json=employeeIdNumber,proto3" json:"employee_id_number,omitempty"`
 	EmploymentStatus         string                 `protobuf:"bytes,20,opt,name=employment_status,json=employmentStatus,proto3" json:"employment_status,omitempty"`
-	OrganizationName         *XON                   `protobuf:"bytes,21,opt,name=organization_name,json=organizationName,proto3" json:"organization_name,omitempty"`
+	OrganizationName         []*XON                 `protobuf:"bytes,21,rep,name=organization_name,json=organizationName,proto3" json:"organization_name,omitempty"`
 	BillingHoldFlag          string                 `protobuf:"bytes,22,opt,name=billing_hold_flag,json=billingHoldFlag,proto3" json:"billing_hold_flag,omitempty"`
 	CreditRatingCode         *CE                    `protobuf:"bytes,23,opt,name=credit_rating_code,json=creditRatingCode,proto3" json:"credit_rating_code,omitempty"`
 	DeathDateTime            string                 `protobuf:"bytes,24,opt,name=death_date_time,json=deathDateTime,proto3" json:"death_date_time,omitempty"`
@@ -51,29 +51,29 @@ type GT1 struct {
 	ChargeAdjustmentCode     *CE                    `protobuf:"bytes,26,opt,name=charge_adjustment_code,json=chargeAdjustmentCode,proto3" json:"charge_adjustment_code,omitempty"`
 	HouseholdAnnualIncome    *CP                    `protobuf:"bytes,27,opt,name=household_annual_income,json=householdAnnualIncome,proto3" json:"household_annual_income,omitempty"`
 	HouseholdSize            string                 `protobuf:"bytes,28,opt,name=household_size,json=householdSize,proto3" json:"household_size,omitempty"`
-	EmployerIdNumber         *CX                    `protobuf:"bytes,29,opt,name=employer_id_number,json=employerIdNumber,proto3" json:"employer_id_number,omitempty"`
+	EmployerIdNumber         []*CX                  `protobuf:"bytes,29,rep,name=employer_id_number,json=employerIdNumber,proto3" json:"employer_id_number,omitempty"`
 	MaritalStatus            string                 `protobuf:"bytes,30,opt,name=marital_status,json=maritalStatus,proto3" json:"marital_status,omitempty"`
 	HireEffectiveDate        string                 `protobuf:"bytes,31,opt,name=hire_effective_date,json=hireEffectiveDate,proto3" json:"hire_effective_date,omitempty"`
 	EmploymentStopDate       string                 `protobuf:"bytes,32,opt,name=employment_stop_date,json=employmentStopDate,proto3" json:"employment_stop_date,omitempty"`
 	LivingDependency         string                 `protobuf:"bytes,33,opt,name=living_dependency,json=livingDependency,proto3" json:"living_dependency,omitempty"`
 	AmbulatoryStatus         string                 `protobuf:"bytes,34,opt,name=ambulatory_status,json=ambulatoryStatus,proto3" json:"ambulatory_status,omitempty"`
-	Citizenship              string                 `protobuf:"bytes,35,opt,name=citizenship,proto3" json:"citizenship,omitempty"`
+	Citizenship              []string               `protobuf:"bytes,35,rep,name=citizenship,proto3" json:"citizenship,omitempty"`
 	PrimaryLanguage          *CE                    `protobuf:"bytes,36,opt,name=primary_language,json=primaryLanguage,proto3" json:"primary_language,omitempty"`
 	LivingArrangement        string                 `protobuf:"bytes,37,opt,name=living_arrangement,json=livingArrangement,proto3" json:"living_arrangement,omitempty"`
 	PublicityIndicator       *CE                    `protobuf:"bytes,38,opt,name=publicity_indicator,json=publicityIndicator,proto3" json:"publicity_indicator,omitempty"`
 	ProtectionIndicator      string                 `protobuf:"bytes,39,opt,name=protection_indicator,json=protectionIndicator,proto3" json:"protection_indicator,omitempty"`
 	StudentIndicator         string                 `protobuf:"bytes,40,opt,name=student_indicator,json=studentIndicator,proto3" json:"student_indicator,omitempty"`
 	Religion                 string                 `protobuf:"bytes,41,opt,name=religion,proto3" json:"religion,omitempty"`
-	MotherMaidenName         *XPN                   `protobuf:"bytes,42,opt,name=mother_maiden_name,json=motherMaidenName,proto3" json:"mother_maiden_name,omitempty"`
+	MotherMaidenName         []*XPN                 `protobuf:"bytes,42,rep,name=mother_maiden_name,json=motherMaidenName,proto3" json:"mother_maiden_name,omitempty"`
 	Nationality              *CE                    `protobuf:"bytes,43,opt,name=nationality,proto3" json:"nationality,omitempty"`
 	EthnicGroup              string                 `protobuf:"bytes,44,opt,name=ethnic_group,json=ethnicGroup,proto3" json:"ethnic_group,omitempty"`
-	ContactName              *XPN                   `protobuf:"bytes,45,opt,name=contact_name,json=contactName,proto3" json:"contact_name,omitempty"`
-	ContactPhoneNumber       *XTN                   `protobuf:"bytes,46,opt,name=contact_phone_number,json=contactPhoneNumber,proto3" json:"contact_phone_number,omitempty"`
+	ContactName              []*XPN                 `protobuf:"bytes,45,rep,name=contact_name,json=contactName,proto3" json:"contact_name,omitempty"`
+	ContactPhoneNumber       []*XTN                 `protobuf:"bytes,46,rep,name=contact_phone_number,json=contactPhoneNumber,proto3" json:"contact_phone_number,omitempty"`
 	ContactReason            *CE                    `protobuf:"bytes,47,opt,name=contact_reason,json=contactReason,proto3" json:"contact_reason,omitempty"`
 	ContactRelationship      string                 `protobuf:"bytes,48,opt,name=contact_relationship,json=contactRelationship,proto3" json:"contact_relationship,omitempty"`
 	JobTitle                 string                 `protobuf:"bytes,49,opt,name=job_title,json=jobTitle,proto3" json:"job_title,omitempty"`
 	JobCode                  *JCC                   `protobuf:"bytes,50,opt,name=job_code,json=jobCode,proto3" json:"job_code,omitempty"`
-	EmployerOrganizationName *XON                   `protobuf:"bytes,51,opt,name=employer_organization_name,json=employerOrganizationName,proto3" json:"employer_organization_name,omitempty"`
+	EmployerOrganizationName []*XON                 `protobuf:"bytes,51,rep,name=employer_organization_name,json=employerOrganizationName,proto3" json:"employer_organization_name,omitempty"`
 	Handicap                 string                 `protobuf:"bytes,52,opt,name=handicap,proto3" json:"handicap,omitempty"`
 	JobStatus                string                 `protobuf:"bytes,53,opt,name=job_status,json=jobStatus,proto3" json:"job_status,omitempty"`
 	FinancialClass           *FC                    `protobuf:"bytes,54,opt,name=financial_class,json=financialClass,proto3" json:"financial_class,omitempty"`
@@ -126,35 +126,35 @@ func (x *GT1) GetGuarantorNumber() *CX {
 	return nil
 }
 
-func (x *GT1) GetName() *XPN {
+func (x *GT1) GetName() []*XPN {
 	if x != nil {
 		return x.Name
 	}
 	return nil
 }
 
-func (x *GT1) GetSpouseName() *XPN {
+func (x *GT1) GetSpouseName() []*XPN {
 	if x != nil {
 		return x.SpouseName
 	}
 	return nil
 }
 
-func (x *GT1) GetAddress() *XAD {
+func (x *GT1) GetAddress() []*XAD {
 	if x != nil {
 		return x.Address
 	}
 	return nil
 }
 
-func (x *GT1) GetHomePhoneNumber() *XTN {
+func (x *GT1) GetHomePhoneNumber() []*XTN {
 	if x != nil {
 		return x.HomePhoneNumber
 	}
 	return nil
 }
 
-func (x *GT1) GetWorkPhoneNumber() *XTN {
+func (x *GT1) GetWorkPhoneNumber() []*XTN {
 	if x != nil {
 		return x.WorkPhoneNumber
 	}
@@ -217,28 +217,28 @@ func (x *GT1) GetPriority() string {
 	return ""
 }
 
-func (x *GT1) GetEmployerName() *XPN {
+func (x *GT1) GetEmployerName() []*XPN {
 	if x != nil {
 		return x.EmployerName
 	}
 	return nil
 }
 
-func (x *GT1) GetEmployerAddress() *XAD {
+func (x *GT1) GetEmployerAddress() []*XAD {
 	if x != nil {
 		return x.EmployerAddress
 	}
 	return nil
 }
 
-func (x *GT1) GetEmployerPhoneNumber() *XTN {
+func (x *GT1) GetEmployerPhoneNumber() []*XTN {
 	if x != nil {
 		return x.EmployerPhoneNumber
 	}
 	return nil
 }
 
-func (x *GT1) GetEmployeeIdNumber() *CX {
+func (x *GT1) GetEmployeeIdNumber() []*CX {
 	if x != nil {
 		return x.EmployeeIdNumber
 	}
@@ -252,7 +252,7 @@ func (x *GT1) GetEmploymentStatus() string {
 	return ""
 }
 
-func (x *GT1) GetOrganizationName() *XON {
+func (x *GT1) GetOrganizationName() []*XON {
 	if x != nil {
 		return x.OrganizationName
 	}
@@ -308,7 +308,7 @@ func (x *GT1) GetHouseholdSize() string {
 	return ""
 }
 
-func (x *GT1) GetEmployerIdNumber() *CX {
+func (x *GT1) GetEmployerIdNumber() []*CX {
 	if x != nil {
 		return x.EmployerIdNumber
 	}
@@ -350,11 +350,11 @@ func (x *GT1) GetAmbulatoryStatus() string {
 	return ""
 }
 
-func (x *GT1) GetCitizenship() string {
+func (x *GT1) GetCitizenship() []string {
 	if x != nil {
 		return x.Citizenship
 	}
-	return ""
+	return nil
 }
 
 func (x *GT1) GetPrimaryLanguage() *CE {
@@ -399,7 +399,7 @@ func (x *GT1) GetReligion() string {
 	return ""
 }
 
-func (x *GT1) GetMotherMaidenName() *XPN {
+func (x *GT1) GetMotherMaidenName() []*XPN {
 	if x != nil {
 		return x.MotherMaidenName
 	}
@@ -420,14 +420,14 @@ func (x *GT1) GetEthnicGroup() string {
 	return ""
 }
 
-func (x *GT1) GetContactName() *XPN {
+func (x *GT1) GetContactName() []*XPN {
 	if x != nil {
 		return x.ContactName
 	}
 	return nil
 }
 
-func (x *GT1) GetContactPhoneNumber() *XTN {
+func (x *GT1) GetContactPhoneNumber() []*XTN {
 	if x != nil {
 		return x.ContactPhoneNumber
 	}
@@ -462,7 +462,7 @@ func (x *GT1) GetJobCode() *JCC {
 	return nil
 }
 
-func (x *GT1) GetEmployerOrganizationName() *XON {
+func (x *GT1) GetEmployerOrganizationName() []*XON {
 	if x != nil {
 		return x.EmployerOrganizationName
 	}
@@ -501,23 +501,23 @@ type IN1 struct {
 	state                    protoimpl.MessageState `protogen:"open.v1"`
 	SetId                    string                 `protobuf:"bytes,1,opt,name=set_id,json=setId,proto3" json:"set_id,omitempty"`
 	PlanId                   *CE                    `protobuf:"bytes,2,opt,name=plan_id,json=planId,proto3" json:"plan_id,omitempty"`
-	CompanyId                *CX                    `protobuf:"bytes,3,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
-	CompanyName              *XON                   `protobuf:"bytes,4,opt,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
-	CompanyAddress           *XAD                   `protobuf:"bytes,5,opt,name=company_address,json=companyAddress,proto3" json:"company_address,omitempty"`
-	CompanyContact           *XPN                   `protobuf:"bytes,6,opt,name=company_contact,json=companyContact,proto3" json:"company_contact,omitempty"`
-	CompanyPhoneNumber       *XTN                   `protobuf:"bytes,7,opt,name=company_phone_number,json=companyPhoneNumber,proto3" json:"company_phone_number,omitempty"`
+	CompanyId                []*CX                  `protobuf:"bytes,3,rep,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	CompanyName              []*XON                 `protobuf:"bytes,4,rep,name=company_name,json=companyName,proto3" json:"company_name,omitempty"`
+	CompanyAddress           []*XAD                 `protobuf:"bytes,5,rep,name=company_address,json=companyAddress,proto3" json:"company_address,omitempty"`
+	CompanyContact           []*XPN                 `protobuf:"bytes,6,rep,name=company_contact,json=companyContact,proto3" json:"company_contact,omitempty"`
+	CompanyPhoneNumber       []*XTN                 `protobuf:"bytes,7,rep,name=company_phone_number,json=companyPhoneNumber,proto3" json:"company_phone_number,omitempty"`
 	GroupNumber              string                 `protobuf:"bytes,8,opt,name=group_number,json=groupNumber,proto3" json:"group_number,omitempty"`
-	GroupName                *XON                   `protobuf:"bytes,9,opt,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
-	GroupEmployerId          *CX                    `protobuf:"bytes,10,opt,name=group_employer_id,json=groupEmployerId,proto3" json:"group_employer_id,omitempty"`
-	GroupEmployerName        *XON                   `protobuf:"bytes,11,opt,name=group_employer_name,json=groupEmployerName,proto3" json:"group_employer_name,omitempty"`
+	GroupName                []*XON                 `protobuf:"bytes,9,rep,name=group_name,json=groupName,proto3" json:"group_name,omitempty"`
+	GroupEmployerId          []*CX                  `protobuf:"bytes,10,rep,name=group_employer_id,json=groupEmployerId,proto3" json:"group_employer_id,omitempty"`
+	GroupEmployerName        []*XON                 `protobuf:"bytes,11,rep,name=group_employer_name,json=groupEmployerName,proto3" json:"group_employer_name,omitempty"`
 	PlanEffectiveDate        string                 `protobuf:"bytes,12,opt,name=plan_effective_date,json=planEffectiveDate,proto3" json:"plan_effective_date,omitempty"`
 	PlanExpirationDate       string                 `protobuf:"bytes,13,opt,name=plan_expiration_date,json=planExpirationDate,proto3" json:"plan_expiration_date,omitempty"`
 	AuthorizationInformation *CMAUI                 `protobuf:"bytes,14,opt,name=authorization_information,json=authorizationInformation,proto3" json:"authorization_information,omitempty"`
 	PlanType                 string                 `protobuf:"bytes,15,opt,name=plan_type,json=planType,proto3" json:"plan_type,omitempty"`
-	InsuredName              *XPN                   `protobuf:"bytes,16,opt,name=insured_name,json=insuredName,proto3" json:"insured_name,omitempty"`
+	InsuredName              []*XPN                 `protobuf:"bytes,16,rep,name=insured_name,json=insuredName,proto3" json:"insured_name,omitempty"`
 	RelationshipToPatient    string                 `protobuf:"bytes,17,opt,name=relationship_to_patient,json=relationshipToPatient,proto3" json:"relationship_to_patient,omitempty"`
 	InsuredDob               string                 `protobuf:"bytes,18,opt,name=insured_dob,json=insuredDob,proto3" json:"insured_dob,omitempty"`
-	InsuredAddress           *XAD                   `protobuf:"bytes,19,opt,name=insured_address,json=insuredAddress,proto3" json:"insured_address,omitempty"`
+	InsuredAddress           []*XAD                 `protobuf:"bytes,19,rep,name=insured_address,json=insuredAddress,proto3" json:"insured_address,omitempty"`
 	Aob                      string                 `protobuf:"bytes,20,opt,name=aob,proto3" json:"aob,omitempty"`
 	Cob                      string                 `protobuf:"bytes,21,opt,name=cob,proto3" json:"cob,omitempty"`
 	CobPriority              string                 `protobuf:"bytes,22,opt,name=cob_priority,json=cobPriority,proto3" json:"cob_priority,omitempty"`
@@ -528,7 +528,7 @@ type IN1 struct {
 	ReleaseInformationCode   string                 `protobuf:"bytes,27,opt,name=release_information_code,json=releaseInformationCode,proto3" json:"release_information_code,omitempty"`
 	Pac                      string                 `protobuf:"bytes,28,opt,name=pac,proto3" json:"pac,omitempty"`
 	VerificationDateTime     string                 `protobuf:"bytes,29,opt,name=verification_date_time,json=verificationDateTime,proto3" json:"verification_date_time,omitempty"`
-	VerificationBy           *XCN                   `protobuf:"bytes,30,opt,name=verification_by,json=verificationBy,proto3" json:"verification_by,omitempty"`
+	VerificationBy           []*XCN                 `protobuf:"bytes,30,rep,name=verification_by,json=verificationBy,proto3" json:"verification_by,omitempty"`
 	AgreementCode            string                 `protobuf:"bytes,31,opt,name=agreement_code,json=agreementCode,proto3" json:"agreement_code,omitempty"`
 	BillingStatus            string                 `protobuf:"bytes,32,opt,name=billing_status,json=billingStatus,proto3" json:"billing_status,omitempty"`
 	LifetimeReserveDays      string                 `protobuf:"bytes,33,opt,name=lifetime_reserve_days,json=lifetimeReserveDays,proto3" json:"lifetime_reserve_days,omitempty"`
@@ -542,7 +542,7 @@ type IN1 struct {
 	RoomRatePrivate          *CP                    `protobuf:"bytes,41,opt,name=room_rate_private,json=roomRatePrivate,proto3" json:"room_rate_private,omitempty"`
 	InsuredEmploymentStatus  *CE                    `protobuf:"bytes,42,opt,name=insured_employment_status,json=insuredEmploymentStatus,proto3" json:"insured_employment_status,omitempty"`
 	InsuredSex               string                 `protobuf:"bytes,43,opt,name=insured_sex,json=insuredSex,proto3" json:"insured_sex,omitempty"`
-	InsuredEmployerAddress   *XAD                   `protobuf:"bytes,44,opt,name=insured_employer_address,json=insuredEmployerAddress,proto3" json:"insured_employer_address,omitempty"`
+	InsuredEmployerAddress   []*XAD                 `protobuf:"bytes,44,rep,name=insured_employer_address,json=insuredEmployerAddress,proto3" json:"insured_employer_address,omitempty"`
 	VerificationStatus       string                 `protobuf:"bytes,45,opt,name=verification_status,json=verificationStatus,proto3" json:"verification_status,omitempty"`
 	PriorInsturancePlanId    string                 `protobuf:"bytes,46,opt,name=prior_insturance_plan_id,json=priorInsturancePlanId,proto3" json:"prior_insturance_plan_id,omitempty"`
 	CoverageType             string                 `protobuf:"bytes,47,opt,name=coverage_type,json=coverageType,proto3" json:"coverage_type,omitempty"`
@@ -596,35 +596,35 @@ func (x *IN1) GetPlanId() *CE {
 	return nil
 }
 
-func (x *IN1) GetCompanyId() *CX {
+func (x *IN1) GetCompanyId() []*CX {
 	if x != nil {
 		return x.CompanyId
 	}
 	return nil
 }
 
-func (x *IN1) GetCompanyName() *XON {
+func (x *IN1) GetCompanyName() []*XON {
 	if x != nil {
 		return x.CompanyName
 	}
 	return nil
 }
 
-func (x *IN1) GetCompanyAddress() *XAD {
+func (x *IN1) GetCompanyAddress() []*XAD {
 	if x != nil {
 		return x.CompanyAddress
 	}
 	return nil
 }
 
-func (x *IN1) GetCompanyContact() *XPN {
+func (x *IN1) GetCompanyContact() []*XPN {
 	if x != nil {
 		return x.CompanyContact
 	}
 	return nil
 }
 
-func (x *IN1) GetCompanyPhoneNumber() *XTN {
+func (x *IN1) GetCompanyPhoneNumber() []*XTN {
 	if x != nil {
 		return x.CompanyPhoneNumber
 	}
@@ -638,21 +638,21 @@ func (x *IN1) GetGroupNumber() string {
 	return ""
 }
 
-func (x *IN1) GetGroupName() *XON {
+func (x *IN1) GetGroupName() []*XON {
 	if x != nil {
 		return x.GroupName
 	}
 	return nil
 }
 
-func (x *IN1) GetGroupEmployerId() *CX {
+func (x *IN1) GetGroupEmployerId() []*CX {
 	if x != nil {
 		return x.GroupEmployerId
 	}
 	return nil
 }
 
-func (x *IN1) GetGroupEmployerName() *XON {
+func (x *IN1) GetGroupEmployerName() []*XON {
 	if x != nil {
 		return x.GroupEmployerName
 	}
@@ -687,7 +687,7 @@ func (x *IN1) GetPlanType() string {
 	return ""
 }
 
-func (x *IN1) GetInsuredName() *XPN {
+func (x *IN1) GetInsuredName() []*XPN {
 	if x != nil {
 		return x.InsuredName
 	}
@@ -708,7 +708,7 @@ func (x *IN1) GetInsuredDob() string {
 	return ""
 }
 
-func (x *IN1) GetInsuredAddress() *XAD {
+func (x *IN1) GetInsuredAddress() []*XAD {
 	if x != nil {
 		return x.InsuredAddress
 	}
@@ -785,7 +785,7 @@ func (x *IN1) GetVerificationDateTime() string {
 	return ""
 }
 
-func (x *IN1) GetVerificationBy() *XCN {
+func (x *IN1) GetVerificationBy() []*XCN {
 	if x != nil {
 		return x.VerificationBy
 	}
@@ -883,7 +883,7 @@ func (x *IN1) GetInsuredSex() string {
 	return ""
 }
 
-func (x *IN1) GetInsuredEmployerAddress() *XAD {
+func (x *IN1) GetInsuredEmployerAddress() []*XAD {
 	if x != nil {
 		return x.InsuredEmployerAddress
 	}
@@ -929,13 +929,13 @@ type IN2 struct {
 	state                              protoimpl.MessageState `protogen:"open.v1"`
 	InsuredEmployeeId                  *CX                    `protobuf:"bytes,1,opt,name=insured_employee_id,json=insuredEmployeeId,proto3" json:"insured_employee_id,omitempty"`
 	InsuredSsn                         string                 `protobuf:"bytes,2,opt,name=insured_ssn,json=insuredSsn,proto3" json:"insured_ssn,omitempty"`
-	InsuredEmployerName                *XCN                   `protobuf:"bytes,3,opt,name=insured_employer_name,json=insuredEmployerName,proto3" json:"insured_employer_name,omitempty"`
+	InsuredEmployerName                []*XCN                 `protobuf:"bytes,3,rep,name=insured_employer_name,json=insuredEmployerName,proto3" json:"insured_employer_name,omitempty"`
 	EmployerInformationData            string                 `protobuf:"bytes,4,opt,name=employer_information_data,json=employerInformationData,proto3" json:"employer_information_data,omitempty"`
 	MailClaimParty                     string                 `protobuf:"bytes,5,opt,name=mail_claim_party,json=mailClaimParty,proto3" json:"mail_claim_party,omitempty"`
 	MedicareCardNumber                 string                 `protobuf:"bytes,6,opt,name=medicare_card_number,json=medicareCardNumber,proto3" json:"medicare_card_number,omitempty"`
-	MedicaidCaseName                   *XPN                   `protobuf:"bytes,7,opt,name=medicaid_case_name,json=medicaidCaseName,proto3" json:"medicaid_case_name,omitempty"`
+	MedicaidCaseName                   []*XPN                 `protobuf:"bytes,7,rep,name=medicaid_case_name,json=medicaidCaseName,proto3" json:"medicaid_case_name,omitempty"`
 	MedicaidCaseNumber                 string                 `protobuf:"bytes,8,opt,name=medicaid_case_number,json=medicaidCaseNumber,proto3" json:"medicaid_case_number,omitempty"`
-	ChampuSponsorName                  *XPN                   `protobuf:"bytes,9,opt,name=champu_sponsor_name,json=champuSponsorName,proto3" json:"champu_sponsor_name,omitempty"`
+	ChampuSponsorName                  []*XPN                 `protobuf:"bytes,9,rep,name=champu_sponsor_name,json=champuSponsorName,proto3" json:"champu_sponsor_name,omitempty"`
 	ChampusIdNumber                    string                 `protobuf:"bytes,10,opt,name=champus_id_number,json=champusIdNumber,proto3" json:"champus_id_number,omitempty"`
 	ChampusDependentRecipient          *CE                    `protobuf:"bytes,11,opt,name=champus_dependent_recipient,json=champusDependentRecipient,proto3" json:"champus_dependent_recipient,omitempty"`
 	ChampusOrganization                string                 `protobuf:"bytes,12,opt,name=champus_organization,json=champusOrganization,proto3" json:"champus_organization,omitempty"`
@@ -948,25 +948,25 @@ type IN2 struct {
 	BabyCoverage                       string                 `protobuf:"bytes,19,opt,name=baby_coverage,json=babyCoverage,proto3" json:"baby_coverage,omitempty"`
 	CombineBabyBill                    string                 `protobuf:"bytes,20,opt,name=combine_baby_bill,json=combineBabyBill,proto3" json:"combine_baby_bill,omitempty"`
 	BloodDeductible                    string                 `protobuf:"bytes,21,opt,name=blood_deductible,json=bloodDeductible,proto3" json:"blood_deductible,omitempty"`
-	SpecialCoverageApprovalName        *XPN                   `protobuf:"bytes,22,opt,name=special_coverage_approval_name,json=specialCoverageApprovalName,proto3" json:"special_coverage_approval_name,omitempty"`
+	SpecialCoverageApprovalName        []*XPN                 `protobuf:"bytes,22,rep,name=special_coverage_approval_name,json=specialCoverageApprovalName,proto3" json:"special_coverage_approval_name,omitempty"`
 	SpecialCoverageApprovalTitle       string                 `protobuf:"bytes,23,opt,name=special_coverage_approval_title,json=specialCoverageApprovalTitle,proto3" json:"special_coverage_approval_title,omitempty"`
 	NoncoveredInsuranceCode            string                 `protobuf:"bytes,24,opt,name=noncovered_insurance_code,json=noncoveredInsuranceCode,proto3" json:"noncovered_insurance_code,omitempty"`
-	PayorId                            *CX                    `protobuf:"bytes,25,opt,name=payor_id,json=payorId,proto3" json:"payor_id,omitempty"`
-	PayorSubscriberId                  *CX                    `protobuf:"bytes,26,opt,name=payor_subscriber_id,json=payorSubscriberId,proto3" json:"payor_subscriber_id,omitempty"`
+	PayorId                            []*CX                  `protobuf:"bytes,25,rep,name=payor_id,json=payorId,proto3" json:"payor_id,omitempty"`
+	PayorSubscriberId                  []*CX                  `protobuf:"bytes,26,rep,name=payor_subscriber_id,json=payorSubscriberId,proto3" json:"payor_subscriber_id,omitempty"`
 	EligibilitySource                  string                 `protobuf:"bytes,27,opt,name=eligibility_source,json=eligibilitySource,proto3" json:"eligibility_source,omitempty"`
-	RoomCoverageType                   *CMPLT                 `protobuf:"bytes,28,opt,name=room_coverage_type,json=roomCoverageType,proto3" json:"room_coverage_type,omitempty"`
-	PolicyType                         *CMPLT                 `protobuf:"bytes,29,opt,name=policy_type,json=policyType,proto3" json:"policy_type,omitempty"`
+	RoomCoverageType                   []*CMPLT               `protobuf:"bytes,28,rep,name=room_coverage_type,json=roomCoverageType,proto3" json:"room_coverage_type,omitempty"`
+	PolicyType                         []*CMPLT               `protobuf:"bytes,29,rep,name=policy_type,json=policyType,proto3" json:"policy_type,omitempty"`
 	DailyDeductible                    *CMDDE                 `protobuf:"bytes,30,opt,name=daily_deductible,json=dailyDeductible,proto3" json:"daily_deductible,omitempty"`
 	LivingDependency                   string                 `protobuf:"bytes,31,opt,name=living_dependency,json=livingDependency,proto3" json:"living_dependency,omitempty"`
 	AmbulatoryStatus                   string                 `protobuf:"bytes,32,opt,name=ambulatory_status,json=ambulatoryStatus,proto3" json:"ambulatory_status,omitempty"`
-	Citizenship                        string                 `protobuf:"bytes,33,opt,name=citizenship,proto3" json:"citizenship,omitempty"`
+	Citizenship                        []string               `protobuf:"bytes,33,rep,name=citizenship,proto3" json:"citizenship,omitempty"`
 	PrimaryLanguage                    *CE                    `protobuf:"bytes,34,opt,name=primary_language,json=primaryLanguage,proto3" json:"primary_language,omitempty"`
 	LivingArrangement                  string                 `protobuf:"bytes,35,opt,name=living_arrangement,json=livingArrangement,proto3" json:"living_arrangement,omitempty"`
 	PublicityIndicator                 *CE                    `protobuf:"bytes,36,opt,name=publicity_indicator,json=publicityIndicator,proto3" json:"publicity_indicator,omitempty"`
 	ProtectionIndicator                string                 `protobuf:"bytes,37,opt,name=protection_indicator,json=protectionIndicator,proto3" json:"protection_indicator,omitempty"`
 	StudentIndicator                   string                 `protobuf:"bytes,38,opt,name=student_indicator,json=studentIndicator,proto3" json:"student_indicator,omitempty"`
 	Religion                           string                 `protobuf:"bytes,39,opt,name=religion,proto3" json:"religion,omitempty"`
-	MotherMaidenName                   *XPN                   `protobuf:"bytes,40,opt,name=mother_maiden_name,json=motherMaidenName,proto3" json:"mother_maiden_name,omitempty"`
+	MotherMaidenName                   []*XPN                 `protobuf:"bytes,40,rep,name=mother_maiden_name,json=motherMaidenName,proto3" json:"mother_maiden_name,omitempty"`
 	Nationality                        *CE                    `protobuf:"bytes,41,opt,name=nationality,proto3" json:"nationality,omitempty"`
 	EthnicGroup                        string                 `protobuf:"bytes,42,opt,name=ethnic_group,json=ethnicGroup,proto3" json:"ethnic_group,omitempty"`
 	MaritalStatus                      string                 `protobuf:"bytes,43,opt,name=marital_status,json=maritalStatus,proto3" json:"marital_status,omitempty"`
@@ -975,28 +975,28 @@ type IN2 struct {
 	JobTitle                           string                 `protobuf:"bytes,46,opt,name=job_title,json=jobTitle,proto3" json:"job_title,omitempty"`
 	JobCode                            *JCC                   `protobuf:"bytes,47,opt,name=job_code,json=jobCode,proto3" json:"job_code,omitempty"`
 	JobStatus                          string                 `protobuf:"bytes,48,opt,name=job_status,json=jobStatus,proto3" json:"job_status,omitempty"`
-	EmployerContactName                *XPN                   `protobuf:"bytes,49,opt,name=employer_contact_name,json=employerContactName,proto3" json:"employer_contact_name,omitempty"`
-	EmployerContactPhoneNumber         *XTN                   `protobuf:"bytes,50,opt,name=employer_contact_phone_number,json=employerContactPhoneNumber,proto3" json:"employer_contact_phone_number,omitempty"`
+	EmployerContactName                []*XPN                 `protobuf:"bytes,49,rep,name=employer_contact_name,json=employerContactName,proto3" json:"employer_contact_name,omitempty"`
+	EmployerContactPhoneNumber         []*XTN                 `protobuf:"bytes,50,rep,name=employer_contact_phone_number,json=employerContactPhoneNumber,proto3" json:"employer_contact_phone_number,omitempty"`
 	EmployerContactReason              string                 `protobuf:"bytes,51,opt,name=employer_contact_reason,json=employerContactReason,proto3" json:"employer_contact_reason,omitempty"`
-	InsuredContactName                 *XPN                   `protobuf:"bytes,52,opt,name=insured_contact_name,json=insuredContactName,proto3" json:"insured_contact_name,omitempty"`
-	InsuredContactPhoneNumbet          *XTN                   `protobuf:"bytes,53,opt,name=insured_contact_phone_numbet,json=insuredContactPhoneNumbet,proto3" json:"insured_contact_phone_numbet,omitempty"`
+	InsuredContactName                 []*XPN                 `protobuf:"bytes,52,rep,name=insured_contact_name,json=insuredContactName,proto3" json:"insured_contact_name,omitempty"`
+	InsuredContactPhoneNumbet          []*XTN                 `protobuf:"bytes,53,rep,name=insured_contact_phone_numbet,json=insuredContactPhoneNumbet,proto3" json:"insured_contact_phone_numbet,omitempty"`
 	InsuredContactReason               string                 `protobuf:"bytes,54,opt,name=insured_contact_reason,json=insuredContactReason,proto3" json:"insured_contact_reason,omitempty"`
 	RelationshipToPatientStartDate     string                 `protobuf:"bytes,55,opt,name=relationship_to_patient_start_date,json=relationshipToPatientStartDate,proto3" json:"relationship_to_patient_start_date,omitempty"`
 	RelationshipToPatientStopDate      string                 `protobuf:"bytes,56,opt,name=relationship_to_patient_stop_date,json=relationshipToPatientStopDate,proto3" json:"relationship_to_patient_stop_date,omitempty"`
 	InsuranceCompanyContactReason      string                 `protobuf:"bytes,57,opt,name=insurance_company_contact_reason,json=insuranceCompanyContactReason,proto3" json:"insurance_company_contact_reason,omitempty"`
-	InsuranceCompanyContactPhoneNumber *XTN                   `protobuf:"bytes,58,opt,name=insurance_company_contact_phone_number,json=insuranceCompanyContactPhoneNumber,proto3" json:"insurance_company_contact_phone_number,omitempty"`
+	InsuranceCompanyContactPhoneNumber []*XTN                 `protobuf:"bytes,58,rep,name=insurance_company_contact_phone_number,json=insuranceCompanyContactPhoneNumber,proto3" json:"insurance_company_contact_phone_number,omitempty"`
 	PolicyScope                        string                 `protobuf:"bytes,59,opt,name=policy_scope,json=policyScope,proto3" json:"policy_scope,omitempty"`
 	PolicySource                       string                 `protobuf:"bytes,60,opt,name=policy_source,json=policySource,proto3" json:"policy_source,omitempty"`
-	PatientMemberNumber                *CX                    `protobuf:"bytes,61,opt,name=patient_member_number,json=patientMemberNumber,proto3" json:"patient_member_number,omitempty"`
+	PatientMemberNumber                []*CX                  `protobuf:"bytes,61,rep,name=patient_member_number,json=patientMemberNumber,proto3" json:"patient_member_number,omitempty"`
 	GuarantorRelationship              string                 `protobuf:"bytes,62,opt,name=guarantor_relationship,json=guarantorRelationship,proto3" json:"guarantor_relationship,omitempty"`
-	InsuredHomePhoneNumber             *XTN                   `protobuf:"bytes,63,opt,name=insured_home_phone_number,json=insuredHomePhoneNumber,proto3" json:"insured_home_phone_number,omitempty"`
-	InsuredHomeWorkNumber              *XTN                   `protobuf:"bytes,64,opt,name=insured_home_work_number,json=insuredHomeWorkNumber,proto3" json:"insured_home_work_number,omitempty"`
+	InsuredHomePhoneNumber             []*XTN                 `protobuf:"bytes,63,rep,name=insured_home_phone_number,json=insuredHomePhoneNumber,proto3" json:"insured_home_phone_number,omitempty"`
+	InsuredHomeWorkNumber              []*XTN                 `protobuf:"bytes,64,rep,name=insured_home_work_number,json=insuredHomeWorkNumber,proto3" json:"insured_home_work_number,omitempty"`
 	MilitaryHandicappedProgram         *CE                    `protobuf:"bytes,65,opt,name=military_handicapped_program,json=militaryHandicappedProgram,proto3" json:"military_handicapped_program,omitempty"`
 	SuspendFlag                        string                 `protobuf:"bytes,66,opt,name=suspend_flag,json=suspendFlag,proto3" json:"suspend_flag,omitempty"`
 	CopayLimitFlag                     string                 `protobuf:"bytes,67,opt,name=copay_limit_flag,json=copayLimitFlag,proto3" json:"copay_limit_flag,omitempty"`
 	StoplossLimitFlag                  string                 `protobuf:"bytes,68,opt,name=stoploss_limit_flag,json=stoplossLimitFlag,proto3" json:"stoploss_limit_flag,omitempty"`
-	InsuredOrganizationName            *XON                   `protobuf:"bytes,69,opt,name=insured_organization_name,json=insuredOrganizationName,proto3" json:"insured_organization_name,omitempty"`
-	InsuredEmployerOrganizationName    *XON                   `protobuf:"bytes,70,opt,name=insured_employer_organization_name,json=insuredEmployerOrganizationName,proto3" json:"insured_employer_organization_name,omitempty"`
+	InsuredOrganizationName            []*XON                 `protobuf:"bytes,69,rep,name=insured_organization_name,json=insuredOrganizationName,proto3" json:"insured_organization_name,omitempty"`
+	InsuredEmployerOrganizationName    []*XON                 `protobuf:"bytes,70,rep,name=insured_employer_organization_name,json=insuredEmployerOrganizationName,proto3" json:"insured_employer_organization_name,omitempty"`
 	Race                               string                 `protobuf:"bytes,71,opt,name=race,proto3" json:"race,omitempty"`
 	HcfaPatientRelationshipToInsured   *CE                    `protobuf:"bytes,72,opt,name=hcfa_patient_relationship_to_insured,json=hcfaPatientRelationshipToInsured,proto3" json:"hcfa_patient_relationship_to_insured,omitempty"`
 	unknownFields                      protoimpl.UnknownFields
@@ -1047,7 +1047,7 @@ func (x *IN2) GetInsuredSsn() string {
 	return ""
 }
 
-func (x *IN2) GetInsuredEmployerName() *XCN {
+func (x *IN2) GetInsuredEmployerName() []*XCN {
 	if x != nil {
 		return x.InsuredEmployerName
 	}
@@ -1075,7 +1075,7 @@ func (x *IN2) GetMedicareCardNumber() string {
 	return ""
 }
 
-func (x *IN2) GetMedicaidCaseName() *XPN {
+func (x *IN2) GetMedicaidCaseName() []*XPN {
 	if x != nil {
 		return x.MedicaidCaseName
 	}
@@ -1089,7 +1089,7 @@ func (x *IN2) GetMedicaidCaseNumber() string {
 	return ""
 }
 
-func (x *IN2) GetChampuSponsorName() *XPN {
+func (x *IN2) GetChampuSponsorName() []*XPN {
 	if x != nil {
 		return x.ChampuSponsorName
 	}
@@ -1180,7 +1180,7 @@ func (x *IN2) GetBloodDeductible() string {
 	return ""
 }
 
-func (x *IN2) GetSpecialCoverageApprovalName() *XPN {
+func (x *IN2) GetSpecialCoverageApprovalName() []*XPN {
 	if x != nil {
 		return x.SpecialCoverageApprovalName
 	}
@@ -1201,14 +1201,14 @@ func (x *IN2) GetNoncoveredInsuranceCode() string {
 	return ""
 }
 
-func (x *IN2) GetPayorId() *CX {
+func (x *IN2) GetPayorId() []*CX {
 	if x != nil {
 		return x.PayorId
 	}
 	return nil
 }
 
-func (x *IN2) GetPayorSubscriberId() *CX {
+func (x *IN2) GetPayorSubscriberId() []*CX {
 	if x != nil {
 		return x.PayorSubscriberId
 	}
@@ -1222,14 +1222,14 @@ func (x *IN2) GetEligibilitySource() string {
 	return ""
 }
 
-func (x *IN2) GetRoomCoverageType() *CMPLT {
+func (x *IN2) GetRoomCoverageType() []*CMPLT {
 	if x != nil {
 		return x.RoomCoverageType
 	}
 	return nil
 }
 
-func (x *IN2) GetPolicyType() *CMPLT {
+func (x *IN2) GetPolicyType() []*CMPLT {
 	if x != nil {
 		return x.PolicyType
 	}
@@ -1257,11 +1257,11 @@ func (x *IN2) GetAmbulatoryStatus() string {
 	return ""
 }
 
-func (x *IN2) GetCitizenship() string {
+func (x *IN2) GetCitizenship() []string {
 	if x != nil {
 		return x.Citizenship
 	}
-	return ""
+	return nil
 }
 
 func (x *IN2) GetPrimaryLanguage() *CE {
@@ -1306,7 +1306,7 @@ func (x *IN2) GetReligion() string {
 	return ""
 }
 
-func (x *IN2) GetMotherMaidenName() *XPN {
+func (x *IN2) GetMotherMaidenName() []*XPN {
 	if x != nil {
 		return x.MotherMaidenName
 	}
@@ -1369,14 +1369,14 @@ func (x *IN2) GetJobStatus() string {
 	return ""
 }
 
-func (x *IN2) GetEmployerContactName() *XPN {
+func (x *IN2) GetEmployerContactName() []*XPN {
 	if x != nil {
 		return x.EmployerContactName
 	}
 	return nil
 }
 
-func (x *IN2) GetEmployerContactPhoneNumber() *XTN {
+func (x *IN2) GetEmployerContactPhoneNumber() []*XTN {
 	if x != nil {
 		return x.EmployerContactPhoneNumber
 	}
@@ -1390,14 +1390,14 @@ func (x *IN2) GetEmployerContactReason() string {
 	return ""
 }
 
-func (x *IN2) GetInsuredContactName() *XPN {
+func (x *IN2) GetInsuredContactName() []*XPN {
 	if x != nil {
 		return x.InsuredContactName
 	}
 	return nil
 }
 
-func (x *IN2) GetInsuredContactPhoneNumbet() *XTN {
+func (x *IN2) GetInsuredContactPhoneNumbet() []*XTN {
 	if x != nil {
 		return x.InsuredContactPhoneNumbet
 	}
@@ -1432,7 +1432,7 @@ func (x *IN2) GetInsuranceCompanyContactReason() string {
 	return ""
 }
 
-func (x *IN2) GetInsuranceCompanyContactPhoneNumber() *XTN {
+func (x *IN2) GetInsuranceCompanyContactPhoneNumber() []*XTN {
 	if x != nil {
 		return x.InsuranceCompanyContactPhoneNumber
 	}
@@ -1453,7 +1453,7 @@ func (x *IN2) GetPolicySource() string {
 	return ""
 }
 
-func (x *IN2) GetPatientMemberNumber() *CX {
+func (x *IN2) GetPatientMemberNumber() []*CX {
 	if x != nil {
 		return x.PatientMemberNumber
 	}
@@ -1467,14 +1467,14 @@ func (x *IN2) GetGuarantorRelationship() string {
 	return ""
 }
 
-func (x *IN2) GetInsuredHomePhoneNumber() *XTN {
+func (x *IN2) GetInsuredHomePhoneNumber() []*XTN {
 	if x != nil {
 		return x.InsuredHomePhoneNumber
 	}
 	return nil
 }
 
-func (x *IN2) GetInsuredHomeWorkNumber() *XTN {
+func (x *IN2) GetInsuredHomeWorkNumber() []*XTN {
 	if x != nil {
 		return x.InsuredHomeWorkNumber
 	}
@@ -1509,14 +1509,14 @@ func (x *IN2) GetStoplossLimitFlag() string {
 	return ""
 }
 
-func (x *IN2) GetInsuredOrganizationName() *XON {
+func (x *IN2) GetInsuredOrganizationName() []*XON {
 	if x != nil {
 		return x.InsuredOrganizationName
 	}
 	return nil
 }
 
-func (x *IN2) GetInsuredEmployerOrganizationName() *XON {
+func (x *IN2) GetInsuredEmployerOrganizationName() []*XON {
 	if x != nil {
 		return x.InsuredEmployerOrganizationName
 	}
@@ -1554,16 +1554,16 @@ type IN3 struct {
 	NonConcurEffectiveDateTime         string                 `protobuf:"bytes,13,opt,name=non_concur_effective_date_time,json=nonConcurEffectiveDateTime,proto3" json:"non_concur_effective_date_time,omitempty"`
 	PhysicianReviewer                  *XCN                   `protobuf:"bytes,14,opt,name=physician_reviewer,json=physicianReviewer,proto3" json:"physician_reviewer,omitempty"`
 	CertificationContact               string                 `protobuf:"bytes,15,opt,name=certification_contact,json=certificationContact,proto3" json:"certification_contact,omitempty"`
-	CertificationContactPhoneNumber    *XTN                   `protobuf:"bytes,16,opt,name=certification_contact_phone_number,json=certificationContactPhoneNumber,proto3" json:"certification_contact_phone_number,omitempty"`
+	CertificationContactPhoneNumber    []*XTN                 `protobuf:"bytes,16,rep,name=certification_contact_phone_number,json=certificationContactPhoneNumber,proto3" json:"certification_contact_phone_number,omitempty"`
 	AppealReason                       *CE                    `protobuf:"bytes,17,opt,name=appeal_reason,json=appealReason,proto3" json:"appeal_reason,omitempty"`
 	CertificationAgency                *CE                    `protobuf:"bytes,18,opt,name=certification_agency,json=certificationAgency,proto3" json:"certification_agency,omitempty"`
-	CertificationAgencyPhoneNumber     *XTN                   `protobuf:"bytes,19,opt,name=certification_agency_phone_number,json=certificationAgencyPhoneNumber,proto3" json:"certification_agency_phone_number,omitempty"`
-	PreCertRequirementWindow           *CMPCR                 `protobuf:"bytes,20,opt,name=pre_cert_requirement_window,json=preCertRequirementWindow,proto3" json:"pre_cert_requirement_window,omitempty"`
+	CertificationAgencyPhoneNumber     []*XTN                 `protobuf:"bytes,19,rep,name=certification_agency_phone_number,json=certificationAgencyPhoneNumber,proto3" json:"certification_agency_phone_number,omitempty"`
+	PreCertRequirementWindow           []*CMPCR               `protobuf:"bytes,20,rep,name=pre_cert_requirement_window,json=preCertRequirementWindow,proto3" json:"pre_cert_requirement_window,omitempty"`
 	CaseManager                        string                 `protobuf:"bytes,21,opt,name=case_manager,json=caseManager,proto3" json:"case_manager,omitempty"`
 	SecondOpinionDate                  string                 `protobuf:"bytes,22,opt,name=second_opinion_date,json=secondOpinionDate,proto3" json:"second_opinion_date,omitempty"`
 	SecondOpinionStatus                string                 `protobuf:"bytes,23,opt,name=second_opinion_status,json=secondOpinionStatus,proto3" json:"second_opinion_status,omitempty"`
 	SecondOpinionDocumentationReceived string                 `protobuf:"bytes,24,opt,name=second_opinion_documentation_received,json=secondOpinionDocumentationReceived,proto3" json:"second_opinion_documentation_received,omitempty"`
-	SecondOpinionPhysician             *XCN                   `protobuf:"bytes,25,opt,name=second_opinion_physician,json=secondOpinionPhysician,proto3" json:"second_opinion_physician,omitempty"`
+	SecondOpinionPhysician             []*XCN                 `protobuf:"bytes,25,rep,name=second_opinion_physician,json=secondOpinionPhysician,proto3" json:"second_opinion_physician,omitempty"`
 	unknownFields                      protoimpl.UnknownFields
 	sizeCache                          protoimpl.SizeCache
 }
@@ -1703,7 +1703,7 @@ func (x *IN3) GetCertificationContact() string {
 	return ""
 }
 
-func (x *IN3) GetCertificationContactPhoneNumber() *XTN {
+func (x *IN3) GetCertificationContactPhoneNumber() []*XTN {
 	if x != nil {
 		return x.CertificationContactPhoneNumber
 	}
@@ -1724,14 +1724,14 @@ func (x *IN3) GetCertificationAgency() *CE {
 	return nil
 }
 
-func (x *IN3) GetCertificationAgencyPhoneNumber() *XTN {
+func (x *IN3) GetCertificationAgencyPhoneNumber() []*XTN {
 	if x != nil {
 		return x.CertificationAgencyPhoneNumber
 	}
 	return nil
 }
 
-func (x *IN3) GetPreCertRequirementWindow() *CMPCR {
+func (x *IN3) GetPreCertRequirementWindow() []*CMPCR {
 	if x != nil {
 		return x.PreCertRequirementWindow
 	}
@@ -1766,7 +1766,7 @@ func (x *IN3) GetSecondOpinionDocumentationReceived() string {
 	return ""
 }
 
-func (x *IN3) GetSecondOpinionPhysician() *XCN {
+func (x *IN3) GetSecondOpinionPhysician() []*XCN {
 	if x != nil {
 		return x.SecondOpinionPhysician
 	}
@@ -1790,7 +1790,7 @@ type DG1 struct {
 	OutlierCost             *CP                    `protobuf:"bytes,13,opt,name=outlier_cost,json=outlierCost,proto3" json:"outlier_cost,omitempty"`
 	GoruperVersion          string                 `protobuf:"bytes,14,opt,name=goruper_version,json=goruperVersion,proto3" json:"goruper_version,omitempty"`
 	Priority                string                 `protobuf:"bytes,15,opt,name=priority,proto3" json:"priority,omitempty"`
-	DiagnosingClinician     *XCN                   `protobuf:"bytes,16,opt,name=diagnosing_clinician,json=diagnosingClinician,proto3" json:"diagnosing_clinician,omitempty"`
+	DiagnosingClinician     []*XCN                 `protobuf:"bytes,16,rep,name=diagnosing_clinician,json=diagnosingClinician,proto3" json:"diagnosing_clinician,omitempty"`
 	Classification          string                 `protobuf:"bytes,17,opt,name=classification,proto3" json:"classification,omitempty"`
 	ConfidentialIndicator   string                 `protobuf:"bytes,18,opt,name=confidential_indicator,json=confidentialIndicator,proto3" json:"confidential_indicator,omitempty"`
 	AttestationDateTime     string                 `protobuf:"bytes,19,opt,name=attestation_date_time,json=attestationDateTime,proto3" json:"attestation_date_time,omitempty"`
@@ -1933,7 +1933,7 @@ func (x *DG1) GetPriority() string {
 	return ""
 }
 
-func (x *DG1) GetDiagnosingClinician() *XCN {
+func (x *DG1) GetDiagnosingClinician() []*XCN {
 	if x != nil {
 		return x.DiagnosingClinician
 	}
@@ -1969,12 +1969,12 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\x03GT1\x12\x15\n" +
 	"\x06set_id\x18\x01 \x01(\tR\x05setId\x12<\n" +
 	"\x10guarantor_number\x18\x02 \x01(\v2\x11.standards.v23.CXR\x0fguarantorNumber\x12&\n" +
-	"\x04name\x18\x03 \x01(\v2\x12.standards.v23.XPNR\x04name\x123\n" +
-	"\vspouse_name\x18\x04 \x01(\v2\x12.standards.v23.XPNR\n" +
+	"\x04name\x18\x03 \x03(\v2\x12.standards.v23.XPNR\x04name\x123\n" +
+	"\vspouse_name\x18\x04 \x03(\v2\x12.standards.v23.XPNR\n" +
 	"spouseName\x12,\n" +
-	"\aaddress\x18\x05 \x01(\v2\x12.standards.v23.XADR\aaddress\x12>\n" +
-	"\x11home_phone_number\x18\x06 \x01(\v2\x12.standards.v23.XTNR\x0fhomePhoneNumber\x12>\n" +
-	"\x11work_phone_number\x18\a \x01(\v2\x12.standards.v23.XTNR\x0fworkPhoneNumber\x12\x10\n" +
+	"\aaddress\x18\x05 \x03(\v2\x12.standards.v23.XADR\aaddress\x12>\n" +
+	"\x11home_phone_number\x18\x06 \x03(\v2\x12.standards.v23.XTNR\x0fhomePhoneNumber\x12>\n" +
+	"\x11work_phone_number\x18\a \x03(\v2\x12.standards.v23.XTNR\x0fworkPhoneNumber\x12\x10\n" +
 	"\x03dob\x18\b \x01(\tR\x03dob\x12\x10\n" +
 	"\x03sex\x18\t \x01(\tR\x03sex\x12\x12\n" +
 	"\x04type\x18\n" +
@@ -1985,12 +1985,12 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"begin_date\x18\r \x01(\tR\tbeginDate\x12\x19\n" +
 	"\bend_date\x18\x0e \x01(\tR\aendDate\x12\x1a\n" +
 	"\bpriority\x18\x0f \x01(\tR\bpriority\x127\n" +
-	"\remployer_name\x18\x10 \x01(\v2\x12.standards.v23.XPNR\femployerName\x12=\n" +
-	"\x10employer_address\x18\x11 \x01(\v2\x12.standards.v23.XADR\x0femployerAddress\x12F\n" +
-	"\x15employer_phone_number\x18\x12 \x01(\v2\x12.standards.v23.XTNR\x13employerPhoneNumber\x12?\n" +
-	"\x12employee_id_number\x18\x13 \x01(\v2\x11.standards.v23.CXR\x10employeeIdNumber\x12+\n" +
+	"\remployer_name\x18\x10 \x03(\v2\x12.standards.v23.XPNR\femployerName\x12=\n" +
+	"\x10employer_address\x18\x11 \x03(\v2\x12.standards.v23.XADR\x0femployerAddress\x12F\n" +
+	"\x15employer_phone_number\x18\x12 \x03(\v2\x12.standards.v23.XTNR\x13employerPhoneNumber\x12?\n" +
+	"\x12employee_id_number\x18\x13 \x03(\v2\x11.standards.v23.CXR\x10employeeIdNumber\x12+\n" +
 	"\x11employment_status\x18\x14 \x01(\tR\x10employmentStatus\x12?\n" +
-	"\x11organization_name\x18\x15 \x01(\v2\x12.standards.v23.XONR\x10organizationName\x12*\n" +
+	"\x11organization_name\x18\x15 \x03(\v2\x12.standards.v23.XONR\x10organizationName\x12*\n" +
 	"\x11billing_hold_flag\x18\x16 \x01(\tR\x0fbillingHoldFlag\x12?\n" +
 	"\x12credit_rating_code\x18\x17 \x01(\v2\x11.standards.v23.CER\x10creditRatingCode\x12&\n" +
 	"\x0fdeath_date_time\x18\x18 \x01(\tR\rdeathDateTime\x12\x1d\n" +
@@ -1999,29 +1999,29 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\x16charge_adjustment_code\x18\x1a \x01(\v2\x11.standards.v23.CER\x14chargeAdjustmentCode\x12I\n" +
 	"\x17household_annual_income\x18\x1b \x01(\v2\x11.standards.v23.CPR\x15householdAnnualIncome\x12%\n" +
 	"\x0ehousehold_size\x18\x1c \x01(\tR\rhouseholdSize\x12?\n" +
-	"\x12employer_id_number\x18\x1d \x01(\v2\x11.standards.v23.CXR\x10employerIdNumber\x12%\n" +
+	"\x12employer_id_number\x18\x1d \x03(\v2\x11.standards.v23.CXR\x10employerIdNumber\x12%\n" +
 	"\x0emarital_status\x18\x1e \x01(\tR\rmaritalStatus\x12.\n" +
 	"\x13hire_effective_date\x18\x1f \x01(\tR\x11hireEffectiveDate\x120\n" +
 	"\x14employment_stop_date\x18  \x01(\tR\x12employmentStopDate\x12+\n" +
 	"\x11living_dependency\x18! \x01(\tR\x10livingDependency\x12+\n" +
 	"\x11ambulatory_status\x18\" \x01(\tR\x10ambulatoryStatus\x12 \n" +
-	"\vcitizenship\x18# \x01(\tR\vcitizenship\x12<\n" +
+	"\vcitizenship\x18# \x03(\tR\vcitizenship\x12<\n" +
 	"\x10primary_language\x18$ \x01(\v2\x11.standards.v23.CER\x0fprimaryLanguage\x12-\n" +
 	"\x12living_arrangement\x18% \x01(\tR\x11livingArrangement\x12B\n" +
 	"\x13publicity_indicator\x18& \x01(\v2\x11.standards.v23.CER\x12publicityIndicator\x121\n" +
 	"\x14protection_indicator\x18' \x01(\tR\x13protectionIndicator\x12+\n" +
 	"\x11student_indicator\x18( \x01(\tR\x10studentIndicator\x12\x1a\n" +
 	"\breligion\x18) \x01(\tR\breligion\x12@\n" +
-	"\x12mother_maiden_name\x18* \x01(\v2\x12.standards.v23.XPNR\x10motherMaidenName\x123\n" +
+	"\x12mother_maiden_name\x18* \x03(\v2\x12.standards.v23.XPNR\x10motherMaidenName\x123\n" +
 	"\vnationality\x18+ \x01(\v2\x11.standards.v23.CER\vnationality\x12!\n" +
 	"\fethnic_group\x18, \x01(\tR\vethnicGroup\x125\n" +
-	"\fcontact_name\x18- \x01(\v2\x12.standards.v23.XPNR\vcontactName\x12D\n" +
-	"\x14contact_phone_number\x18. \x01(\v2\x12.standards.v23.XTNR\x12contactPhoneNumber\x128\n" +
+	"\fcontact_name\x18- \x03(\v2\x12.standards.v23.XPNR\vcontactName\x12D\n" +
+	"\x14contact_phone_number\x18. \x03(\v2\x12.standards.v23.XTNR\x12contactPhoneNumber\x128\n" +
 	"\x0econtact_reason\x18/ \x01(\v2\x11.standards.v23.CER\rcontactReason\x121\n" +
 	"\x14contact_relationship\x180 \x01(\tR\x13contactRelationship\x12\x1b\n" +
 	"\tjob_title\x181 \x01(\tR\bjobTitle\x12-\n" +
 	"\bjob_code\x182 \x01(\v2\x12.standards.v23.JCCR\ajobCode\x12P\n" +
-	"\x1aemployer_organization_name\x183 \x01(\v2\x12.standards.v23.XONR\x18employerOrganizationName\x12\x1a\n" +
+	"\x1aemployer_organization_name\x183 \x03(\v2\x12.standards.v23.XONR\x18employerOrganizationName\x12\x1a\n" +
 	"\bhandicap\x184 \x01(\tR\bhandicap\x12\x1d\n" +
 	"\n" +
 	"job_status\x185 \x01(\tR\tjobStatus\x12:\n" +
@@ -2031,26 +2031,26 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\x06set_id\x18\x01 \x01(\tR\x05setId\x12*\n" +
 	"\aplan_id\x18\x02 \x01(\v2\x11.standards.v23.CER\x06planId\x120\n" +
 	"\n" +
-	"company_id\x18\x03 \x01(\v2\x11.standards.v23.CXR\tcompanyId\x125\n" +
-	"\fcompany_name\x18\x04 \x01(\v2\x12.standards.v23.XONR\vcompanyName\x12;\n" +
-	"\x0fcompany_address\x18\x05 \x01(\v2\x12.standards.v23.XADR\x0ecompanyAddress\x12;\n" +
-	"\x0fcompany_contact\x18\x06 \x01(\v2\x12.standards.v23.XPNR\x0ecompanyContact\x12D\n" +
-	"\x14company_phone_number\x18\a \x01(\v2\x12.standards.v23.XTNR\x12companyPhoneNumber\x12!\n" +
+	"company_id\x18\x03 \x03(\v2\x11.standards.v23.CXR\tcompanyId\x125\n" +
+	"\fcompany_name\x18\x04 \x03(\v2\x12.standards.v23.XONR\vcompanyName\x12;\n" +
+	"\x0fcompany_address\x18\x05 \x03(\v2\x12.standards.v23.XADR\x0ecompanyAddress\x12;\n" +
+	"\x0fcompany_contact\x18\x06 \x03(\v2\x12.standards.v23.XPNR\x0ecompanyContact\x12D\n" +
+	"\x14company_phone_number\x18\a \x03(\v2\x12.standards.v23.XTNR\x12companyPhoneNumber\x12!\n" +
 	"\fgroup_number\x18\b \x01(\tR\vgroupNumber\x121\n" +
 	"\n" +
-	"group_name\x18\t \x01(\v2\x12.standards.v23.XONR\tgroupName\x12=\n" +
+	"group_name\x18\t \x03(\v2\x12.standards.v23.XONR\tgroupName\x12=\n" +
 	"\x11group_employer_id\x18\n" +
-	" \x01(\v2\x11.standards.v23.CXR\x0fgroupEmployerId\x12B\n" +
-	"\x13group_employer_name\x18\v \x01(\v2\x12.standards.v23.XONR\x11groupEmployerName\x12.\n" +
+	" \x03(\v2\x11.standards.v23.CXR\x0fgroupEmployerId\x12B\n" +
+	"\x13group_employer_name\x18\v \x03(\v2\x12.standards.v23.XONR\x11groupEmployerName\x12.\n" +
 	"\x13plan_effective_date\x18\f \x01(\tR\x11planEffectiveDate\x120\n" +
 	"\x14plan_expiration_date\x18\r \x01(\tR\x12planExpirationDate\x12Q\n" +
 	"\x19authorization_information\x18\x0e \x01(\v2\x14.standards.v23.CMAUIR\x18authorizationInformation\x12\x1b\n" +
 	"\tplan_type\x18\x0f \x01(\tR\bplanType\x125\n" +
-	"\finsured_name\x18\x10 \x01(\v2\x12.standards.v23.XPNR\vinsuredName\x126\n" +
+	"\finsured_name\x18\x10 \x03(\v2\x12.standards.v23.XPNR\vinsuredName\x126\n" +
 	"\x17relationship_to_patient\x18\x11 \x01(\tR\x15relationshipToPatient\x12\x1f\n" +
 	"\vinsured_dob\x18\x12 \x01(\tR\n" +
 	"insuredDob\x12;\n" +
-	"\x0finsured_address\x18\x13 \x01(\v2\x12.standards.v23.XADR\x0einsuredAddress\x12\x10\n" +
+	"\x0finsured_address\x18\x13 \x03(\v2\x12.standards.v23.XADR\x0einsuredAddress\x12\x10\n" +
 	"\x03aob\x18\x14 \x01(\tR\x03aob\x12\x10\n" +
 	"\x03cob\x18\x15 \x01(\tR\x03cob\x12!\n" +
 	"\fcob_priority\x18\x16 \x01(\tR\vcobPriority\x12%\n" +
@@ -2061,7 +2061,7 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\x18release_information_code\x18\x1b \x01(\tR\x16releaseInformationCode\x12\x10\n" +
 	"\x03pac\x18\x1c \x01(\tR\x03pac\x124\n" +
 	"\x16verification_date_time\x18\x1d \x01(\tR\x14verificationDateTime\x12;\n" +
-	"\x0fverification_by\x18\x1e \x01(\v2\x12.standards.v23.XCNR\x0everificationBy\x12%\n" +
+	"\x0fverification_by\x18\x1e \x03(\v2\x12.standards.v23.XCNR\x0everificationBy\x12%\n" +
 	"\x0eagreement_code\x18\x1f \x01(\tR\ragreementCode\x12%\n" +
 	"\x0ebilling_status\x18  \x01(\tR\rbillingStatus\x122\n" +
 	"\x15lifetime_reserve_days\x18! \x01(\tR\x13lifetimeReserveDays\x12-\n" +
@@ -2076,7 +2076,7 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\x19insured_employment_status\x18* \x01(\v2\x11.standards.v23.CER\x17insuredEmploymentStatus\x12\x1f\n" +
 	"\vinsured_sex\x18+ \x01(\tR\n" +
 	"insuredSex\x12L\n" +
-	"\x18insured_employer_address\x18, \x01(\v2\x12.standards.v23.XADR\x16insuredEmployerAddress\x12/\n" +
+	"\x18insured_employer_address\x18, \x03(\v2\x12.standards.v23.XADR\x16insuredEmployerAddress\x12/\n" +
 	"\x13verification_status\x18- \x01(\tR\x12verificationStatus\x127\n" +
 	"\x18prior_insturance_plan_id\x18. \x01(\tR\x15priorInsturancePlanId\x12#\n" +
 	"\rcoverage_type\x18/ \x01(\tR\fcoverageType\x12\x1a\n" +
@@ -2086,13 +2086,13 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\x13insured_employee_id\x18\x01 \x01(\v2\x11.standards.v23.CXR\x11insuredEmployeeId\x12\x1f\n" +
 	"\vinsured_ssn\x18\x02 \x01(\tR\n" +
 	"insuredSsn\x12F\n" +
-	"\x15insured_employer_name\x18\x03 \x01(\v2\x12.standards.v23.XCNR\x13insuredEmployerName\x12:\n" +
+	"\x15insured_employer_name\x18\x03 \x03(\v2\x12.standards.v23.XCNR\x13insuredEmployerName\x12:\n" +
 	"\x19employer_information_data\x18\x04 \x01(\tR\x17employerInformationData\x12(\n" +
 	"\x10mail_claim_party\x18\x05 \x01(\tR\x0emailClaimParty\x120\n" +
 	"\x14medicare_card_number\x18\x06 \x01(\tR\x12medicareCardNumber\x12@\n" +
-	"\x12medicaid_case_name\x18\a \x01(\v2\x12.standards.v23.XPNR\x10medicaidCaseName\x120\n" +
+	"\x12medicaid_case_name\x18\a \x03(\v2\x12.standards.v23.XPNR\x10medicaidCaseName\x120\n" +
 	"\x14medicaid_case_number\x18\b \x01(\tR\x12medicaidCaseNumber\x12B\n" +
-	"\x13champu_sponsor_name\x18\t \x01(\v2\x12.standards.v23.XPNR\x11champuSponsorName\x12*\n" +
+	"\x13champu_sponsor_name\x18\t \x03(\v2\x12.standards.v23.XPNR\x11champuSponsorName\x12*\n" +
 	"\x11champus_id_number\x18\n" +
 	" \x01(\tR\x0fchampusIdNumber\x12Q\n" +
 	"\x1bchampus_dependent_recipient\x18\v \x01(\v2\x11.standards.v23.CER\x19champusDependentRecipient\x121\n" +
@@ -2106,26 +2106,26 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\rbaby_coverage\x18\x13 \x01(\tR\fbabyCoverage\x12*\n" +
 	"\x11combine_baby_bill\x18\x14 \x01(\tR\x0fcombineBabyBill\x12)\n" +
 	"\x10blood_deductible\x18\x15 \x01(\tR\x0fbloodDeductible\x12W\n" +
-	"\x1especial_coverage_approval_name\x18\x16 \x01(\v2\x12.standards.v23.XPNR\x1bspecialCoverageApprovalName\x12E\n" +
+	"\x1especial_coverage_approval_name\x18\x16 \x03(\v2\x12.standards.v23.XPNR\x1bspecialCoverageApprovalName\x12E\n" +
 	"\x1fspecial_coverage_approval_title\x18\x17 \x01(\tR\x1cspecialCoverageApprovalTitle\x12:\n" +
 	"\x19noncovered_insurance_code\x18\x18 \x01(\tR\x17noncoveredInsuranceCode\x12,\n" +
-	"\bpayor_id\x18\x19 \x01(\v2\x11.standards.v23.CXR\apayorId\x12A\n" +
-	"\x13payor_subscriber_id\x18\x1a \x01(\v2\x11.standards.v23.CXR\x11payorSubscriberId\x12-\n" +
+	"\bpayor_id\x18\x19 \x03(\v2\x11.standards.v23.CXR\apayorId\x12A\n" +
+	"\x13payor_subscriber_id\x18\x1a \x03(\v2\x11.standards.v23.CXR\x11payorSubscriberId\x12-\n" +
 	"\x12eligibility_source\x18\x1b \x01(\tR\x11eligibilitySource\x12B\n" +
-	"\x12room_coverage_type\x18\x1c \x01(\v2\x14.standards.v23.CMPLTR\x10roomCoverageType\x125\n" +
-	"\vpolicy_type\x18\x1d \x01(\v2\x14.standards.v23.CMPLTR\n" +
+	"\x12room_coverage_type\x18\x1c \x03(\v2\x14.standards.v23.CMPLTR\x10roomCoverageType\x125\n" +
+	"\vpolicy_type\x18\x1d \x03(\v2\x14.standards.v23.CMPLTR\n" +
 	"policyType\x12?\n" +
 	"\x10daily_deductible\x18\x1e \x01(\v2\x14.standards.v23.CMDDER\x0fdailyDeductible\x12+\n" +
 	"\x11living_dependency\x18\x1f \x01(\tR\x10livingDependency\x12+\n" +
 	"\x11ambulatory_status\x18  \x01(\tR\x10ambulatoryStatus\x12 \n" +
-	"\vcitizenship\x18! \x01(\tR\vcitizenship\x12<\n" +
+	"\vcitizenship\x18! \x03(\tR\vcitizenship\x12<\n" +
 	"\x10primary_language\x18\" \x01(\v2\x11.standards.v23.CER\x0fprimaryLanguage\x12-\n" +
 	"\x12living_arrangement\x18# \x01(\tR\x11livingArrangement\x12B\n" +
 	"\x13publicity_indicator\x18$ \x01(\v2\x11.standards.v23.CER\x12publicityIndicator\x121\n" +
 	"\x14protection_indicator\x18% \x01(\tR\x13protectionIndicator\x12+\n" +
 	"\x11student_indicator\x18& \x01(\tR\x10studentIndicator\x12\x1a\n" +
 	"\breligion\x18' \x01(\tR\breligion\x12@\n" +
-	"\x12mother_maiden_name\x18( \x01(\v2\x12.standards.v23.XPNR\x10motherMaidenName\x123\n" +
+	"\x12mother_maiden_name\x18( \x03(\v2\x12.standards.v23.XPNR\x10motherMaidenName\x123\n" +
 	"\vnationality\x18) \x01(\v2\x11.standards.v23.CER\vnationality\x12!\n" +
 	"\fethnic_group\x18* \x01(\tR\vethnicGroup\x12%\n" +
 	"\x0emarital_status\x18+ \x01(\tR\rmaritalStatus\x12A\n" +
@@ -2135,28 +2135,28 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\bjob_code\x18/ \x01(\v2\x12.standards.v23.JCCR\ajobCode\x12\x1d\n" +
 	"\n" +
 	"job_status\x180 \x01(\tR\tjobStatus\x12F\n" +
-	"\x15employer_contact_name\x181 \x01(\v2\x12.standards.v23.XPNR\x13employerContactName\x12U\n" +
-	"\x1demployer_contact_phone_number\x182 \x01(\v2\x12.standards.v23.XTNR\x1aemployerContactPhoneNumber\x126\n" +
+	"\x15employer_contact_name\x181 \x03(\v2\x12.standards.v23.XPNR\x13employerContactName\x12U\n" +
+	"\x1demployer_contact_phone_number\x182 \x03(\v2\x12.standards.v23.XTNR\x1aemployerContactPhoneNumber\x126\n" +
 	"\x17employer_contact_reason\x183 \x01(\tR\x15employerContactReason\x12D\n" +
-	"\x14insured_contact_name\x184 \x01(\v2\x12.standards.v23.XPNR\x12insuredContactName\x12S\n" +
-	"\x1cinsured_contact_phone_numbet\x185 \x01(\v2\x12.standards.v23.XTNR\x19insuredContactPhoneNumbet\x124\n" +
+	"\x14insured_contact_name\x184 \x03(\v2\x12.standards.v23.XPNR\x12insuredContactName\x12S\n" +
+	"\x1cinsured_contact_phone_numbet\x185 \x03(\v2\x12.standards.v23.XTNR\x19insuredContactPhoneNumbet\x124\n" +
 	"\x16insured_contact_reason\x186 \x01(\tR\x14insuredContactReason\x12J\n" +
 	"\"relationship_to_patient_start_date\x187 \x01(\tR\x1erelationshipToPatientStartDate\x12H\n" +
 	"!relationship_to_patient_stop_date\x188 \x01(\tR\x1drelationshipToPatientStopDate\x12G\n" +
 	" insurance_company_contact_reason\x189 \x01(\tR\x1dinsuranceCompanyContactReason\x12f\n" +
-	"&insurance_company_contact_phone_number\x18: \x01(\v2\x12.standards.v23.XTNR\"insuranceCompanyContactPhoneNumber\x12!\n" +
+	"&insurance_company_contact_phone_number\x18: \x03(\v2\x12.standards.v23.XTNR\"insuranceCompanyContactPhoneNumber\x12!\n" +
 	"\fpolicy_scope\x18; \x01(\tR\vpolicyScope\x12#\n" +
 	"\rpolicy_source\x18< \x01(\tR\fpolicySource\x12E\n" +
-	"\x15patient_member_number\x18= \x01(\v2\x11.standards.v23.CXR\x13patientMemberNumber\x125\n" +
+	"\x15patient_member_number\x18= \x03(\v2\x11.standards.v23.CXR\x13patientMemberNumber\x125\n" +
 	"\x16guarantor_relationship\x18> \x01(\tR\x15guarantorRelationship\x12M\n" +
-	"\x19insured_home_phone_number\x18? \x01(\v2\x12.standards.v23.XTNR\x16insuredHomePhoneNumber\x12K\n" +
-	"\x18insured_home_work_number\x18@ \x01(\v2\x12.standards.v23.XTNR\x15insuredHomeWorkNumber\x12S\n" +
+	"\x19insured_home_phone_number\x18? \x03(\v2\x12.standards.v23.XTNR\x16insuredHomePhoneNumber\x12K\n" +
+	"\x18insured_home_work_number\x18@ \x03(\v2\x12.standards.v23.XTNR\x15insuredHomeWorkNumber\x12S\n" +
 	"\x1cmilitary_handicapped_program\x18A \x01(\v2\x11.standards.v23.CER\x1amilitaryHandicappedProgram\x12!\n" +
 	"\fsuspend_flag\x18B \x01(\tR\vsuspendFlag\x12(\n" +
 	"\x10copay_limit_flag\x18C \x01(\tR\x0ecopayLimitFlag\x12.\n" +
 	"\x13stoploss_limit_flag\x18D \x01(\tR\x11stoplossLimitFlag\x12N\n" +
-	"\x19insured_organization_name\x18E \x01(\v2\x12.standards.v23.XONR\x17insuredOrganizationName\x12_\n" +
-	"\"insured_employer_organization_name\x18F \x01(\v2\x12.standards.v23.XONR\x1finsuredEmployerOrganizationName\x12\x12\n" +
+	"\x19insured_organization_name\x18E \x03(\v2\x12.standards.v23.XONR\x17insuredOrganizationName\x12_\n" +
+	"\"insured_employer_organization_name\x18F \x03(\v2\x12.standards.v23.XONR\x1finsuredEmployerOrganizationName\x12\x12\n" +
 	"\x04race\x18G \x01(\tR\x04race\x12a\n" +
 	"$hcfa_patient_relationship_to_insured\x18H \x01(\v2\x11.standards.v23.CER hcfaPatientRelationshipToInsured\"\x94\f\n" +
 	"\x03IN3\x12\x15\n" +
@@ -2176,16 +2176,16 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\x1enon_concur_effective_date_time\x18\r \x01(\tR\x1anonConcurEffectiveDateTime\x12A\n" +
 	"\x12physician_reviewer\x18\x0e \x01(\v2\x12.standards.v23.XCNR\x11physicianReviewer\x123\n" +
 	"\x15certification_contact\x18\x0f \x01(\tR\x14certificationContact\x12_\n" +
-	"\"certification_contact_phone_number\x18\x10 \x01(\v2\x12.standards.v23.XTNR\x1fcertificationContactPhoneNumber\x126\n" +
+	"\"certification_contact_phone_number\x18\x10 \x03(\v2\x12.standards.v23.XTNR\x1fcertificationContactPhoneNumber\x126\n" +
 	"\rappeal_reason\x18\x11 \x01(\v2\x11.standards.v23.CER\fappealReason\x12D\n" +
 	"\x14certification_agency\x18\x12 \x01(\v2\x11.standards.v23.CER\x13certificationAgency\x12]\n" +
-	"!certification_agency_phone_number\x18\x13 \x01(\v2\x12.standards.v23.XTNR\x1ecertificationAgencyPhoneNumber\x12S\n" +
-	"\x1bpre_cert_requirement_window\x18\x14 \x01(\v2\x14.standards.v23.CMPCRR\x18preCertRequirementWindow\x12!\n" +
+	"!certification_agency_phone_number\x18\x13 \x03(\v2\x12.standards.v23.XTNR\x1ecertificationAgencyPhoneNumber\x12S\n" +
+	"\x1bpre_cert_requirement_window\x18\x14 \x03(\v2\x14.standards.v23.CMPCRR\x18preCertRequirementWindow\x12!\n" +
 	"\fcase_manager\x18\x15 \x01(\tR\vcaseManager\x12.\n" +
 	"\x13second_opinion_date\x18\x16 \x01(\tR\x11secondOpinionDate\x122\n" +
 	"\x15second_opinion_status\x18\x17 \x01(\tR\x13secondOpinionStatus\x12Q\n" +
 	"%second_opinion_documentation_received\x18\x18 \x01(\tR\"secondOpinionDocumentationReceived\x12L\n" +
-	"\x18second_opinion_physician\x18\x19 \x01(\v2\x12.standards.v23.XCNR\x16secondOpinionPhysician\"\xf2\x06\n" +
+	"\x18second_opinion_physician\x18\x19 \x03(\v2\x12.standards.v23.XCNR\x16secondOpinionPhysician\"\xf2\x06\n" +
 	"\x03DG1\x12\x15\n" +
 	"\x06set_id\x18\x01 \x01(\tR\x05setId\x12#\n" +
 	"\rcoding_method\x18\x02 \x01(\tR\fcodingMethod\x12%\n" +
@@ -2203,7 +2203,7 @@ const file_standards_v23_financial_proto_rawDesc = "" +
 	"\foutlier_cost\x18\r \x01(\v2\x11.standards.v23.CPR\voutlierCost\x12'\n" +
 	"\x0fgoruper_version\x18\x0e \x01(\tR\x0egoruperVersion\x12\x1a\n" +
 	"\bpriority\x18\x0f \x01(\tR\bpriority\x12E\n" +
-	"\x14diagnosing_clinician\x18\x10 \x01(\v2\x12.standards.v23.XCNR\x13diagnosingClinician\x12&\n" +
+	"\x14diagnosing_clinician\x18\x10 \x03(\v2\x12.standards.v23.XCNR\x13diagnosingClinician\x12&\n" +
 	"\x0eclassification\x18\x11 \x01(\tR\x0eclassification\x125\n" +
 	"\x16confidential_indicator\x18\x12 \x01(\tR\x15confidentialIndicator\x122\n" +
 	"\x15attestation_date_time\x18\x13 \x01(\tR\x13attestationDateTimeB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
@@ -541,8 +541,8 @@ func (x *ObsPatientGroup) GetVisit() *PatientVisitGroup {
 
 type ObsOrderGroup struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// @gotags: hl7:"ORC,required"
-	ORC *ORC `protobuf:"bytes,1,opt,name=ORC,proto3" json:"ORC,omitempty" hl7:"ORC,required"`
+	// @gotags: hl7:"ORC"
+	ORC *ORC `protobuf:"bytes,1,opt,name=ORC,proto3" json:"ORC,omitempty" hl7:"ORC"`
 	// @gotags: hl7:"OBR,required"
 	OBR *OBR   `protobuf:"bytes,2,opt,name=OBR,proto3" json:"OBR,omitempty" hl7:"OBR,required"`
 	NTE []*NTE `protobuf:"bytes,3,rep,name=NTE,proto3" json:"NTE,omitempty"`
@@ -21,6 +21,66 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ACK struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MSH           *MSH                   `protobuf:"bytes,1,opt,name=MSH,proto3" json:"MSH,omitempty"`
+	MSA           *MSA                   `protobuf:"bytes,2,opt,name=MSA,proto3" json:"MSA,omitempty"`
+	ERR           *ERR                   `protobuf:"bytes,3,opt,name=ERR,proto3" json:"ERR,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ACK) Reset() {
+	*x = ACK{}
+	mi := &file_standards_v23_messages_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ACK) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ACK) ProtoMessage() {}
+
+func (x *ACK) ProtoReflect() protoreflect.Message {
+	mi := &file_standards_v23_messages_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ACK.ProtoReflect.Descriptor instead.
+func (*ACK) Descriptor() ([]byte, []int) {
+	return file_standards_v23_messages_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ACK) GetMSH() *MSH {
+	if x != nil {
+		return x.MSH
+	}
+	return nil
+}
+
+func (x *ACK) GetMSA() *MSA {
+	if x != nil {
+		return x.MSA
+	}
+	return nil
+}
+
+func (x *ACK) GetERR() *ERR {
+	if x != nil {
+		return x.ERR
+	}
+	return nil
+}
+
 type ORM_O01 struct {
 	state        protoimpl.MessageState `protogen:"open.v1"`
 	MSH          *MSH                   `protobuf:"bytes,1,opt,name=MSH,proto3" json:"MSH,omitempty"`
@@ -34,7 +94,7 @@ type ORM_O01 struct {
 
 func (x *ORM_O01) Reset() {
 	*x = ORM_O01{}
-	mi := &file_standards_v23_messages_proto_msgTypes[0]
+	mi := &file_standards_v23_messages_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -46,7 +106,7 @@ func (x *ORM_O01) String() string {
 func (*ORM_O01) ProtoMessage() {}
 
 func (x *ORM_O01) ProtoReflect() protoreflect.Message {
-	mi := &file_standards_v23_messages_proto_msgTypes[0]
+	mi := &file_standards_v23_messages_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -59,7 +119,7 @@ func (x *ORM_O01) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ORM_O01.ProtoReflect.Descriptor instead.
 func (*ORM_O01) Descriptor() ([]byte, []int) {
-	return file_standards_v23_messages_proto_rawDescGZIP(), []int{0}
+	return file_standards_v23_messages_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *ORM_O01) GetMSH() *MSH {
@@ -102,7 +162,7 @@ type ORU_R01 struct {
 
 func (x *ORU_R01) Reset() {
 	*x = ORU_R01{}
-	mi := &file_standards_v23_messages_proto_msgTypes[1]
+	mi := &file_standards_v23_messages_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -114,7 +174,7 @@ func (x *ORU_R01) String() string {
 func (*ORU_R01) ProtoMessage() {}
 
 func (x *ORU_R01) ProtoReflect() protoreflect.Message {
-	mi := &file_standards_v23_messages_proto_msgTypes[1]
+	mi := &file_standards_v23_messages_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -127,7 +187,7 @@ func (x *ORU_R01) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ORU_R01.ProtoReflect.Descriptor instead.
 func (*ORU_R01) Descriptor() ([]byte, []int) {
-	return file_standards_v23_messages_proto_rawDescGZIP(), []int{1}
+	return file_standards_v23_messages_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *ORU_R01) GetMSH() *MSH {
@@ -155,7 +215,11 @@ var File_standards_v23_messages_proto protoreflect.FileDescriptor
 
 const file_standards_v23_messages_proto_rawDesc = "" +
 	"\n" +
-	"\x1cstandards/v23/messages.proto\x12\rstandards.v23\x1a\x1bstandards/v23/control.proto\x1a\x1astandards/v23/groups.proto\"\xd5\x01\n" +
+	"\x1cstandards/v23/messages.proto\x12\rstandards.v23\x1a\x1bstandards/v23/control.proto\x1a\x1astandards/v23/groups.proto\"w\n" +
+	"\x03ACK\x12$\n" +
+	"\x03MSH\x18\x01 \x01(\v2\x12.standards.v23.MSHR\x03MSH\x12$\n" +
+	"\x03MSA\x18\x02 \x01(\v2\x12.standards.v23.MSAR\x03MSA\x12$\n" +
+	"\x03ERR\x18\x03 \x01(\v2\x12.standards.v23.ERRR\x03ERR\"\xd5\x01\n" +
 	"\aORM_O01\x12$\n" +
 	"\x03MSH\x18\x01 \x01(\v2\x12.standards.v23.MSHR\x03MSH\x12$\n" +
 	"\x03NTE\x18\x02 \x01(\v2\x12.standards.v23.NTER\x03NTE\x12@\n" +
@@ -178,30 +242,36 @@ func file_standards_v23_messages_proto_rawDescGZIP() []byte {
 	return file_standards_v23_messages_proto_rawDescData
 }
 
-var file_standards_v23_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_standards_v23_messages_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_standards_v23_messages_proto_goTypes = []any{
-	(*ORM_O01)(nil),      // 0: standards.v23.ORM_O01
-	(*ORU_R01)(nil),      // 1: standards.v23.ORU_R01
-	(*MSH)(nil),          // 2: standards.v23.MSH
-	(*NTE)(nil),          // 3: standards.v23.NTE
-	(*PatientGroup)(nil), // 4: standards.v23.PatientGroup
-	(*OrderGroup)(nil),   // 5: standards.v23.OrderGroup
-	(*ResultGroup)(nil),  // 6: standards.v23.ResultGroup
-	(*DSC)(nil),          // 7: standards.v23.DSC
+	(*ACK)(nil),          // 0: standards.v23.ACK
+	(*ORM_O01)(nil),      // 1: standards.v23.ORM_O01
+	(*ORU_R01)(nil),      // 2: standards.v23.ORU_R01
+	(*MSH)(nil),          // 3: standards.v23.MSH
+	(*MSA)(nil),          // 4: standards.v23.MSA
+	(*ERR)(nil),          // 5: standards.v23.ERR
+	(*NTE)(nil),          // 6: standards.v23.NTE
+	(*PatientGroup)(nil), // 7: standards.v23.PatientGroup
+	(*OrderGroup)(nil),   // 8: standards.v23.OrderGroup
+	(*ResultGroup)(nil),  // 9: standards.v23.ResultGroup
+	(*DSC)(nil),          // 10: standards.v23.DSC
 }
 var file_standards_v23_messages_proto_depIdxs = []int32{
-	2, // 0: standards.v23.ORM_O01.MSH:type_name -> standards.v23.MSH
-	3, // 1: standards.v23.ORM_O01.NTE:type_name -> standards.v23.NTE
-	4, // 2: standards.v23.ORM_O01.patient_group:type_name -> standards.v23.PatientGroup
-	5, // 3: standards.v23.ORM_O01.order_groups:type_name -> standards.v23.OrderGroup
-	2, // 4: standards.v23.ORU_R01.MSH:type_name -> standards.v23.MSH
-	6, // 5: standards.v23.ORU_R01.results:type_name -> standards.v23.ResultGroup
-	7, // 6: standards.v23.ORU_R01.DSC:type_name -> standards.v23.DSC
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	3,  // 0: standards.v23.ACK.MSH:type_name -> standards.v23.MSH
+	4,  // 1: standards.v23.ACK.MSA:type_name -> standards.v23.MSA
+	5,  // 2: standards.v23.ACK.ERR:type_name -> standards.v23.ERR
+	3,  // 3: standards.v23.ORM_O01.MSH:type_name -> standards.v23.MSH
+	6,  // 4: standards.v23.ORM_O01.NTE:type_name -> standards.v23.NTE
+	7,  // 5: standards.v23.ORM_O01.patient_group:type_name -> standards.v23.PatientGroup
+	8,  // 6: standards.v23.ORM_O01.order_groups:type_name -> standards.v23.OrderGroup
+	3,  // 7: standards.v23.ORU_R01.MSH:type_name -> standards.v23.MSH
+	9,  // 8: standards.v23.ORU_R01.results:type_name -> standards.v23.ResultGroup
+	10, // 9: standards.v23.ORU_R01.DSC:type_name -> standards.v23.DSC
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_standards_v23_messages_proto_init() }
@@ -217,7 +287,7 @@ func file_standards_v23_messages_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_standards_v23_messages_proto_rawDesc), len(file_standards_v23_messages_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
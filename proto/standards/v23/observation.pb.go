@@ -27,10 +27,10 @@ type OBX struct {
 	ValueType                    string                 `protobuf:"bytes,2,opt,name=value_type,json=valueType,proto3" json:"value_type,omitempty"`
 	ObservationIdentifier        *CE                    `protobuf:"bytes,3,opt,name=observation_identifier,json=observationIdentifier,proto3" json:"observation_identifier,omitempty"`
 	ObservationSubId             string                 `protobuf:"bytes,4,opt,name=observation_sub_id,json=observationSubId,proto3" json:"observation_sub_id,omitempty"`
-	ObservationValue             string                 `protobuf:"bytes,5,opt,name=observation_value,json=observationValue,proto3" json:"observation_value,omitempty"`
+	ObservationValue             []string               `protobuf:"bytes,5,rep,name=observation_value,json=observationValue,proto3" json:"observation_value,omitempty"`
 	Units                        *CE                    `protobuf:"bytes,6,opt,name=units,proto3" json:"units,omitempty"`
 	ReferencesRange              string                 `protobuf:"bytes,7,opt,name=references_range,json=referencesRange,proto3" json:"references_range,omitempty"`
-	AbnormalFlags                string                 `protobuf:"bytes,8,opt,name=abnormal_flags,json=abnormalFlags,proto3" json:"abnormal_flags,omitempty"`
+	AbnormalFlags                []string               `protobuf:"bytes,8,rep,name=abnormal_flags,json=abnormalFlags,proto3" json:"abnormal_flags,omitempty"`
 	Probability                  string                 `protobuf:"bytes,9,opt,name=probability,proto3" json:"probability,omitempty"`
 	AbnormalTestNature           string                 `protobuf:"bytes,10,opt,name=abnormal_test_nature,json=abnormalTestNature,proto3" json:"abnormal_test_nature,omitempty"`
 	ResultStatus                 string                 `protobuf:"bytes,11,opt,name=result_status,json=resultStatus,proto3" json:"result_status,omitempty"`
@@ -39,7 +39,7 @@ type OBX struct {
 	ObservationDateTime          string                 `protobuf:"bytes,14,opt,name=observation_date_time,json=observationDateTime,proto3" json:"observation_date_time,omitempty"`
 	ProducerId                   *CE                    `protobuf:"bytes,15,opt,name=producer_id,json=producerId,proto3" json:"producer_id,omitempty"`
 	ResponsibleObserver          *XCN                   `protobuf:"bytes,16,opt,name=responsible_observer,json=responsibleObserver,proto3" json:"responsible_observer,omitempty"`
-	ObservationMethod            *CE                    `protobuf:"bytes,17,opt,name=observation_method,json=observationMethod,proto3" json:"observation_method,omitempty"`
+	ObservationMethod            []*CE                  `protobuf:"bytes,17,rep,name=observation_method,json=observationMethod,proto3" json:"observation_method,omitempty"`
 	unknownFields                protoimpl.UnknownFields
 	sizeCache                    protoimpl.SizeCache
 }
@@ -102,11 +102,11 @@ func (x *OBX) GetObservationSubId() string {
 	return ""
 }
 
-func (x *OBX) GetObservationValue() string {
+func (x *OBX) GetObservationValue() []string {
 	if x != nil {
 		return x.ObservationValue
 	}
-	return ""
+	return nil
 }
 
 func (x *OBX) GetUnits() *CE {
@@ -123,11 +123,11 @@ func (x *OBX) GetReferencesRange() string {
 	return ""
 }
 
-func (x *OBX) GetAbnormalFlags() string {
+func (x *OBX) GetAbnormalFlags() []string {
 	if x != nil {
 		return x.AbnormalFlags
 	}
-	return ""
+	return nil
 }
 
 func (x *OBX) GetProbability() string {
@@ -186,7 +186,7 @@ func (x *OBX) GetResponsibleObserver() *XCN {
 	return nil
 }
 
-func (x *OBX) GetObservationMethod() *CE {
+func (x *OBX) GetObservationMethod() []*CE {
 	if x != nil {
 		return x.ObservationMethod
 	}
@@ -204,10 +204,10 @@ const file_standards_v23_observation_proto_rawDesc = "" +
 	"value_type\x18\x02 \x01(\tR\tvalueType\x12H\n" +
 	"\x16observation_identifier\x18\x03 \x01(\v2\x11.standards.v23.CER\x15observationIdentifier\x12,\n" +
 	"\x12observation_sub_id\x18\x04 \x01(\tR\x10observationSubId\x12+\n" +
-	"\x11observation_value\x18\x05 \x01(\tR\x10observationValue\x12'\n" +
+	"\x11observation_value\x18\x05 \x03(\tR\x10observationValue\x12'\n" +
 	"\x05units\x18\x06 \x01(\v2\x11.standards.v23.CER\x05units\x12)\n" +
 	"\x10references_range\x18\a \x01(\tR\x0freferencesRange\x12%\n" +
-	"\x0eabnormal_flags\x18\b \x01(\tR\rabnormalFlags\x12 \n" +
+	"\x0eabnormal_flags\x18\b \x03(\tR\rabnormalFlags\x12 \n" +
 	"\vprobability\x18\t \x01(\tR\vprobability\x120\n" +
 	"\x14abnormal_test_nature\x18\n" +
 	" \x01(\tR\x12abnormalTestNature\x12#\n" +
@@ -218,7 +218,7 @@ const file_standards_v23_observation_proto_rawDesc = "" +
 	"\vproducer_id\x18\x0f \x01(\v2\x11.standards.v23.CER\n" +
 	"producerId\x12E\n" +
 	"\x14responsible_observer\x18\x10 \x01(\v2\x12.standards.v23.XCNR\x13responsibleObserver\x12@\n" +
-	"\x12observation_method\x18\x11 \x01(\v2\x11.standards.v23.CER\x11observationMethodB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
+	"\x12observation_method\x18\x11 \x03(\v2\x11.standards.v23.CER\x11observationMethodB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
 
 var (
 	file_standards_v23_observation_proto_rawDescOnce sync.Once
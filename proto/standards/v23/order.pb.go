@@ -36,7 +36,7 @@ type ORC struct {
 	VerifiedBy             *XCN                   `protobuf:"bytes,11,opt,name=verified_by,json=verifiedBy,proto3" json:"verified_by,omitempty"`
 	OrderingProvider       *XCN                   `protobuf:"bytes,12,opt,name=ordering_provider,json=orderingProvider,proto3" json:"ordering_provider,omitempty"`
 	EntryLocation          *PL                    `protobuf:"bytes,13,opt,name=entry_location,json=entryLocation,proto3" json:"entry_location,omitempty"`
-	CallbackPhoneNumber    *XTN                   `protobuf:"bytes,14,opt,name=callback_phone_number,json=callbackPhoneNumber,proto3" json:"callback_phone_number,omitempty"`
+	CallbackPhoneNumber    []*XTN                 `protobuf:"bytes,14,rep,name=callback_phone_number,json=callbackPhoneNumber,proto3" json:"callback_phone_number,omitempty"`
 	EffectiveDateTime      string                 `protobuf:"bytes,15,opt,name=effective_date_time,json=effectiveDateTime,proto3" json:"effective_date_time,omitempty"`
 	OrderControlCodeReason *CE                    `protobuf:"bytes,16,opt,name=order_control_code_reason,json=orderControlCodeReason,proto3" json:"order_control_code_reason,omitempty"`
 	EnteringOrganization   *CE                    `protobuf:"bytes,17,opt,name=entering_organization,json=enteringOrganization,proto3" json:"entering_organization,omitempty"`
@@ -167,7 +167,7 @@ func (x *ORC) GetEntryLocation() *PL {
 	return nil
 }
 
-func (x *ORC) GetCallbackPhoneNumber() *XTN {
+func (x *ORC) GetCallbackPhoneNumber() []*XTN {
 	if x != nil {
 		return x.CallbackPhoneNumber
 	}
@@ -220,14 +220,14 @@ type OBR struct {
 	ObservationDateTime                string                 `protobuf:"bytes,7,opt,name=observation_date_time,json=observationDateTime,proto3" json:"observation_date_time,omitempty"`
 	ObservationEndDateTime             string                 `protobuf:"bytes,8,opt,name=observation_end_date_time,json=observationEndDateTime,proto3" json:"observation_end_date_time,omitempty"`
 	CollectionVolume                   *CQ                    `protobuf:"bytes,9,opt,name=collection_volume,json=collectionVolume,proto3" json:"collection_volume,omitempty"`
-	CollectorIdentifier                *XCN                   `protobuf:"bytes,10,opt,name=collector_identifier,json=collectorIdentifier,proto3" json:"collector_identifier,omitempty"`
+	CollectorIdentifier                []*XCN                 `protobuf:"bytes,10,rep,name=collector_identifier,json=collectorIdentifier,proto3" json:"collector_identifier,omitempty"`
 	SpecimenActionCode                 string                 `protobuf:"bytes,11,opt,name=specimen_action_code,json=specimenActionCode,proto3" json:"specimen_action_code,omitempty"`
 	DangerCode                         *CE                    `protobuf:"bytes,12,opt,name=danger_code,json=dangerCode,proto3" json:"danger_code,omitempty"`
 	RelevantClinicalInfo               string                 `protobuf:"bytes,13,opt,name=relevant_clinical_info,json=relevantClinicalInfo,proto3" json:"relevant_clinical_info,omitempty"`
 	SpecimenReceivedDateTime           string                 `protobuf:"bytes,14,opt,name=specimen_received_date_time,json=specimenReceivedDateTime,proto3" json:"specimen_received_date_time,omitempty"`
 	SpecimenSource                     *CMSPE                 `protobuf:"bytes,15,opt,name=specimen_source,json=specimenSource,proto3" json:"specimen_source,omitempty"`
-	OrderingProvider                   *XCN                   `protobuf:"bytes,16,opt,name=ordering_provider,json=orderingProvider,proto3" json:"ordering_provider,omitempty"`
-	OrderCallbackPhoneNumber           *XTN                   `protobuf:"bytes,17,opt,name=order_callback_phone_number,json=orderCallbackPhoneNumber,proto3" json:"order_callback_phone_number,omitempty"`
+	OrderingProvider                   []*XCN                 `protobuf:"bytes,16,rep,name=ordering_provider,json=orderingProvider,proto3" json:"ordering_provider,omitempty"`
+	OrderCallbackPhoneNumber           []*XTN                 `protobuf:"bytes,17,rep,name=order_callback_phone_number,json=orderCallbackPhoneNumber,proto3" json:"order_callback_phone_number,omitempty"`
 	PlacerField_1                      string                 `protobuf:"bytes,18,opt,name=placer_field_1,json=placerField1,proto3" json:"placer_field_1,omitempty"`
 	PlacerField_2                      string                 `protobuf:"bytes,19,opt,name=placer_field_2,json=placerField2,proto3" json:"placer_field_2,omitempty"`
 	FillerField_1                      string                 `protobuf:"bytes,20,opt,name=filler_field_1,json=fillerField1,proto3" json:"filler_field_1,omitempty"`
@@ -238,22 +238,22 @@ type OBR struct {
 	ResultStatus                       string                 `protobuf:"bytes,25,opt,name=result_status,json=resultStatus,proto3" json:"result_status,omitempty"`
 	ParentResult                       *CMPRE                 `protobuf:"bytes,26,opt,name=parent_result,json=parentResult,proto3" json:"parent_result,omitempty"`
 	QuantityTiming                     string                 `protobuf:"bytes,27,opt,name=quantity_timing,json=quantityTiming,proto3" json:"quantity_timing,omitempty"`
-	ResultCopiesTo                     *XCN                   `protobuf:"bytes,28,opt,name=result_copies_to,json=resultCopiesTo,proto3" json:"result_copies_to,omitempty"`
+	ResultCopiesTo                     []*XCN                 `protobuf:"bytes,28,rep,name=result_copies_to,json=resultCopiesTo,proto3" json:"result_copies_to,omitempty"`
 	Parent                             *CMPOR                 `protobuf:"bytes,29,opt,name=parent,proto3" json:"parent,omitempty"`
 	TransportationMode                 string                 `protobuf:"bytes,30,opt,name=transportation_mode,json=transportationMode,proto3" json:"transportation_mode,omitempty"`
-	ReasonForStudy                     *CE                    `protobuf:"bytes,31,opt,name=reason_for_study,json=reasonForStudy,proto3" json:"reason_for_study,omitempty"`
+	ReasonForStudy                     []*CE                  `protobuf:"bytes,31,rep,name=reason_for_study,json=reasonForStudy,proto3" json:"reason_for_study,omitempty"`
 	PrincipalResultInterpreter         *CMOBS                 `protobuf:"bytes,32,opt,name=principal_result_interpreter,json=principalResultInterpreter,proto3" json:"principal_result_interpreter,omitempty"`
-	AssistantResultInterpreter         *CMOBS                 `protobuf:"bytes,33,opt,name=assistant_result_interpreter,json=assistantResultInterpreter,proto3" json:"assistant_result_interpreter,omitempty"`
-	Technician                         *CMOBS                 `protobuf:"bytes,34,opt,name=technician,proto3" json:"technician,omitempty"`
-	Transcriptionist                   *CMOBS                 `protobuf:"bytes,35,opt,name=transcriptionist,proto3" json:"transcriptionist,omitempty"`
+	AssistantResultInterpreter         []*CMOBS               `protobuf:"bytes,33,rep,name=assistant_result_interpreter,json=assistantResultInterpreter,proto3" json:"assistant_result_interpreter,omitempty"`
+	Technician                         []*CMOBS               `protobuf:"bytes,34,rep,name=technician,proto3" json:"technician,omitempty"`
+	Transcriptionist                   []*CMOBS               `protobuf:"bytes,35,rep,name=transcriptionist,proto3" json:"transcriptionist,omitempty"`
 	ScheduledDateTime                  string                 `protobuf:"bytes,36,opt,name=scheduled_date_time,json=scheduledDateTime,proto3" json:"scheduled_date_time,omitempty"`
 	SampleContainersCount              string                 `protobuf:"bytes,37,opt,name=sample_containers_count,json=sampleContainersCount,proto3" json:"sample_containers_count,omitempty"`
 	SampleTransportLogistics           *CE                    `protobuf:"bytes,38,opt,name=sample_transport_logistics,json=sampleTransportLogistics,proto3" json:"sample_transport_logistics,omitempty"`
-	CollectorComment                   *CE                    `protobuf:"bytes,39,opt,name=collector_comment,json=collectorComment,proto3" json:"collector_comment,omitempty"`
+	CollectorComment                   []*CE                  `protobuf:"bytes,39,rep,name=collector_comment,json=collectorComment,proto3" json:"collector_comment,omitempty"`
 	TransportArrangementResponsibility *CE                    `protobuf:"bytes,40,opt,name=transport_arrangement_responsibility,json=transportArrangementResponsibility,proto3" json:"transport_arrangement_responsibility,omitempty"`
 	TransportArranged                  string                 `protobuf:"bytes,41,opt,name=transport_arranged,json=transportArranged,proto3" json:"transport_arranged,omitempty"`
 	EscortRequired                     string                 `protobuf:"bytes,42,opt,name=escort_required,json=escortRequired,proto3" json:"escort_required,omitempty"`
-	PlannedPatientTransportComment     *CE                    `protobuf:"bytes,43,opt,name=planned_patient_transport_comment,json=plannedPatientTransportComment,proto3" json:"planned_patient_transport_comment,omitempty"`
+	PlannedPatientTransportComment     []*CE                  `protobuf:"bytes,43,rep,name=planned_patient_transport_comment,json=plannedPatientTransportComment,proto3" json:"planned_patient_transport_comment,omitempty"`
 	unknownFields                      protoimpl.UnknownFields
 	sizeCache                          protoimpl.SizeCache
 }
@@ -351,7 +351,7 @@ func (x *OBR) GetCollectionVolume() *CQ {
 	return nil
 }
 
-func (x *OBR) GetCollectorIdentifier() *XCN {
+func (x *OBR) GetCollectorIdentifier() []*XCN {
 	if x != nil {
 		return x.CollectorIdentifier
 	}
@@ -393,14 +393,14 @@ func (x *OBR) GetSpecimenSource() *CMSPE {
 	return nil
 }
 
-func (x *OBR) GetOrderingProvider() *XCN {
+func (x *OBR) GetOrderingProvider() []*XCN {
 	if x != nil {
 		return x.OrderingProvider
 	}
 	return nil
 }
 
-func (x *OBR) GetOrderCallbackPhoneNumber() *XTN {
+func (x *OBR) GetOrderCallbackPhoneNumber() []*XTN {
 	if x != nil {
 		return x.OrderCallbackPhoneNumber
 	}
@@ -477,7 +477,7 @@ func (x *OBR) GetQuantityTiming() string {
 	return ""
 }
 
-func (x *OBR) GetResultCopiesTo() *XCN {
+func (x *OBR) GetResultCopiesTo() []*XCN {
 	if x != nil {
 		return x.ResultCopiesTo
 	}
@@ -498,7 +498,7 @@ func (x *OBR) GetTransportationMode() string {
 	return ""
 }
 
-func (x *OBR) GetReasonForStudy() *CE {
+func (x *OBR) GetReasonForStudy() []*CE {
 	if x != nil {
 		return x.ReasonForStudy
 	}
@@ -512,21 +512,21 @@ func (x *OBR) GetPrincipalResultInterpreter() *CMOBS {
 	return nil
 }
 
-func (x *OBR) GetAssistantResultInterpreter() *CMOBS {
+func (x *OBR) GetAssistantResultInterpreter() []*CMOBS {
 	if x != nil {
 		return x.AssistantResultInterpreter
 	}
 	return nil
 }
 
-func (x *OBR) GetTechnician() *CMOBS {
+func (x *OBR) GetTechnician() []*CMOBS {
 	if x != nil {
 		return x.Technician
 	}
 	return nil
 }
 
-func (x *OBR) GetTranscriptionist() *CMOBS {
+func (x *OBR) GetTranscriptionist() []*CMOBS {
 	if x != nil {
 		return x.Transcriptionist
 	}
@@ -554,7 +554,7 @@ func (x *OBR) GetSampleTransportLogistics() *CE {
 	return nil
 }
 
-func (x *OBR) GetCollectorComment() *CE {
+func (x *OBR) GetCollectorComment() []*CE {
 	if x != nil {
 		return x.CollectorComment
 	}
@@ -582,7 +582,7 @@ func (x *OBR) GetEscortRequired() string {
 	return ""
 }
 
-func (x *OBR) GetPlannedPatientTransportComment() *CE {
+func (x *OBR) GetPlannedPatientTransportComment() []*CE {
 	if x != nil {
 		return x.PlannedPatientTransportComment
 	}
@@ -611,7 +611,7 @@ const file_standards_v23_order_proto_rawDesc = "" +
 	"verifiedBy\x12?\n" +
 	"\x11ordering_provider\x18\f \x01(\v2\x12.standards.v23.XCNR\x10orderingProvider\x128\n" +
 	"\x0eentry_location\x18\r \x01(\v2\x11.standards.v23.PLR\rentryLocation\x12F\n" +
-	"\x15callback_phone_number\x18\x0e \x01(\v2\x12.standards.v23.XTNR\x13callbackPhoneNumber\x12.\n" +
+	"\x15callback_phone_number\x18\x0e \x03(\v2\x12.standards.v23.XTNR\x13callbackPhoneNumber\x12.\n" +
 	"\x13effective_date_time\x18\x0f \x01(\tR\x11effectiveDateTime\x12L\n" +
 	"\x19order_control_code_reason\x18\x10 \x01(\v2\x11.standards.v23.CER\x16orderControlCodeReason\x12F\n" +
 	"\x15entering_organization\x18\x11 \x01(\v2\x11.standards.v23.CER\x14enteringOrganization\x12:\n" +
@@ -628,15 +628,15 @@ const file_standards_v23_order_proto_rawDesc = "" +
 	"\x19observation_end_date_time\x18\b \x01(\tR\x16observationEndDateTime\x12>\n" +
 	"\x11collection_volume\x18\t \x01(\v2\x11.standards.v23.CQR\x10collectionVolume\x12E\n" +
 	"\x14collector_identifier\x18\n" +
-	" \x01(\v2\x12.standards.v23.XCNR\x13collectorIdentifier\x120\n" +
+	" \x03(\v2\x12.standards.v23.XCNR\x13collectorIdentifier\x120\n" +
 	"\x14specimen_action_code\x18\v \x01(\tR\x12specimenActionCode\x122\n" +
 	"\vdanger_code\x18\f \x01(\v2\x11.standards.v23.CER\n" +
 	"dangerCode\x124\n" +
 	"\x16relevant_clinical_info\x18\r \x01(\tR\x14relevantClinicalInfo\x12=\n" +
 	"\x1bspecimen_received_date_time\x18\x0e \x01(\tR\x18specimenReceivedDateTime\x12=\n" +
 	"\x0fspecimen_source\x18\x0f \x01(\v2\x14.standards.v23.CMSPER\x0especimenSource\x12?\n" +
-	"\x11ordering_provider\x18\x10 \x01(\v2\x12.standards.v23.XCNR\x10orderingProvider\x12Q\n" +
-	"\x1border_callback_phone_number\x18\x11 \x01(\v2\x12.standards.v23.XTNR\x18orderCallbackPhoneNumber\x12$\n" +
+	"\x11ordering_provider\x18\x10 \x03(\v2\x12.standards.v23.XCNR\x10orderingProvider\x12Q\n" +
+	"\x1border_callback_phone_number\x18\x11 \x03(\v2\x12.standards.v23.XTNR\x18orderCallbackPhoneNumber\x12$\n" +
 	"\x0eplacer_field_1\x18\x12 \x01(\tR\fplacerField1\x12$\n" +
 	"\x0eplacer_field_2\x18\x13 \x01(\tR\fplacerField2\x12$\n" +
 	"\x0efiller_field_1\x18\x14 \x01(\tR\ffillerField1\x12$\n" +
@@ -647,24 +647,24 @@ const file_standards_v23_order_proto_rawDesc = "" +
 	"\rresult_status\x18\x19 \x01(\tR\fresultStatus\x129\n" +
 	"\rparent_result\x18\x1a \x01(\v2\x14.standards.v23.CMPRER\fparentResult\x12'\n" +
 	"\x0fquantity_timing\x18\x1b \x01(\tR\x0equantityTiming\x12<\n" +
-	"\x10result_copies_to\x18\x1c \x01(\v2\x12.standards.v23.XCNR\x0eresultCopiesTo\x12,\n" +
+	"\x10result_copies_to\x18\x1c \x03(\v2\x12.standards.v23.XCNR\x0eresultCopiesTo\x12,\n" +
 	"\x06parent\x18\x1d \x01(\v2\x14.standards.v23.CMPORR\x06parent\x12/\n" +
 	"\x13transportation_mode\x18\x1e \x01(\tR\x12transportationMode\x12;\n" +
-	"\x10reason_for_study\x18\x1f \x01(\v2\x11.standards.v23.CER\x0ereasonForStudy\x12V\n" +
+	"\x10reason_for_study\x18\x1f \x03(\v2\x11.standards.v23.CER\x0ereasonForStudy\x12V\n" +
 	"\x1cprincipal_result_interpreter\x18  \x01(\v2\x14.standards.v23.CMOBSR\x1aprincipalResultInterpreter\x12V\n" +
-	"\x1cassistant_result_interpreter\x18! \x01(\v2\x14.standards.v23.CMOBSR\x1aassistantResultInterpreter\x124\n" +
+	"\x1cassistant_result_interpreter\x18! \x03(\v2\x14.standards.v23.CMOBSR\x1aassistantResultInterpreter\x124\n" +
 	"\n" +
-	"technician\x18\" \x01(\v2\x14.standards.v23.CMOBSR\n" +
+	"technician\x18\" \x03(\v2\x14.standards.v23.CMOBSR\n" +
 	"technician\x12@\n" +
-	"\x10transcriptionist\x18# \x01(\v2\x14.standards.v23.CMOBSR\x10transcriptionist\x12.\n" +
+	"\x10transcriptionist\x18# \x03(\v2\x14.standards.v23.CMOBSR\x10transcriptionist\x12.\n" +
 	"\x13scheduled_date_time\x18$ \x01(\tR\x11scheduledDateTime\x126\n" +
 	"\x17sample_containers_count\x18% \x01(\tR\x15sampleContainersCount\x12O\n" +
 	"\x1asample_transport_logistics\x18& \x01(\v2\x11.standards.v23.CER\x18sampleTransportLogistics\x12>\n" +
-	"\x11collector_comment\x18' \x01(\v2\x11.standards.v23.CER\x10collectorComment\x12c\n" +
+	"\x11collector_comment\x18' \x03(\v2\x11.standards.v23.CER\x10collectorComment\x12c\n" +
 	"$transport_arrangement_responsibility\x18( \x01(\v2\x11.standards.v23.CER\"transportArrangementResponsibility\x12-\n" +
 	"\x12transport_arranged\x18) \x01(\tR\x11transportArranged\x12'\n" +
 	"\x0fescort_required\x18* \x01(\tR\x0eescortRequired\x12\\\n" +
-	"!planned_patient_transport_comment\x18+ \x01(\v2\x11.standards.v23.CER\x1eplannedPatientTransportCommentB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
+	"!planned_patient_transport_comment\x18+ \x03(\v2\x11.standards.v23.CER\x1eplannedPatientTransportCommentB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
 
 var (
 	file_standards_v23_order_proto_rawDescOnce sync.Once
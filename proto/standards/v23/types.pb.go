@@ -2265,6 +2265,74 @@ func (x *CMOBS) GetFloor() string {
 	return ""
 }
 
+type CMELD struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	SegmentId            string                 `protobuf:"bytes,1,opt,name=segment_id,json=segmentId,proto3" json:"segment_id,omitempty"`
+	Sequence             string                 `protobuf:"bytes,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	FieldPosition        string                 `protobuf:"bytes,3,opt,name=field_position,json=fieldPosition,proto3" json:"field_position,omitempty"`
+	CodeIdentifyingError *CE                    `protobuf:"bytes,4,opt,name=code_identifying_error,json=codeIdentifyingError,proto3" json:"code_identifying_error,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CMELD) Reset() {
+	*x = CMELD{}
+	mi := &file_standards_v23_types_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CMELD) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CMELD) ProtoMessage() {}
+
+func (x *CMELD) ProtoReflect() protoreflect.Message {
+	mi := &file_standards_v23_types_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CMELD.ProtoReflect.Descriptor instead.
+func (*CMELD) Descriptor() ([]byte, []int) {
+	return file_standards_v23_types_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CMELD) GetSegmentId() string {
+	if x != nil {
+		return x.SegmentId
+	}
+	return ""
+}
+
+func (x *CMELD) GetSequence() string {
+	if x != nil {
+		return x.Sequence
+	}
+	return ""
+}
+
+func (x *CMELD) GetFieldPosition() string {
+	if x != nil {
+		return x.FieldPosition
+	}
+	return ""
+}
+
+func (x *CMELD) GetCodeIdentifyingError() *CE {
+	if x != nil {
+		return x.CodeIdentifyingError
+	}
+	return nil
+}
+
 var File_standards_v23_types_proto protoreflect.FileDescriptor
 
 const file_standards_v23_types_proto_rawDesc = "" +
@@ -2471,7 +2539,13 @@ const file_standards_v23_types_proto_rawDesc = "" +
 	"\x15patient_location_type\x18\t \x01(\tR\x13patientLocationType\x12\x1a\n" +
 	"\bbuilding\x18\n" +
 	" \x01(\tR\bbuilding\x12\x14\n" +
-	"\x05floor\x18\v \x01(\tR\x05floorB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
+	"\x05floor\x18\v \x01(\tR\x05floor\"\xb2\x01\n" +
+	"\x05CMELD\x12\x1d\n" +
+	"\n" +
+	"segment_id\x18\x01 \x01(\tR\tsegmentId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\tR\bsequence\x12%\n" +
+	"\x0efield_position\x18\x03 \x01(\tR\rfieldPosition\x12G\n" +
+	"\x16code_identifying_error\x18\x04 \x01(\v2\x11.standards.v23.CER\x14codeIdentifyingErrorB1Z/github.com/s-hammon/hl7/proto/standards/v23;v23b\x06proto3"
 
 var (
 	file_standards_v23_types_proto_rawDescOnce sync.Once
@@ -2485,7 +2559,7 @@ func file_standards_v23_types_proto_rawDescGZIP() []byte {
 	return file_standards_v23_types_proto_rawDescData
 }
 
-var file_standards_v23_types_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_standards_v23_types_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_standards_v23_types_proto_goTypes = []any{
 	(*CMMSG)(nil), // 0: standards.v23.CMMSG
 	(*XCN)(nil),   // 1: standards.v23.XCN
@@ -2516,6 +2590,7 @@ var file_standards_v23_types_proto_goTypes = []any{
 	(*HD)(nil),    // 26: standards.v23.HD
 	(*CN)(nil),    // 27: standards.v23.CN
 	(*CMOBS)(nil), // 28: standards.v23.CMOBS
+	(*CMELD)(nil), // 29: standards.v23.CMELD
 }
 var file_standards_v23_types_proto_depIdxs = []int32{
 	6,  // 0: standards.v23.CP.range_units:type_name -> standards.v23.CE
@@ -2531,11 +2606,12 @@ var file_standards_v23_types_proto_depIdxs = []int32{
 	26, // 10: standards.v23.CN.assigning_authority:type_name -> standards.v23.HD
 	27, // 11: standards.v23.CMOBS.name:type_name -> standards.v23.CN
 	26, // 12: standards.v23.CMOBS.facility:type_name -> standards.v23.HD
-	13, // [13:13] is the sub-list for method output_type
-	13, // [13:13] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	6,  // 13: standards.v23.CMELD.code_identifying_error:type_name -> standards.v23.CE
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_standards_v23_types_proto_init() }
@@ -2549,7 +2625,7 @@ func file_standards_v23_types_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_standards_v23_types_proto_rawDesc), len(file_standards_v23_types_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,153 @@
+package hl7
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/s-hammon/hl7/standards"
+)
+
+// segmentTypes maps segment IDs to the struct types registered for them.
+type segmentTypes map[string]reflect.Type
+
+var segmentRegistry struct {
+	sync.RWMutex
+	types segmentTypes
+}
+
+// RegisterSegment records the struct type of v, which may be a struct or
+// a pointer to one, as the type of segments called name, typically a
+// site-specific Z-segment. Unmarshal then decodes such segments into that
+// type wherever the destination does not say otherwise: in map output, and
+// in interface-typed fields of message and group structs, such as
+//
+//	ZPV any   `hl7:"ZPV"`
+//	ZOR []any `hl7:"ZOR"`
+//
+// The struct's fields follow the same positional rules as any other
+// segment struct. RegisterSegment is meant to be called from init
+// functions; it panics if name is not a valid segment ID, if v is not a
+// struct, or if name is already registered with a different type.
+func RegisterSegment(name string, v any) {
+	t := checkSegmentType(name, v)
+
+	segmentRegistry.Lock()
+	defer segmentRegistry.Unlock()
+
+	if prev, ok := segmentRegistry.types[name]; ok && prev != t {
+		panic(fmt.Sprintf("hl7: RegisterSegment: %s already registered as %v", name, prev))
+	}
+	if segmentRegistry.types == nil {
+		segmentRegistry.types = make(segmentTypes)
+	}
+	segmentRegistry.types[name] = t
+}
+
+// RegisterSegment records the struct type of v as the type of segments
+// called name for messages read by dec, taking precedence over types
+// registered with the package-level RegisterSegment. It panics if name is
+// not a valid segment ID or if v is not a struct.
+func (dec *Decoder) RegisterSegment(name string, v any) {
+	t := checkSegmentType(name, v)
+	if dec.d.segTypes == nil {
+		dec.d.segTypes = make(segmentTypes)
+	}
+	dec.d.segTypes[name] = t
+}
+
+func checkSegmentType(name string, v any) reflect.Type {
+	if !isSegmentName(name) {
+		panic(fmt.Sprintf("hl7: RegisterSegment: invalid segment ID %q", name))
+	}
+
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("hl7: RegisterSegment: %s: %T is not a struct", name, v))
+	}
+
+	return t
+}
+
+// segmentType returns the struct type registered for segments called
+// name, looking at the decoder's own registrations before the global ones.
+func (d *decodeState) segmentType(name string) (reflect.Type, bool) {
+	if t, ok := d.segTypes[name]; ok {
+		return t, true
+	}
+
+	segmentRegistry.RLock()
+	defer segmentRegistry.RUnlock()
+	t, ok := segmentRegistry.types[name]
+	return t, ok
+}
+
+// RegisterMessage records the struct type of v, which may be a struct or a
+// pointer to one, as the type Parse decodes messages of the given MSH-12
+// version and MSH-9 message type into. The message type is written as in
+// MSH-9, as "ORU^R01" or, to tell message structures apart,
+// "ADT^A04^ADT_A01". The packages under standards register their own
+// message types when imported. RegisterMessage panics if v is not a struct
+// or if the pair is already registered with a different type.
+func RegisterMessage(version, messageType string, v any) {
+	standards.Register(version, messageType, v)
+}
+
+// Parse decodes data into a new value of the type registered for its
+// version and message type and returns a pointer to it, so that callers
+// need not know in advance what kind of message they hold:
+//
+//	v, err := hl7.Parse(data)
+//	switch m := v.(type) {
+//	case *v23.ORU_R01:
+//		...
+//	case *hl7.Message:
+//		// no type registered
+//	}
+//
+// Messages of types that have none registered are returned as a *Message.
+func Parse(data []byte) (any, error) {
+	return UnmarshalOptions{}.Parse(data)
+}
+
+// Parse is like the package-level Parse but decodes with the options o.
+func (o UnmarshalOptions) Parse(data []byte) (any, error) {
+	if t, ok := messageType(data); ok {
+		v := reflect.New(t).Interface()
+		if err := o.Unmarshal(data, v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+
+	m := new(Message)
+	if err := o.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// messageType returns the type registered for the message in data, judging
+// by its header alone.
+func messageType(data []byte) (reflect.Type, bool) {
+	if isUTF16(data) {
+		text, err := utf16Charset{}.Decode(data)
+		if err != nil {
+			return nil, false
+		}
+		data = text
+	}
+
+	version, _, _ := strings.Cut(headerField(data, 12), "^")
+	typ := strings.TrimRight(headerField(data, 9), "^")
+	if version == "" || typ == "" {
+		return nil, false
+	}
+
+	return standards.Lookup(strings.TrimSpace(version), typ)
+}
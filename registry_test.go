@@ -0,0 +1,174 @@
+package hl7
+
+import (
+	"strings"
+	"testing"
+
+	v23 "github.com/s-hammon/hl7/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+type zrx struct {
+	SetId    int
+	Drug     zrxDrug
+	Quantity float64
+}
+
+type zrxDrug struct {
+	Code string
+	Name string
+}
+
+type zor struct {
+	SetId  int
+	Status string
+}
+
+type zorAlt struct {
+	Status string `hl7:"2"`
+}
+
+type registryMsg struct {
+	MSH v23.MSH
+	PID v23.PID
+	ZRX []any `hl7:"ZRX"`
+	ZOR any
+}
+
+func init() {
+	RegisterSegment("ZRX", zrx{})
+	RegisterSegment("ZOR", &zor{})
+}
+
+const registryInput = "MSH|^~\\&|App|Fac|||||RDE^O01|1|P|2.3\r" +
+	"PID|1||V1\r" +
+	"ZRX|1|AMOX^Amoxicillin|2.5\r" +
+	"ZRX|2|IBU^Ibuprofen|1\r" +
+	"ZOR|1|ACTIVE\r"
+
+func TestRegisterSegment_Struct(t *testing.T) {
+	var m registryMsg
+	require.NoError(t, Unmarshal([]byte(registryInput), &m))
+	require.Equal(t, []any{
+		zrx{SetId: 1, Drug: zrxDrug{Code: "AMOX", Name: "Amoxicillin"}, Quantity: 2.5},
+		zrx{SetId: 2, Drug: zrxDrug{Code: "IBU", Name: "Ibuprofen"}, Quantity: 1},
+	}, m.ZRX)
+	require.Equal(t, zor{SetId: 1, Status: "ACTIVE"}, m.ZOR)
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, registryInput, string(b))
+}
+
+func TestMarshal_UnregisteredSegment(t *testing.T) {
+	in := "MSH|^~\\&|App|Fac|||||ADT^A01|1|P|2.3\r" +
+		"PID|1||V1\r" +
+		"ZPV|1|WARD^3&B|\"\"|x~y\r"
+
+	var m struct {
+		MSH v23.MSH
+		PID v23.PID
+		ZPV any
+	}
+	require.NoError(t, Unmarshal([]byte(in), &m))
+	require.IsType(t, map[int]any{}, m.ZPV)
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, in, string(b))
+
+	m.ZPV = "ZPV|1"
+	_, err = Marshal(&m)
+	var unsupported *UnsupportedTypeError
+	require.ErrorAs(t, err, &unsupported)
+}
+
+func TestRegisterSegment_Map(t *testing.T) {
+	var m map[string]any
+	require.NoError(t, Unmarshal([]byte(registryInput), &m))
+	require.Equal(t, []zrx{
+		{SetId: 1, Drug: zrxDrug{Code: "AMOX", Name: "Amoxicillin"}, Quantity: 2.5},
+		{SetId: 2, Drug: zrxDrug{Code: "IBU", Name: "Ibuprofen"}, Quantity: 1},
+	}, m["ZRX"])
+	require.Equal(t, zor{SetId: 1, Status: "ACTIVE"}, m["ZOR"])
+	require.Equal(t, "V1", m["PID"].(map[int]any)[3])
+}
+
+func TestDecoder_RegisterSegment(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(registryInput + "ZXX|1|x\r"))
+	dec.RegisterSegment("ZOR", zorAlt{})
+	dec.RegisterSegment("ZXX", zor{})
+
+	var m map[string]any
+	require.NoError(t, dec.Decode(&m))
+	require.Equal(t, zorAlt{Status: "ACTIVE"}, m["ZOR"])
+	require.Equal(t, zor{SetId: 1, Status: "x"}, m["ZXX"])
+	require.IsType(t, []zrx{}, m["ZRX"])
+
+	// registrations made on a decoder stay with it
+	require.NoError(t, Unmarshal([]byte(registryInput+"ZXX|1|x\r"), &m))
+	require.Equal(t, zor{SetId: 1, Status: "ACTIVE"}, m["ZOR"])
+	require.IsType(t, map[int]any{}, m["ZXX"])
+}
+
+func TestRegisterSegment_Panics(t *testing.T) {
+	require.Panics(t, func() { RegisterSegment("zrx", zrx{}) })
+	require.Panics(t, func() { RegisterSegment("ZRY", "not a struct") })
+	require.Panics(t, func() { RegisterSegment("ZRX", zor{}) })
+	require.NotPanics(t, func() { RegisterSegment("ZRX", &zrx{}) })
+}
+
+type adtA04 struct {
+	MSH v23.MSH
+	PID v23.PID
+}
+
+func TestParse(t *testing.T) {
+	RegisterMessage("2.3", "ADT^A04", adtA04{})
+
+	v, err := Parse(oruMultipleOrdersMsg)
+	require.NoError(t, err)
+	require.IsType(t, &v23.ORU_R01{}, v)
+	require.Equal(t, "BANANA", v.(*v23.ORU_R01).Results[0].PID.PatientName[0].FamilyName)
+
+	v, err = Parse([]byte("MSH|^~\\&|App|Fac|||||ADT^A04^ADT_A01|1|P|2.3\rPID|1||V1\r"))
+	require.NoError(t, err)
+	require.Equal(t, "V1", v.(*adtA04).PID.InternalPatientId[0].Id)
+
+	// other delimiters, and a version with components
+	v, err = Parse([]byte("MSH#*~\\&#App#Fac#####ADT*A04#1#P#2.3*USA\rPID#1##V1\r"))
+	require.NoError(t, err)
+	require.IsType(t, &adtA04{}, v)
+
+	// v23 registers ACK alone, for every trigger event
+	v, err = Parse([]byte("MSH|^~\\&|App|Fac|||||ACK^A04|1|P|2.3\rMSA|AA|1\r"))
+	require.NoError(t, err)
+	require.Equal(t, "AA", v.(*v23.ACK).MSA.AcknowledgementCode)
+
+	// unregistered versions and types fall back to a Message
+	for _, in := range []string{
+		"MSH|^~\\&|App|Fac|||||ADT^A04|1|P|2.5\rPID|1||V1\r",
+		"MSH|^~\\&|App|Fac|||||ADT^A08|1|P|2.3\rPID|1||V1\r",
+		"MSH|^~\\&|App|Fac\rPID|1||V1\r",
+	} {
+		v, err = Parse([]byte(in))
+		require.NoError(t, err, in)
+		require.IsType(t, &Message{}, v, in)
+		require.Equal(t, in, string(v.(*Message).Bytes()))
+	}
+
+	_, err = Parse([]byte("PID|1||V1\r"))
+	var syntaxErr *SyntaxError
+	require.ErrorAs(t, err, &syntaxErr)
+
+	_, err = UnmarshalOptions{Strict: true}.Parse([]byte("MSH|^~\\&|App|Fac|||||ADT^A04|1|P|2.3\rPID|1||V1\rZZZ|1\r"))
+	var unexpected *UnexpectedSegmentError
+	require.ErrorAs(t, err, &unexpected)
+}
+
+func TestRegisterMessage_Panics(t *testing.T) {
+	require.Panics(t, func() { RegisterMessage("2.3", "ORU^R01", zor{}) })
+	require.Panics(t, func() { RegisterMessage("2.3", "ZZZ^Z01", "not a struct") })
+	require.Panics(t, func() { RegisterMessage("", "ZZZ^Z01", zor{}) })
+	require.NotPanics(t, func() { RegisterMessage("2.3", "ORU^R01", &v23.ORU_R01{}) })
+}
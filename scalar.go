@@ -0,0 +1,210 @@
+package hl7
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// isScalar reports whether values of type t are stored from a single HL7
+// primitive rather than from components.
+func isScalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+
+	return t == timeType
+}
+
+// setScalar parses s into dst, whose type must satisfy isScalar.
+func setScalar(dst reflect.Value, s string, loc *time.Location) error {
+	if dst.Type() == timeType {
+		t, err := parseTS(s, loc)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Bool:
+		b, err := parseBool(s)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	}
+
+	return nil
+}
+
+// appendScalar appends the HL7 text of v, whose type must satisfy
+// isScalar, to b. Zero values are written as nothing at all, since an
+// empty field is how HL7 says a value is absent, unless explicit is set.
+func appendScalar(b []byte, v reflect.Value, opts tagOptions, explicit bool) []byte {
+	if !explicit && v.IsZero() {
+		return b
+	}
+
+	if v.Type() == timeType {
+		return appendTS(b, v.Interface().(time.Time), opts.Date())
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return append(b, v.String()...)
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 'Y')
+		}
+		return append(b, 'N')
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(b, v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.AppendUint(b, v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(b, v.Float(), 'f', -1, v.Type().Bits())
+	}
+
+	return b
+}
+
+// parseBool accepts the HL7 yes/no indicator (table 0136) as well as the
+// forms understood by strconv.ParseBool.
+func parseBool(s string) (bool, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "Y", "YES":
+		return true, nil
+	case "N", "NO":
+		return false, nil
+	}
+
+	return strconv.ParseBool(strings.TrimSpace(s))
+}
+
+var errInvalidTS = errors.New("invalid HL7 timestamp")
+
+// parseTS parses an HL7 TS, DTM or DT value:
+//
+//	YYYY[MM[DD[HH[MM[SS[.S[S[S[S]]]]]]]]][+/-ZZZZ]
+//
+// Values without a UTC offset are interpreted in loc.
+func parseTS(s string, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	raw := s
+	bad := func() (time.Time, error) {
+		return time.Time{}, fmt.Errorf("%w %q", errInvalidTS, raw)
+	}
+
+	if i := strings.IndexAny(s, "+-"); i >= 0 {
+		zone := s[i:]
+		s = s[:i]
+		if len(zone) != 5 || !isDigits(zone[1:]) {
+			return bad()
+		}
+
+		hh, _ := strconv.Atoi(zone[1:3])
+		mm, _ := strconv.Atoi(zone[3:5])
+		offset := hh*3600 + mm*60
+		if zone[0] == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+
+	var nsec int
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		frac := s[i+1:]
+		s = s[:i]
+		if len(s) != 14 || frac == "" || len(frac) > 9 || !isDigits(frac) {
+			return bad()
+		}
+
+		nsec, _ = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+	}
+
+	switch len(s) {
+	default:
+		return bad()
+	case 4, 6, 8, 10, 12, 14:
+	}
+	if !isDigits(s) {
+		return bad()
+	}
+
+	// year, month, day, hour, minute, second
+	parts := [6]int{0, 1, 1, 0, 0, 0}
+	parts[0], _ = strconv.Atoi(s[:4])
+	for i := 1; 4+2*i <= len(s); i++ {
+		parts[i], _ = strconv.Atoi(s[2+2*i : 4+2*i])
+	}
+
+	t := time.Date(parts[0], time.Month(parts[1]), parts[2], parts[3], parts[4], parts[5], nsec, loc)
+	if t.Month() != time.Month(parts[1]) || t.Day() != parts[2] ||
+		t.Hour() != parts[3] || t.Minute() != parts[4] || t.Second() != parts[5] {
+		return bad()
+	}
+
+	return t, nil
+}
+
+// appendTS appends t in HL7 TS form, to the second, with fractional
+// seconds (to HL7's maximum of four digits) when present and a UTC offset
+// unless t is in UTC. dateOnly writes the DT form, YYYYMMDD.
+func appendTS(b []byte, t time.Time, dateOnly bool) []byte {
+	if dateOnly {
+		return t.AppendFormat(b, "20060102")
+	}
+
+	b = t.AppendFormat(b, "20060102150405")
+	if ns := t.Nanosecond(); ns >= 100000 {
+		frac := strconv.Itoa(1e9 + ns)[1:5]
+		b = append(b, '.')
+		b = append(b, strings.TrimRight(frac, "0")...)
+	}
+	if t.Location() != time.UTC {
+		b = t.AppendFormat(b, "-0700")
+	}
+
+	return b
+}
+
+func isDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return s != ""
+}
@@ -0,0 +1,172 @@
+package hl7
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	v23 "github.com/s-hammon/hl7/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+type zsc1 struct {
+	SetId    int
+	Count    uint16
+	Dose     float64
+	Active   bool
+	Observed time.Time
+	Birth    time.Time `hl7:",date"`
+	Resulted *time.Time
+	Refills  *int
+	Range    zrange
+}
+
+type zrange struct {
+	Low  float32
+	High float32
+}
+
+type scalarMsg struct {
+	MSH v23.MSH
+	ZSC []zsc1
+}
+
+func TestParseTS(t *testing.T) {
+	est := time.FixedZone("", -5*3600)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"2025", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"202504", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"20250404", time.Date(2025, 4, 4, 0, 0, 0, 0, time.UTC)},
+		{"2025040415", time.Date(2025, 4, 4, 15, 0, 0, 0, time.UTC)},
+		{"202504041525", time.Date(2025, 4, 4, 15, 25, 0, 0, time.UTC)},
+		{"20250404152530", time.Date(2025, 4, 4, 15, 25, 30, 0, time.UTC)},
+		{"20250404152530.12", time.Date(2025, 4, 4, 15, 25, 30, 120000000, time.UTC)},
+		{"20250404152530-0500", time.Date(2025, 4, 4, 15, 25, 30, 0, est)},
+		{"202504041525+0000", time.Date(2025, 4, 4, 15, 25, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseTS(tt.in, time.UTC)
+		require.NoError(t, err, tt.in)
+		require.True(t, tt.want.Equal(got), "%s: got %v, want %v", tt.in, got, tt.want)
+	}
+
+	for _, in := range []string{"", "25", "20250", "20251301", "20250230", "2025040415253", "20250404.5", "20250404-05", "2025O404"} {
+		_, err := parseTS(in, time.UTC)
+		require.ErrorIs(t, err, errInvalidTS, in)
+	}
+}
+
+func TestAppendTS(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		dateOnly bool
+		want     string
+	}{
+		{time.Date(2025, 4, 4, 15, 25, 30, 0, time.UTC), false, "20250404152530"},
+		{time.Date(2025, 4, 4, 15, 25, 30, 0, time.UTC), true, "20250404"},
+		{time.Date(2025, 4, 4, 15, 25, 30, 120000000, time.UTC), false, "20250404152530.12"},
+		{time.Date(2025, 4, 4, 15, 25, 30, 0, time.FixedZone("", -5*3600)), false, "20250404152530-0500"},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, string(appendTS(nil, tt.t, tt.dateOnly)))
+	}
+}
+
+func TestUnmarshal_Scalars(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App\r" +
+		"ZSC|1|12|2.5|Y|20250404152530-0500|19800102|20250405|0|0.5^10\r" +
+		"ZSC|2||||||||\r")
+
+	var m scalarMsg
+	require.NoError(t, Unmarshal(msg, &m))
+	require.Len(t, m.ZSC, 2)
+
+	z := m.ZSC[0]
+	require.Equal(t, 1, z.SetId)
+	require.Equal(t, uint16(12), z.Count)
+	require.Equal(t, 2.5, z.Dose)
+	require.True(t, z.Active)
+	require.True(t, time.Date(2025, 4, 4, 20, 25, 30, 0, time.UTC).Equal(z.Observed))
+	require.Equal(t, time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC), z.Birth)
+	require.NotNil(t, z.Resulted)
+	require.Equal(t, time.Date(2025, 4, 5, 0, 0, 0, 0, time.UTC), *z.Resulted)
+	require.NotNil(t, z.Refills)
+	require.Equal(t, 0, *z.Refills)
+	require.Equal(t, zrange{Low: 0.5, High: 10}, z.Range)
+
+	// absent values leave pointers nil
+	require.Equal(t, zsc1{SetId: 2}, m.ZSC[1])
+
+	loc := time.FixedZone("", 2*3600)
+	m = scalarMsg{}
+	require.NoError(t, UnmarshalOptions{TimeZone: loc}.Unmarshal(msg, &m))
+	require.Equal(t, time.Date(1980, 1, 2, 0, 0, 0, 0, loc), m.ZSC[0].Birth)
+}
+
+func TestUnmarshal_ScalarError(t *testing.T) {
+	msg := []byte("MSH|^~\\&|App\r" +
+		"ZSC|1|-3|x|MAYBE|2025-04-04\r")
+
+	var m scalarMsg
+	err := UnmarshalOptions{AllErrors: true}.Unmarshal(msg, &m)
+
+	var list ErrorList
+	require.ErrorAs(t, err, &list)
+	require.Len(t, list, 4)
+
+	var typeErr *UnmarshalTypeError
+	require.ErrorAs(t, list[0], &typeErr)
+	require.Equal(t, "ZSC-2", typeErr.Location.String())
+	require.Equal(t, "uint16", typeErr.Type.String())
+	var numErr *strconv.NumError
+	require.True(t, errors.As(typeErr, &numErr))
+
+	require.ErrorAs(t, list[3], &typeErr)
+	require.Equal(t, "ZSC-5", typeErr.Location.String())
+	require.ErrorIs(t, typeErr, errInvalidTS)
+
+	// the fields that did parse are still set
+	require.Equal(t, 1, m.ZSC[0].SetId)
+}
+
+func TestMarshal_Scalars(t *testing.T) {
+	resulted := time.Date(2025, 4, 5, 8, 0, 0, 0, time.UTC)
+	refills := 0
+
+	m := scalarMsg{
+		MSH: v23.MSH{SendingApplication: "App"},
+		ZSC: []zsc1{
+			{
+				SetId:    1,
+				Count:    12,
+				Dose:     2.5,
+				Active:   true,
+				Observed: time.Date(2025, 4, 4, 15, 25, 30, 0, time.FixedZone("", -5*3600)),
+				Birth:    time.Date(1980, 1, 2, 0, 0, 0, 0, time.UTC),
+				Resulted: &resulted,
+				Refills:  &refills,
+				Range:    zrange{Low: 0.5, High: 10},
+			},
+			{SetId: 2},
+		},
+	}
+
+	b, err := Marshal(&m)
+	require.NoError(t, err)
+	require.Equal(t, "MSH|^~\\&|App\r"+
+		"ZSC|1|12|2.5|Y|20250404152530-0500|19800102|20250405080000|0|0.5^10\r"+
+		"ZSC|2\r", string(b))
+
+	var got scalarMsg
+	require.NoError(t, Unmarshal(b, &got))
+	require.Equal(t, m.ZSC[0].SetId, got.ZSC[0].SetId)
+	require.True(t, m.ZSC[0].Observed.Equal(got.ZSC[0].Observed))
+	require.Equal(t, m.ZSC[0].Range, got.ZSC[0].Range)
+}
@@ -1,7 +1,5 @@
 package hl7
 
-import "sync"
-
 type scanner struct {
 	err error
 
@@ -10,28 +8,51 @@ type scanner struct {
 	repDelim byte
 	escDelim byte
 	subDelim byte
+
+	// states maps each byte to the state it starts; see setStates.
+	states [256]uint8
 }
 
-var scannerPool = sync.Pool{
-	New: func() any {
-		return &scanner{}
-	},
+// Delimiters are the separator and escape characters a message declares in
+// MSH-1 and MSH-2.
+type Delimiters struct {
+	Field        byte
+	Component    byte
+	Repetition   byte
+	Escape       byte
+	SubComponent byte
 }
 
-func newScanner() *scanner {
-	s := scannerPool.Get().(*scanner)
-	s.err = nil
-	return s
+func (s *scanner) delimiters() Delimiters {
+	return Delimiters{
+		Field:        s.fldDelim,
+		Component:    s.comDelim,
+		Repetition:   s.repDelim,
+		Escape:       s.escDelim,
+		SubComponent: s.subDelim,
+	}
 }
 
 func (s *scanner) state(c byte) int {
-	switch c {
-	default:
-		return stateValue
-	case s.fldDelim:
-		return stateFieldIdx
-	// TODO: add cases for other delimiters
-	case '\r':
-		return stateEndSegment
+	return int(s.states[c])
+}
+
+// setStates fills s.states from the delimiters, so that state is a single
+// lookup for each byte of the message.
+func (s *scanner) setStates() {
+	for c := range s.states {
+		s.states[c] = uint8(stateValue)
 	}
+	s.states[s.subDelim] = uint8(stateSubComponent)
+	s.states[s.comDelim] = uint8(stateComponent)
+	s.states[s.repDelim] = uint8(stateRepetition)
+	s.states[s.fldDelim] = uint8(stateFieldIdx)
+	s.states['\r'] = uint8(stateEndSegment)
+}
+
+// A mark is a repetition, component or subcomponent delimiter found while
+// scanning a field: its byte offset in the message and its state.
+type mark struct {
+	off   int
+	state int
 }
@@ -0,0 +1,80 @@
+// Package standards holds the message types of the HL7 versions
+// implemented beneath it. Each version package registers its message
+// structs from an init function, so importing one, if only for its side
+// effects, is enough for hl7.Parse to decode that version's messages:
+//
+//	import _ "github.com/s-hammon/hl7/standards/v23"
+//
+// The registry lives here rather than in package hl7 so that the version
+// packages need not depend on the decoder.
+package standards
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type key struct {
+	version     string
+	messageType string
+}
+
+var registry struct {
+	sync.RWMutex
+	types map[key]reflect.Type
+}
+
+// Register records the struct type of v, which may be a struct or a
+// pointer to one, as the type of messages with the given MSH-12 version
+// and MSH-9 message type, written the way it appears in MSH-9: "ORU^R01",
+// or "ADT^A04^ADT_A01" to name the message structure as well. It panics if
+// v is not a struct or if the pair is already registered with a different
+// type.
+func Register(version, messageType string, v any) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("standards: Register: %s %s: %T is not a struct", version, messageType, v))
+	}
+	if version == "" || messageType == "" {
+		panic(fmt.Sprintf("standards: Register: %v: empty version or message type", t))
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	k := key{version, messageType}
+	if prev, ok := registry.types[k]; ok && prev != t {
+		panic(fmt.Sprintf("standards: Register: %s %s already registered as %v", version, messageType, prev))
+	}
+	if registry.types == nil {
+		registry.types = make(map[key]reflect.Type)
+	}
+	registry.types[k] = t
+}
+
+// Lookup returns the struct type registered for the version and message
+// type. A message type with a structure or trigger event that has no
+// registration of its own falls back to the shorter forms, so
+// "ADT^A04^ADT_A01" finds a type registered as "ADT^A04", and "ACK^A01"
+// one registered as "ACK".
+func Lookup(version, messageType string) (reflect.Type, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	for {
+		if t, ok := registry.types[key{version, messageType}]; ok {
+			return t, true
+		}
+
+		i := strings.LastIndexByte(messageType, '^')
+		if i < 0 {
+			return nil, false
+		}
+		messageType = messageType[:i]
+	}
+}
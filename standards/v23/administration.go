@@ -12,18 +12,18 @@ type EVN struct {
 type PID struct {
 	SetId                  string
 	ExternalPatientId      CX
-	InternalPatientId      CX
-	AlternatePatientId     CX
-	PatientName            XPN
+	InternalPatientId      []CX
+	AlternatePatientId     []CX
+	PatientName            []XPN
 	MotherMaidenName       XPN
 	DOB                    string
 	Sex                    string
-	PatientAlias           XPN
+	PatientAlias           []XPN
 	Race                   string
-	PatientAddress         XAD
+	PatientAddress         []XAD
 	CountyCode             string
-	HomePhoneNumber        XTN
-	WorkPhoneNumber        XTN
+	HomePhoneNumber        []XTN
+	WorkPhoneNumber        []XTN
 	PrimaryLanguage        CE
 	MaritalStatus          string
 	Religion               string
@@ -35,7 +35,7 @@ type PID struct {
 	BirthPlace             string
 	MultipleBirthIndicator string
 	BirthOrder             string
-	Citizenship            string
+	Citizenship            []string
 	VeteranStatus          CE
 	Nationality            CE
 	PatientDeathDateTime   string
@@ -49,9 +49,9 @@ type PV1 struct {
 	AdmissionType           string
 	PreadmitNumber          CX
 	PriorPatientLocation    PL
-	AttendingDoctor         XCN
-	ReferringDoctor         XCN
-	ConsultingDoctor        XCN
+	AttendingDoctor         []XCN
+	ReferringDoctor         []XCN
+	ConsultingDoctor        []XCN
 	HospitalService         string
 	TemporaryLocation       PL
 	PreadmitTestIndicator   string
@@ -59,7 +59,7 @@ type PV1 struct {
 	AdmitSource             string
 	AmbulatoryStatus        string
 	VipIndicator            string
-	AdmittingDoctor         XCN
+	AdmittingDoctor         []XCN
 	PatientType             string
 	VisitNumber             CX
 	FinancialClass          FC
@@ -94,7 +94,7 @@ type PV1 struct {
 	TotalPayments           string
 	AlternateVisitId        CX
 	VisitIndicator          string
-	OtherHealthcareProvider XCN
+	OtherHealthcareProvider []XCN
 }
 
 type PV2 struct {
@@ -140,8 +140,8 @@ type PV2 struct {
 type PD1 struct {
 	LivingDependency       string
 	LivingArrangement      string
-	PatientPrimaryFacility XON
-	PatientPCPName         XCN
+	PatientPrimaryFacility []XON
+	PatientPCPName         []XCN
 	StudentIndicator       string
 	Handicap               string
 	LivingWill             string
@@ -157,6 +157,6 @@ type AL1 struct {
 	AllergyType        string
 	AllergyCode        CE
 	AllergySeverity    string
-	AllergyReaction    string
+	AllergyReaction    []string
 	IdentificationDate string
 }
@@ -1,32 +1,46 @@
 package v23
 
 type MSH struct {
-	FieldDelimiter            string
-	EncodingCharacters        string
-	SendingApplication        string
-	SendingFacility           string
-	ReceivingApplication      string
-	ReceivingFacility         string
-	DateTime                  string
-	Security                  string
-	MessageType               CM_MSG
-	ControlId                 string
-	ProcessingId              string
-	VersionId                 string
-	SequenceNumber            string
-	ContinuationPointer       string
-	AcceptAcknowledgementType string
-	CountryCode               string
-	CharacterSet              string
-	PrincipalLanguage         string
+	FieldDelimiter                 string
+	EncodingCharacters             string
+	SendingApplication             string
+	SendingFacility                string
+	ReceivingApplication           string
+	ReceivingFacility              string
+	DateTime                       string
+	Security                       string
+	MessageType                    CM_MSG
+	ControlId                      string
+	ProcessingId                   string
+	VersionId                      string
+	SequenceNumber                 string
+	ContinuationPointer            string
+	AcceptAcknowledgementType      string
+	ApplicationAcknowledgementType string
+	CountryCode                    string
+	CharacterSet                   string
+	PrincipalLanguage              string
 }
 
 type NTE struct {
 	SetId           string
 	SourceOfComment string
-	Comment         string
+	Comment         []string
 }
 
 type DSC struct {
 	ContinuationPointer string
 }
+
+type MSA struct {
+	AcknowledgementCode        string
+	ControlId                  string
+	TextMessage                string
+	ExpectedSequenceNumber     string
+	DelayedAcknowledgementType string
+	ErrorCondition             CE
+}
+
+type ERR struct {
+	ErrorCodeAndLocation []CM_ELD
+}
@@ -3,11 +3,11 @@ package v23
 type GT1 struct {
 	SetId                    string
 	GuarantorNumber          CX
-	Name                     XPN
-	SpouseName               XPN
-	Address                  XAD
-	HomePhoneNumber          XTN
-	WorkPhoneNumber          XTN
+	Name                     []XPN
+	SpouseName               []XPN
+	Address                  []XAD
+	HomePhoneNumber          []XTN
+	WorkPhoneNumber          []XTN
 	DOB                      string
 	Sex                      string
 	Type                     string
@@ -16,12 +16,12 @@ type GT1 struct {
 	BeginDate                string
 	EndDate                  string
 	Priority                 string
-	EmployerName             XPN
-	EmployerAddress          XAD
-	EmployerPhoneNumber      XTN
-	EmployeeIdNumber         CX
+	EmployerName             []XPN
+	EmployerAddress          []XAD
+	EmployerPhoneNumber      []XTN
+	EmployeeIdNumber         []CX
 	EmploymentStatus         string
-	OrganizationName         XON
+	OrganizationName         []XON
 	BillingHoldFlag          string
 	CreditRatingCode         CE
 	DeathDateTime            string
@@ -29,29 +29,29 @@ type GT1 struct {
 	ChargeAdjustmentCode     CE
 	HouseholdAnnualIncome    CP
 	HouseholdSize            string
-	EmployerIdNumber         CX
+	EmployerIdNumber         []CX
 	MaritalStatus            string
 	HireEffectiveDate        string
 	EmploymentStopDate       string
 	LivingDependency         string
 	AmbulatoryStatus         string
-	Citizenship              string
+	Citizenship              []string
 	PrimaryLanguage          CE
 	LivingArrangement        string
 	PublicityIndicator       CE
 	ProtectionIndicator      string
 	StudentIndicator         string
 	Religion                 string
-	MotherMaidenName         XPN
+	MotherMaidenName         []XPN
 	Nationality              CE
 	EthnicGroup              string
-	ContactName              XPN
-	ContactPhoneNumber       XTN
+	ContactName              []XPN
+	ContactPhoneNumber       []XTN
 	ContactReason            CE
 	ContactRelationship      string
 	JobTitle                 string
 	JobCode                  JCC
-	EmployerOrganizationName XON
+	EmployerOrganizationName []XON
 	Handicap                 string
 	JobStatus                string
 	FinancialClass           FC
@@ -61,23 +61,23 @@ type GT1 struct {
 type IN1 struct {
 	SetId                    string
 	PlanId                   CE
-	CompanyId                CX
-	CompanyName              XON
-	CompanyAddress           XAD
-	CompanyContact           XPN
-	CompanyPhoneNumber       XTN
+	CompanyId                []CX
+	CompanyName              []XON
+	CompanyAddress           []XAD
+	CompanyContact           []XPN
+	CompanyPhoneNumber       []XTN
 	GroupNumber              string
-	GroupName                XON
-	GroupEmployerId          CX
-	GroupEmployerName        XON
+	GroupName                []XON
+	GroupEmployerId          []CX
+	GroupEmployerName        []XON
 	PlanEffectiveDate        string
 	PlanExpirationDate       string
 	AuthorizationInformation CM_AUI
 	PlanType                 string
-	InsuredName              XPN
+	InsuredName              []XPN
 	RelationshipToPatient    string
 	InsuredDOB               string
-	InsuredAddress           XAD
+	InsuredAddress           []XAD
 	AOB                      string
 	COB                      string
 	COBPriority              string
@@ -88,7 +88,7 @@ type IN1 struct {
 	ReleaseInformationCode   string
 	PAC                      string
 	VerificationDateTime     string
-	VerificationBy           XCN
+	VerificationBy           []XCN
 	AgreementCode            string
 	BillingStatus            string
 	LifetimeReserveDays      string
@@ -102,7 +102,7 @@ type IN1 struct {
 	RoomRatePrivate          CP
 	InsuredEmploymentStatus  CE
 	InsuredSex               string
-	InsuredEmployerAddress   XAD
+	InsuredEmployerAddress   []XAD
 	VerificationStatus       string
 	PriorInsturancePlanId    string
 	CoverageType             string
@@ -113,13 +113,13 @@ type IN1 struct {
 type IN2 struct {
 	InsuredEmployeeId                  CX
 	InsuredSSN                         string
-	InsuredEmployerName                XCN
+	InsuredEmployerName                []XCN
 	EmployerInformationData            string
 	MailClaimParty                     string
 	MedicareCardNumber                 string
-	MedicaidCaseName                   XPN
+	MedicaidCaseName                   []XPN
 	MedicaidCaseNumber                 string
-	ChampuSponsorName                  XPN
+	ChampuSponsorName                  []XPN
 	ChampusIdNumber                    string
 	ChampusDependentRecipient          CE
 	ChampusOrganization                string
@@ -132,25 +132,25 @@ type IN2 struct {
 	BabyCoverage                       string
 	CombineBabyBill                    string
 	BloodDeductible                    string
-	SpecialCoverageApprovalName        XPN
+	SpecialCoverageApprovalName        []XPN
 	SpecialCoverageApprovalTitle       string
 	NoncoveredInsuranceCode            string
-	PayorId                            CX
-	PayorSubscriberId                  CX
+	PayorId                            []CX
+	PayorSubscriberId                  []CX
 	EligibilitySource                  string
-	RoomCoverageType                   CM_PLT
-	PolicyType                         CM_PLT
+	RoomCoverageType                   []CM_PLT
+	PolicyType                         []CM_PLT
 	DailyDeductible                    CM_DDE
 	LivingDependency                   string
 	AmbulatoryStatus                   string
-	Citizenship                        string
+	Citizenship                        []string
 	PrimaryLanguage                    CE
 	LivingArrangement                  string
 	PublicityIndicator                 CE
 	ProtectionIndicator                string
 	StudentIndicator                   string
 	Religion                           string
-	MotherMaidenName                   XPN
+	MotherMaidenName                   []XPN
 	Nationality                        CE
 	EthnicGroup                        string
 	MaritalStatus                      string
@@ -159,28 +159,28 @@ type IN2 struct {
 	JobTitle                           string
 	JobCode                            JCC
 	JobStatus                          string
-	EmployerContactName                XPN
-	EmployerContactPhoneNumber         XTN
+	EmployerContactName                []XPN
+	EmployerContactPhoneNumber         []XTN
 	EmployerContactReason              string
-	InsuredContactName                 XPN
-	InsuredContactPhoneNumbet          XTN
+	InsuredContactName                 []XPN
+	InsuredContactPhoneNumbet          []XTN
 	InsuredContactReason               string
 	RelationshipToPatientStartDate     string
 	RelationshipToPatientStopDate      string
 	InsuranceCompanyContactReason      string
-	InsuranceCompanyContactPhoneNumber XTN
+	InsuranceCompanyContactPhoneNumber []XTN
 	PolicyScope                        string
 	PolicySource                       string
-	PatientMemberNumber                CX
+	PatientMemberNumber                []CX
 	GuarantorRelationship              string
-	InsuredHomePhoneNumber             XTN
-	InsuredHomeWorkNumber              XTN
+	InsuredHomePhoneNumber             []XTN
+	InsuredHomeWorkNumber              []XTN
 	MilitaryHandicappedProgram         CE
 	SuspendFlag                        string
 	CopayLimitFlag                     string
 	StoplossLimitFlag                  string
-	InsuredOrganizationName            XON
-	InsuredEmployerOrganizationName    XON
+	InsuredOrganizationName            []XON
+	InsuredEmployerOrganizationName    []XON
 	Race                               string
 	HcfaPatientRelationshipToInsured   CE
 }
@@ -201,16 +201,16 @@ type IN3 struct {
 	NonConcurEffectiveDateTime         string
 	PhysicianReviewer                  XCN
 	CertificationContact               string
-	CertificationContactPhoneNumber    XTN
+	CertificationContactPhoneNumber    []XTN
 	AppealReason                       CE
 	CertificationAgency                CE
-	CertificationAgencyPhoneNumber     XTN
-	PreCertRequirementWindow           CM_PCR
+	CertificationAgencyPhoneNumber     []XTN
+	PreCertRequirementWindow           []CM_PCR
 	CaseManager                        string
 	SecondOpinionDate                  string
 	SecondOpinionStatus                string
 	SecondOpinionDocumentationReceived string
-	SecondOpinionPhysician             XCN
+	SecondOpinionPhysician             []XCN
 }
 
 type DG1 struct {
@@ -229,7 +229,7 @@ type DG1 struct {
 	OutlierCost             CP
 	GoruperVersion          string
 	Priority                string
-	DiagnosingClinician     XCN
+	DiagnosingClinician     []XCN
 	Classification          string
 	ConfidentialIndicator   string
 	AttestationDateTime     string
@@ -55,7 +55,7 @@ type ObsPatientGroup struct {
 }
 
 type ObsOrderGroup struct {
-	ORC         ORC `hl7:"ORC,required"`
+	ORC         ORC `hl7:"ORC"`
 	OBR         OBR `hl7:"OBR,required"`
 	NTE         []NTE
 	Observation []ObservationGroup `hl7:"group"`
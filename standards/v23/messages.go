@@ -1,5 +1,11 @@
 package v23
 
+type ACK struct {
+	MSH MSH
+	MSA MSA
+	ERR ERR
+}
+
 type ORM_O01 struct {
 	MSH          MSH
 	NTE          NTE
@@ -10,5 +16,5 @@ type ORM_O01 struct {
 type ORU_R01 struct {
 	MSH     MSH
 	Results []ResultGroup `hl7:"group"`
-	DCS     DSC
+	DCS     DSC           `hl7:"DSC"`
 }
@@ -5,10 +5,10 @@ type OBX struct {
 	ValueType                    string
 	ObservationIdentifier        CE
 	ObservationSubId             string
-	ObservationValue             string
+	ObservationValue             []string
 	Units                        CE
 	ReferencesRange              string
-	AbnormalFlags                string
+	AbnormalFlags                []string
 	Probability                  string
 	AbnormalTestNature           string
 	ResultStatus                 string
@@ -17,5 +17,5 @@ type OBX struct {
 	ObservationDateTime          string
 	ProducerId                   CE
 	ResponsibleObserver          XCN
-	ObservationMethod            CE
+	ObservationMethod            []CE
 }
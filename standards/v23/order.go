@@ -14,7 +14,7 @@ type ORC struct {
 	VerifiedBy             XCN
 	OrderingProvider       XCN
 	EntryLocation          PL
-	CallbackPhoneNumber    XTN
+	CallbackPhoneNumber    []XTN
 	EffectiveDateTime      string
 	OrderControlCodeReason CE
 	EnteringOrganization   CE
@@ -32,14 +32,14 @@ type OBR struct {
 	ObservationDateTime                string
 	ObservationEndDateTime             string
 	CollectionVolume                   CQ
-	CollectorIdentifier                XCN
+	CollectorIdentifier                []XCN
 	SpecimenActionCode                 string
 	DangerCode                         CE
 	RelevantClinicalInfo               string
 	SpecimenReceivedDateTime           string
 	SpecimenSource                     CM_SPE
-	OrderingProvider                   XCN
-	OrderCallbackPhoneNumber           XTN
+	OrderingProvider                   []XCN
+	OrderCallbackPhoneNumber           []XTN
 	PlacerField1                       string
 	PlacerField2                       string
 	FillerField1                       string
@@ -50,20 +50,20 @@ type OBR struct {
 	ResultStatus                       string
 	ParentResult                       CM_PRE
 	QuantityTiming                     string
-	ResultCopiesTo                     XCN
+	ResultCopiesTo                     []XCN
 	Parent                             CM_POR
 	TransportationMode                 string
-	ReasonForStudy                     CE
+	ReasonForStudy                     []CE
 	PrincipalResultInterpreter         CM_OBS
-	AssistantResultInterpreter         CM_OBS
-	Technician                         CM_OBS
-	Transcriptionist                   CM_OBS
+	AssistantResultInterpreter         []CM_OBS
+	Technician                         []CM_OBS
+	Transcriptionist                   []CM_OBS
 	ScheduledDateTime                  string
 	SampleContainersCount              string
 	SampleTransportLogistics           CE
-	CollectorComment                   CE
+	CollectorComment                   []CE
 	TransportArrangementResponsibility CE
 	TransportArranged                  string
 	EscortRequired                     string
-	PlannedPatientTransportComment     CE
+	PlannedPatientTransportComment     []CE
 }
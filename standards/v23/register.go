@@ -0,0 +1,9 @@
+package v23
+
+import "github.com/s-hammon/hl7/standards"
+
+func init() {
+	standards.Register("2.3", "ACK", ACK{})
+	standards.Register("2.3", "ORM^O01", ORM_O01{})
+	standards.Register("2.3", "ORU^R01", ORU_R01{})
+}
@@ -236,3 +236,10 @@ type TQ struct {
 	Text          string
 	Conjunction   string
 }
+
+type CM_ELD struct {
+	SegmentId            string
+	Sequence             string
+	FieldPosition        string
+	CodeIdentifyingError CE
+}
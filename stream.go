@@ -0,0 +1,257 @@
+package hl7
+
+import (
+	"bytes"
+	"io"
+)
+
+// A Decoder reads and decodes HL7 messages from an input stream. The input
+// may hold any number of back-to-back messages; each one starts with an MSH
+// segment at the beginning of a line. Segments may be terminated by CR, LF
+// or CRLF. The FHS, BHS, BTS and FTS segments of a batch file between
+// messages are skipped; use ParseFile to read them.
+type Decoder struct {
+	r    io.Reader
+	opts UnmarshalOptions
+
+	buf     []byte
+	scanp   int   // start of unread data in buf
+	scanned int64 // amount of data already scanned and dropped from buf
+	err     error
+
+	d      decodeState
+	msg    []byte // the current message, normalized to CR terminators
+	offset int64  // input offset of the current message
+}
+
+// NewDecoder returns a new decoder that reads from r, which may be nil if
+// the decoder is given its input by Reset.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r, offset: -1, d: decodeState{reuse: true}}
+}
+
+// Reset makes dec decode the messages in data instead of what remains of
+// its input, keeping its options, registered segment types and the
+// buffers it decodes messages with. data takes the place of the buffer
+// dec read its input into, which is let go, and must not be modified
+// while dec reads from it. Reset lets one Decoder serve every message a
+// connection delivers:
+//
+//	for frame := range frames {
+//		dec.Reset(frame)
+//		if err := dec.Decode(&m); err != nil {
+//			// ...
+//		}
+//	}
+func (dec *Decoder) Reset(data []byte) {
+	dec.r = nil
+	dec.buf = data
+	dec.scanp = 0
+	dec.scanned = 0
+	dec.err = io.EOF
+	dec.offset = -1
+}
+
+// SetOptions configures how subsequent messages are decoded.
+func (dec *Decoder) SetOptions(o UnmarshalOptions) {
+	dec.opts = o
+}
+
+// Decode reads the next message from its input and stores it in the value
+// pointed to by v, the same way Unmarshal does. It returns io.EOF once the
+// input is exhausted. A *Message is given its own copy of the message text.
+//
+// Unlike Unmarshal, Decode first clears a struct v, so that it holds the
+// new message alone, and then fills its slices from the memory they
+// already have. Decoding every message into the same value allocates
+// little once the value has grown to fit, but a slice kept from one
+// message is overwritten by the next.
+//
+// A message that fails to decode has still been consumed, so calling
+// Decode again moves on to the message after it; MessageOffset reports
+// where the failed message started.
+func (dec *Decoder) Decode(v any) error {
+	msg, err := dec.next()
+	if err != nil {
+		return err
+	}
+
+	if m, ok := v.(*Message); ok && m != nil {
+		// msg is overwritten by the next call; the Message needs its own
+		// copy to point into.
+		return dec.opts.parseMessage(m, bytes.Clone(msg))
+	}
+
+	dec.d.opts = dec.opts
+	dec.d.init(msg)
+	if dec.d.savedError != nil {
+		return dec.d.err()
+	}
+
+	return dec.d.unmarshal(v)
+}
+
+// More reports whether there is another message in the input.
+func (dec *Decoder) More() bool {
+	return dec.skip() == nil
+}
+
+// MessageOffset returns the byte offset in the input of the start of the
+// message most recently read by Decode, or -1 before the first call.
+func (dec *Decoder) MessageOffset() int64 {
+	return dec.offset
+}
+
+// next reads the next message into dec.msg.
+func (dec *Decoder) next() ([]byte, error) {
+	if err := dec.skip(); err != nil {
+		return nil, err
+	}
+	dec.offset = dec.scanned + int64(dec.scanp)
+
+	// A message ends where the next line starting with MSH begins. Skip
+	// the current message's own header when searching for it.
+	from := dec.scanp + 3
+	for {
+		if from < len(dec.buf) {
+			if i := nextHeader(dec.buf[from:]); i >= 0 {
+				end := from + i
+				dec.normalize(dec.buf[dec.scanp:end])
+				dec.scanp = end
+				return dec.msg, nil
+			}
+			// keep enough bytes to match a header split across reads
+			from = max(from, len(dec.buf)-3)
+		}
+
+		if dec.err != nil {
+			end := len(dec.buf)
+			dec.normalize(dec.buf[dec.scanp:end])
+			dec.scanp = end
+			return dec.msg, nil
+		}
+
+		from -= dec.scanp
+		dec.refill()
+		from += dec.scanp
+	}
+}
+
+// nextHeader returns the index of the first MSH segment, or segment of a
+// batch envelope, that follows a segment terminator in p, or -1.
+func nextHeader(p []byte) int {
+	for i := 0; ; {
+		j := bytes.IndexAny(p[i:], "\r\n")
+		if j < 0 {
+			return -1
+		}
+		i += j + 1
+		if len(p)-i < 3 {
+			return -1
+		}
+		if name := string(p[i : i+3]); name == "MSH" || isEnvelopeSegment(name) {
+			return i
+		}
+	}
+}
+
+// isEnvelopeSegment reports whether name is one of the segments that
+// enclose the messages of a batch file.
+func isEnvelopeSegment(name string) bool {
+	switch name {
+	case "FHS", "FTS", "BHS", "BTS":
+		return true
+	}
+
+	return false
+}
+
+// normalize copies msg into dec.msg with normalizeTerminators.
+func (dec *Decoder) normalize(msg []byte) {
+	dec.msg = normalizeTerminators(dec.msg[:0], msg)
+}
+
+// normalizeTerminators appends msg to dst, rewriting LF and CRLF segment
+// terminators as CR and dropping trailing blank lines.
+func normalizeTerminators(dst, msg []byte) []byte {
+	for i, c := range msg {
+		if c == '\n' {
+			if i > 0 && msg[i-1] == '\r' {
+				continue
+			}
+			c = '\r'
+		}
+		dst = append(dst, c)
+	}
+
+	dst = bytes.TrimRight(dst, "\r")
+	return append(dst, '\r')
+}
+
+// skip advances past whatever comes between messages: whitespace and the
+// segments of a batch envelope. It returns io.EOF (or the read error) if
+// the input holds nothing else.
+func (dec *Decoder) skip() error {
+	for {
+		for ; dec.scanp < len(dec.buf); dec.scanp++ {
+			switch dec.buf[dec.scanp] {
+			case ' ', '\t', '\r', '\n':
+			default:
+				if !dec.skipEnvelope() {
+					return nil
+				}
+				dec.scanp--
+			}
+		}
+
+		if dec.err != nil {
+			return dec.err
+		}
+		dec.refill()
+	}
+}
+
+// skipEnvelope advances to the end of the line at dec.scanp if it is a
+// segment of a batch envelope, reporting whether it did.
+func (dec *Decoder) skipEnvelope() bool {
+	for len(dec.buf)-dec.scanp < 3 && dec.err == nil {
+		dec.refill()
+	}
+	if len(dec.buf)-dec.scanp < 3 || !isEnvelopeSegment(string(dec.buf[dec.scanp:dec.scanp+3])) {
+		return false
+	}
+
+	for {
+		if i := bytes.IndexAny(dec.buf[dec.scanp:], "\r\n"); i >= 0 {
+			dec.scanp += i
+			return true
+		}
+		if dec.err != nil {
+			dec.scanp = len(dec.buf)
+			return true
+		}
+		dec.refill()
+	}
+}
+
+func (dec *Decoder) refill() {
+	// Make room to read more into the buffer. First slide down data
+	// already consumed.
+	if dec.scanp > 0 {
+		dec.scanned += int64(dec.scanp)
+		n := copy(dec.buf, dec.buf[dec.scanp:])
+		dec.buf = dec.buf[:n]
+		dec.scanp = 0
+	}
+
+	const minRead = 512
+	if cap(dec.buf)-len(dec.buf) < minRead {
+		newBuf := make([]byte, len(dec.buf), 2*cap(dec.buf)+minRead)
+		copy(newBuf, dec.buf)
+		dec.buf = newBuf
+	}
+
+	n, err := dec.r.Read(dec.buf[len(dec.buf):cap(dec.buf)])
+	dec.buf = dec.buf[:len(dec.buf)+n]
+	dec.err = err
+}
@@ -0,0 +1,160 @@
+package hl7
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	v23 "github.com/s-hammon/hl7/proto/standards/v23"
+	v23s "github.com/s-hammon/hl7/standards/v23"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoder(t *testing.T) {
+	input := "MSH|^~\\&|App1|Fac1|||||ORU^R01|1\rPID|1||V1\r" +
+		"MSH|^~\\&|App2|Fac2|||||ORU^R01|2\nPID|1||V2\n\n" +
+		"MSH|^~\\&|App3|Fac3|||||ORU^R01|3\r\nPID|1||V3\r\n"
+
+	readers := map[string]func() io.Reader{
+		"whole":   func() io.Reader { return strings.NewReader(input) },
+		"onebyte": func() io.Reader { return iotest.OneByteReader(strings.NewReader(input)) },
+	}
+
+	for name, newReader := range readers {
+		t.Run(name, func(t *testing.T) {
+			dec := NewDecoder(newReader())
+			require.Equal(t, int64(-1), dec.MessageOffset())
+
+			var offsets []int64
+			var ids []string
+			for dec.More() {
+				var m v23.ORU_R01
+				require.NoError(t, dec.Decode(&m))
+				offsets = append(offsets, dec.MessageOffset())
+				ids = append(ids, m.MSH.ControlId+"/"+m.Results[0].PID.InternalPatientId[0].Id)
+			}
+
+			require.Equal(t, []string{"1/V1", "2/V2", "3/V3"}, ids)
+			require.Equal(t, []int64{
+				0,
+				int64(strings.Index(input, "MSH|^~\\&|App2")),
+				int64(strings.Index(input, "MSH|^~\\&|App3")),
+			}, offsets)
+			require.ErrorIs(t, dec.Decode(&v23.ORU_R01{}), io.EOF)
+		})
+	}
+}
+
+func TestDecoder_SkipBadMessage(t *testing.T) {
+	input := "MSH|^~\\&|App1\rPID|1||V1\r" +
+		"garbage\rMSH|^~\\&|App2\rPID|1||V2\r"
+
+	dec := NewDecoder(strings.NewReader(input))
+
+	var m map[string]any
+	require.NoError(t, dec.Decode(&m))
+	require.NoError(t, dec.Decode(&m))
+	require.Equal(t, "App2", m["MSH"].(map[int]any)[3])
+	require.ErrorIs(t, dec.Decode(&m), io.EOF)
+
+	input = "MSH|^~\\&|App1\rPID|1||V1\r" +
+		"MSH|^\r" +
+		"\rMSH|^~\\&|App3\rPID|1||V3\r"
+
+	dec = NewDecoder(strings.NewReader(input))
+	require.NoError(t, dec.Decode(&m))
+
+	err := dec.Decode(&m)
+	require.Error(t, err)
+	require.Equal(t, int64(len("MSH|^~\\&|App1\rPID|1||V1\r")), dec.MessageOffset())
+
+	require.NoError(t, dec.Decode(&m))
+	require.Equal(t, "App3", m["MSH"].(map[int]any)[3])
+	require.False(t, dec.More())
+}
+
+func TestDecoder_ORU(t *testing.T) {
+	var input bytes.Buffer
+	for range 3 {
+		input.Write(bytes.ReplaceAll(oruMsg, []byte("\r"), []byte("\r\n")))
+		input.WriteString("\r\n")
+	}
+
+	dec := NewDecoder(&input)
+	n := 0
+	for dec.More() {
+		var m v23.ORU_R01
+		require.NoError(t, dec.Decode(&m))
+		require.Len(t, m.Results[0].Order[0].Observation, 39)
+		require.Equal(t, []string{"Signed on 4/4/2025 3:25 PM by Julie M Farkas, M.D."}, m.Results[0].Order[0].Observation[37].OBX.ObservationValue)
+		n++
+	}
+	require.Equal(t, 3, n)
+}
+
+func TestDecoder_Reset(t *testing.T) {
+	dec := NewDecoder(nil)
+	var m v23s.ORU_R01
+
+	dec.Reset(oruMsg)
+	require.NoError(t, dec.Decode(&m))
+	require.ErrorIs(t, dec.Decode(&m), io.EOF)
+	obs := m.Results[0].Order[0].Observation
+	require.Len(t, obs, 39)
+
+	dec.Reset([]byte("MSH|^~\\&|App|Fac|||||ORU^R01|2|P|2.3\r" +
+		"PID|||V2\r" +
+		"ORC|RE\r" +
+		"OBR|1\r" +
+		"OBX|1|ST|||first\r" +
+		"OBX|2|ST\r"))
+	require.NoError(t, dec.Decode(&m))
+	require.Equal(t, int64(0), dec.MessageOffset())
+
+	// the new message alone, in the memory of the old one
+	require.Equal(t, "2", m.MSH.ControlId)
+	require.Empty(t, m.MSH.ReceivingApplication)
+	require.Equal(t, "V2", m.Results[0].PID.InternalPatientId[0].Id)
+	require.Empty(t, m.Results[0].PID.PatientName)
+	got := m.Results[0].Order[0].Observation
+	require.Len(t, got, 2)
+	require.Same(t, &obs[0], &got[0])
+	require.Equal(t, []string{"first"}, got[0].OBX.ObservationValue)
+	require.Equal(t, v23s.OBX{SetId: "2", ValueType: "ST"}, got[1].OBX)
+
+	// a Decoder reading from a stream can be reset too
+	dec = NewDecoder(strings.NewReader(string(oruMsg)))
+	dec.Reset(oruMultipleOrdersMsg)
+	require.NoError(t, dec.Decode(&m))
+	require.Equal(t, "BANANA", m.Results[0].PID.PatientName[0].FamilyName)
+	require.False(t, dec.More())
+}
+
+func TestDecoder_ResetAllocs(t *testing.T) {
+	dec := NewDecoder(nil)
+	var m v23s.ORU_R01
+	allocs := testing.AllocsPerRun(10, func() {
+		dec.Reset(oruMsg)
+		if err := dec.Decode(&m); err != nil {
+			t.Fatal(err)
+		}
+	})
+	// the one conversion of the message text to a string
+	require.LessOrEqual(t, allocs, 1.0)
+}
+
+func BenchmarkDecoder_Reset(b *testing.B) {
+	dec := NewDecoder(nil)
+	var m v23s.ORU_R01
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(oruMultipleOrdersMsg)))
+	for b.Loop() {
+		dec.Reset(oruMultipleOrdersMsg)
+		if err := dec.Decode(&m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
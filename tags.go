@@ -1,8 +1,10 @@
 package hl7
 
 import (
+	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 type tagOptions string
@@ -15,6 +17,18 @@ func (o tagOptions) Group() bool {
 	return o.Contains("group")
 }
 
+// Date reports whether a time.Time field holds an HL7 DT value, which is
+// written without a time of day.
+func (o tagOptions) Date() bool {
+	return o.Contains("date")
+}
+
+// Unknown reports whether a []RawSegment field collects the segments that
+// no other field of its message or group takes.
+func (o tagOptions) Unknown() bool {
+	return o.Contains("unknown")
+}
+
 func (o tagOptions) Optional() bool {
 	return !o.Required()
 }
@@ -46,7 +60,7 @@ func parseTag(tag string) hl7Tag {
 		switch name {
 		default:
 			return hl7Tag{Name: name}
-		case "group", "required":
+		case "group", "required", "date", "unknown":
 			return hl7Tag{
 				Options: tagOptions(name),
 			}
@@ -66,3 +80,80 @@ func (t hl7Tag) Index() (int, bool) {
 
 	return n, true
 }
+
+// compositeField maps a field of a segment or composite struct to its HL7
+// index.
+type compositeField struct {
+	index  int // index of the Go struct field
+	hl7Idx int
+	tag    hl7Tag
+}
+
+var compositeFieldCache sync.Map // map[reflect.Type][]compositeField
+
+// cachedCompositeFields is like compositeFields but computes the fields of
+// each type only once.
+func cachedCompositeFields(t reflect.Type) []compositeField {
+	if f, ok := compositeFieldCache.Load(t); ok {
+		return f.([]compositeField)
+	}
+	f, _ := compositeFieldCache.LoadOrStore(t, compositeFields(t))
+	return f.([]compositeField)
+}
+
+// compositeFields returns the exported fields of a segment or composite
+// struct type with their 1-based HL7 indexes. Untagged fields, and fields
+// whose tag carries only options (`hl7:",date"`), are numbered by
+// position; a numeric tag name sets the index explicitly and does not
+// advance the positional count. Fields with any other tag name are left
+// out.
+func compositeFields(t reflect.Type) []compositeField {
+	out := make([]compositeField, 0, t.NumField())
+
+	idx := 1
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+
+		f := compositeField{index: i}
+		raw, tagged := sf.Tag.Lookup("hl7")
+		if tagged {
+			f.tag = parseTag(raw)
+		}
+
+		switch n, ok := f.tag.Index(); {
+		case f.tag.Name == "":
+			f.hl7Idx = idx
+			idx++
+		case ok:
+			f.hl7Idx = n
+		default:
+			continue
+		}
+
+		out = append(out, f)
+	}
+
+	return out
+}
+
+type implementsKey struct {
+	t, iface reflect.Type
+}
+
+var implementsCache sync.Map // map[implementsKey]bool
+
+// cachedImplements reports whether t implements the interface type iface,
+// remembering the answer for each pair of types.
+func cachedImplements(t, iface reflect.Type) bool {
+	key := implementsKey{t, iface}
+	if ok, found := implementsCache.Load(key); found {
+		return ok.(bool)
+	}
+
+	ok := t.Implements(iface)
+	implementsCache.Store(key, ok)
+	return ok
+}